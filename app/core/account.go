@@ -4,6 +4,14 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"time"
+)
+
+// Available account roles.
+const (
+	RoleUser   = "user"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
 )
 
 type Account struct {
@@ -11,14 +19,33 @@ type Account struct {
 	Phone    string `json:"phone" binding:"required,e164,lowercase"`
 	Password string `json:"password" binding:"required,min=8,max=255,ascii"`
 	Age      int    `json:"age" binding:"required,gte=1,lte=120"`
-	Role     string `jsdon:"role" binding:"required,lowercase,checkRole"`
-	Created  string `json:"created"`
-	Modified string `json:"modified"`
+	// The role is never taken from the request, the new accounts are always users.
+	Role     string `json:"-"`
+	Disabled bool   `json:"-"`
+	// Whether the account proved it owns the phone with the code sent by SMS.
+	PhoneVerified bool   `json:"-"`
+	Created       string `json:"created"`
+	Modified      string `json:"modified"`
+}
+
+// The account as it is shown to the admin and to its owner, the password hash is never exposed.
+// The age 0 means it is unknown: the accounts created by the single sign-on have none until the user sets it.
+type AccountInfo struct {
+	ID            string    `json:"id"`
+	Phone         string    `json:"phone"`
+	Age           int       `json:"age"`
+	Role          string    `json:"role"`
+	Disabled      bool      `json:"disabled"`
+	PhoneVerified bool      `json:"phone_verified"`
+	Created       time.Time `json:"created"`
+	Modified      time.Time `json:"modified"`
 }
 
 type TokenPair struct {
 	AccessToken  string
 	RefreshToken string
+	// The lifetime of the access token in seconds.
+	ExpiresIn int64 `json:"expires_in"`
 }
 
 var (
@@ -26,8 +53,13 @@ var (
 	ErrUserNotFound          = errors.New("user is not found with such credentials")
 	ErrWrongPassword         = errors.New("wrong passord")
 	ErrContexAccountNotFound = errors.New("no account found in contex")
+	ErrPasswordTooLong       = errors.New("the password is too long for the hashing algorithm")
+	ErrAccountDisabled       = errors.New("the account is disabled")
+	ErrSelfManagement        = errors.New("the admin can't change the own role or disable the own account")
 )
 
+// The legacy password hash with one global salt. It is kept only to verify the passwords
+// of the accounts which are not logged in since the adaptive hashing was introduced.
 func SHA256(password, salt string) string {
 	sum := sha256.Sum256([]byte(password + salt))
 
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The API key lets the services and the dashboards call the API without the account login.
+// Only the hash of the key is stored, the key itself is shown once when it is created.
+type APIKey struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// The beginning of the key which tells the keys apart.
+	Prefix    string       `json:"prefix"`
+	Scopes    []Permission `json:"scopes"`
+	CreatedBy string       `json:"created_by"`
+	// The nil time means the key never expires.
+	Expires  *time.Time `json:"expires"`
+	LastUsed *time.Time `json:"last_used"`
+	Revoked  *time.Time `json:"revoked"`
+	Created  time.Time  `json:"created"`
+}
+
+// The key which has just been created along with its secret.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyInput struct {
+	Name    string
+	Scopes  []Permission
+	Expires *time.Time
+}
+
+var (
+	// The API key is granted only the permissions which don't act on behalf of an account.
+	apiKeyPermissions = []Permission{PermCatalogueRead, PermMovieWrite, PermDirectorWrite}
+
+	ErrAPIKeyNotFound  = errors.New("api key is not found")
+	ErrInvalidAPIKey   = errors.New("invalid api key")
+	ErrAPIKeyExpired   = errors.New("api key has expired")
+	ErrAPIKeyRevoked   = errors.New("api key is revoked")
+	ErrScopeNotAllowed = errors.New("the permission can't be granted to the api key")
+	ErrExpiryInThePast = errors.New("the expiry should be in the future")
+	ErrNoScopes        = errors.New("the api key should be granted at least one scope")
+)
+
+// Checks every scope is the known permission which can be granted to the API key.
+// The key without scopes can't call anything, so it is refused.
+func (i APIKeyInput) Validate(now time.Time) error {
+	if len(i.Scopes) == 0 {
+		return ErrNoScopes
+	}
+
+	for _, scope := range i.Scopes {
+		if !isKnownPermission(scope) {
+			return fmt.Errorf("%w: %q", ErrUnknownPermission, scope)
+		}
+
+		if !hasPermission(apiKeyPermissions, scope) {
+			return fmt.Errorf("%w: %q", ErrScopeNotAllowed, scope)
+		}
+	}
+
+	if i.Expires != nil && !i.Expires.After(now) {
+		return ErrExpiryInThePast
+	}
+
+	return nil
+}
+
+// Reports whether the key is granted the permission.
+func (k APIKey) Has(permission Permission) bool {
+	return hasPermission(k.Scopes, permission)
+}
+
+func hasPermission(permissions []Permission, permission Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -17,12 +18,46 @@ type Director struct {
 	BirthDate BirthDayType `json:"birth_date" binding:"required"  db:"birth_date"  `
 	Created   string       `json:"created"  db:"created"`
 	Modified  string       `json:"modified"  db:"modified"`
+	PhotoURL  string       `json:"photo_url"  db:"photo_url"`
+	// The summary of the director movies, it is filled only for the single director.
+	Summary *DirectorSummary `json:"summary,omitempty" db:"-"`
+}
+
+// The aggregates of the director movies. The average rate is calculated among the rated movies,
+// the fields are null if there are no such movies.
+type DirectorSummary struct {
+	MoviesCount       int      `json:"movies_count"`
+	AvgRate           *float64 `json:"avg_rate"`
+	FirstReleaseYear  *int     `json:"first_release_year"`
+	LatestReleaseYear *int     `json:"latest_release_year"`
+	// Changes whenever any movie of the director is added, changed or removed.
+	Version string `json:"-"`
+}
+
+// The photo of the director which is uploaded to the blob store.
+type Photo struct {
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+// The maximum size of the director photo in bytes.
+const MaxPhotoSize = 5 << 20
+
+// The content types of the photo which are allowed to upload and the extensions of the stored objects.
+var PhotoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
 }
 
 var (
 	ErrDublicatDirector = errors.New("there is the director with such data")
 	ErrNowDirectorAdded = errors.New("no director added")
 	ErrNowDirectorFound = errors.New("no director found")
+	ErrDirectorHasMovie = errors.New("the director still has movies")
+	ErrUnsupportedPhoto = errors.New("the photo should be jpeg, png or webp image")
+	ErrPhotoTooLarge    = errors.New("the photo should not be larger than 5 MB")
 )
 
 // Custom unmarshal function, for the custom type BrithDayType,
@@ -50,3 +85,16 @@ func (b *BirthDayType) UnmarshalJSON(data []byte) error {
 func (b BirthDayType) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("\"%s\"", b.Time.Format("2006-01-02"))), nil
 }
+
+// Checks the content type and the size of the photo.
+func (p Photo) Validate() error {
+	if _, ok := PhotoExtensions[p.ContentType]; !ok {
+		return ErrUnsupportedPhoto
+	}
+
+	if p.Size > MaxPhotoSize {
+		return ErrPhotoTooLarge
+	}
+
+	return nil
+}
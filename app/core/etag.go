@@ -0,0 +1,46 @@
+package core
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Separates the modified timestamp and the version of the derived data in the entity tag.
+const etagVersionSeparator = "/"
+
+var (
+	ErrPreconditionFailed = errors.New("the entity was modified since it was read")
+	ErrMalformedETag      = errors.New("malformed entity tag")
+)
+
+// Returns the strong entity tag of the entity which is derived from its modified timestamp.
+func ETag(modified string) string {
+	return `"` + base64.RawURLEncoding.EncodeToString([]byte(modified)) + `"`
+}
+
+// Returns the entity tag of the representation which contains the data derived from other entities.
+// The version of that data is added to the modified timestamp, so the tag changes whenever the data does,
+// while ModifiedFromETag still returns only the timestamp for the conditional update.
+func VersionedETag(modified, version string) string {
+	return ETag(modified + etagVersionSeparator + version)
+}
+
+// Returns the modified timestamp which was encoded into the entity tag by the ETag or VersionedETag function.
+func ModifiedFromETag(etag string) (string, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+
+	if len(trimmed) < 2 || !strings.HasPrefix(trimmed, `"`) || !strings.HasSuffix(trimmed, `"`) {
+		return "", ErrMalformedETag
+	}
+
+	modified, err := base64.RawURLEncoding.DecodeString(trimmed[1 : len(trimmed)-1])
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrMalformedETag, err.Error())
+	}
+
+	timestamp, _, _ := strings.Cut(string(modified), etagVersionSeparator)
+
+	return timestamp, nil
+}
@@ -0,0 +1,42 @@
+package core
+
+import "time"
+
+// Everything the service holds about the account, it is given to the account owner on request.
+type AccountExport struct {
+	Exported   time.Time          `json:"exported"`
+	Account    AccountInfo        `json:"account"`
+	Sessions   []SessionInfo      `json:"sessions"`
+	Lists      []ListExport       `json:"lists"`
+	Ratings    []Rating           `json:"ratings"`
+	Identities []ExportedIdentity `json:"identities"`
+}
+
+// The list of the account together with all its movies.
+type ListExport struct {
+	MovieList
+	Movies []ExportedListMovie `json:"movies"`
+}
+
+type ExportedListMovie struct {
+	MovieID string `json:"movie_id" db:"movie_id"`
+	Title   string `json:"title" db:"title"`
+}
+
+// The number of the rows removed together with the account.
+type ErasureReport struct {
+	AccountID    string `json:"account_id"`
+	Sessions     int64  `json:"sessions"`
+	Lists        int64  `json:"lists"`
+	ListMovies   int64  `json:"list_movies"`
+	Ratings      int64  `json:"ratings"`
+	Identities   int64  `json:"identities"`
+	OneTimeCodes int64  `json:"one_time_codes"`
+}
+
+// The account of the identity provider linked to the account.
+type ExportedIdentity struct {
+	Issuer  string    `json:"issuer" db:"issuer"`
+	Subject string    `json:"subject" db:"subject"`
+	Created time.Time `json:"created" db:"created"`
+}
@@ -0,0 +1,20 @@
+package core
+
+// The public key of the access token signature in the JSON Web Key format (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	// The RSA public key.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// The Ed25519 public key.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// The set of the keys the access tokens can be verified with.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
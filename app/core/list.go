@@ -14,7 +14,43 @@ type MovieList struct {
 	Modified  string    `json:"modified" db:"modified"`
 }
 
+// The type of the list which is created by the system for every account.
+type ListType string
+
+const (
+	FavoriteList ListType = "favorite"
+	WishList     ListType = "wishlist"
+)
+
+// The system lists of the account, they can't be renamed or deleted.
+var SystemListTypes = []ListType{FavoriteList, WishList}
+
+// Reports whether the list type is one of the system list types.
+func IsSystemListType(listType string) bool {
+	for _, systemType := range SystemListTypes {
+		if string(systemType) == listType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The movie of the list with the name of its director.
+type ListMovie struct {
+	Movie
+	DirectorName string `json:"director_name" db:"director_name"`
+}
+
+// The list with the page of its movies.
+type MovieListDetails struct {
+	MovieList
+	Movies []ListMovie `json:"movies"`
+}
+
 var (
+	ErrListNotFound        = errors.New("no list found")
+	ErrSystemList          = errors.New("the system list can't be renamed or deleted")
 	ErrDuplicateRow        = errors.New("such record already exists")
 	ErrEmptyMovieListType  = errors.New("the movie list type should't be empty")
 	ErrEpmtryMovieID       = errors.New("the movie ID should't be empty")
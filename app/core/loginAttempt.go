@@ -0,0 +1,31 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The failed logins of the phone or of the client IP.
+type LoginAttempts struct {
+	Key         string
+	Failures    int
+	LastFailure time.Time
+	// The zero time means the login is not locked.
+	LockedUntil time.Time
+}
+
+var ErrLoginLocked = errors.New("too many failed login attempts")
+
+// The login is locked after too many failures, it can be tried again after RetryAfter.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e LoginLockedError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrLoginLocked, e.RetryAfter)
+}
+
+func (e LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
@@ -5,15 +5,17 @@ import (
 )
 
 type Movie struct {
-	ID          string `json:"id" db:"id"`
-	Title       string `json:"title" binding:"required,min=1" db:"title"`
-	Genre       string `json:"genre" binding:"required" db:"genre"`
-	DirectorID  string `json:"director_id" binding:"required" db:"director_id"`
-	Rate        int    `json:"rate" binding:"gte=0,lte=10" db:"rate"`
-	ReleaseDate string `json:"release_date" binding:"required" db:"release_date"`
-	Duration    int    `json:"duration" binding:"gte=1" db:"duration"`
-	Created     string `json:"created" db:"created"`
-	Modified    string `json:"modified" db:"modified"`
+	ID          string  `json:"id" db:"id"`
+	Title       string  `json:"title" binding:"required,min=1" db:"title"`
+	Genre       string  `json:"genre" binding:"required" db:"genre"`
+	DirectorID  string  `json:"director_id" binding:"required" db:"director_id"`
+	Rate        int     `json:"rate" binding:"gte=0,lte=10" db:"rate"`
+	ReleaseDate string  `json:"release_date" binding:"required" db:"release_date"`
+	Duration    int     `json:"duration" binding:"gte=1" db:"duration"`
+	AvgRate     float64 `json:"avg_rate" db:"avg_rate"`
+	Votes       int     `json:"votes" db:"votes"`
+	Created     string  `json:"created" db:"created"`
+	Modified    string  `json:"modified" db:"modified"`
 }
 
 var (
@@ -21,6 +23,7 @@ var (
 	ErrUniqueMovie      = errors.New("dublicating the movie title with the such director")
 	ErrNowMovieAdd      = errors.New("no movie added")
 	ErrNotFound         = errors.New("nothing was found")
+	ErrMovieInLists     = errors.New("the movie is still referenced by the lists")
 )
 
 type MovieCSV struct {
@@ -31,4 +34,6 @@ type MovieCSV struct {
 	Rate         int      `csv:"Rate" db:"rate"`
 	ReleaseDate  DateTime `csv:"Release_Date" db:"release_date"`
 	Duration     int      `csv:"Duration/Min" db:"duration"`
+	AvgRate      float64  `csv:"Average_Rate" db:"avg_rate"`
+	Votes        int      `csv:"Votes" db:"votes"`
 }
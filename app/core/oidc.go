@@ -0,0 +1,35 @@
+package core
+
+import (
+	"errors"
+	"time"
+)
+
+// The account at the OpenID Connect provider, the subject is unique within the issuer.
+type ExternalIdentity struct {
+	Issuer  string
+	Subject string
+	// The phone in the E.164 format if the provider shared it.
+	Phone         string
+	PhoneVerified bool
+}
+
+// The login started at the identity provider. It is finished by the callback with the same state
+// and proves the code is exchanged by the same client with the PKCE code verifier.
+type OIDCLogin struct {
+	State        string
+	CodeVerifier string
+	Nonce        string
+	Expired      time.Time
+	Created      time.Time
+}
+
+var (
+	ErrOIDCDisabled       = errors.New("the single sign-on is not configured")
+	ErrOIDCLoginNotFound  = errors.New("the single sign-on login is not found or expired")
+	ErrOIDCAuthFailed     = errors.New("the identity provider login failed")
+	ErrOIDCPhoneRequired  = errors.New("the identity provider didn't share the verified phone")
+	ErrOIDCPhoneNotLinked = errors.New("the account with the phone exists, verify its phone to link the single sign-on")
+	ErrIdentityNotFound   = errors.New("no account is linked to the identity")
+	ErrDuplicateIdentity  = errors.New("the identity is already linked to the account")
+)
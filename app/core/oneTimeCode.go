@@ -0,0 +1,33 @@
+package core
+
+import (
+	"errors"
+	"time"
+)
+
+// What the one-time code confirms.
+type CodePurpose string
+
+const (
+	PhoneVerification CodePurpose = "phone_verification"
+	PasswordReset     CodePurpose = "password_reset"
+)
+
+// The code sent by SMS. Only the hash of the code is stored.
+type OneTimeCode struct {
+	AccountID string
+	Purpose   CodePurpose
+	CodeHash  string
+	// The number of the confirmations tried with the code, including the current one.
+	Attempts int
+	Expired  time.Time
+	Created  time.Time
+}
+
+var (
+	ErrInvalidCode      = errors.New("the code is invalid")
+	ErrCodeExpired      = errors.New("the code is expired")
+	ErrTooManyAttempts  = errors.New("too many attempts, request a new code")
+	ErrCodeNotFound     = errors.New("no code found")
+	ErrPhoneNotVerified = errors.New("the phone is not verified")
+)
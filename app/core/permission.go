@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The permission allows the group of the actions, the roles are granted the sets of the permissions.
+type Permission string
+
+// Available permissions.
+const (
+	PermMovieWrite    Permission = "movie:write"
+	PermDirectorWrite Permission = "director:write"
+	PermAccountManage Permission = "account:manage"
+	PermListModerate  Permission = "list:moderate"
+	PermCatalogueRead Permission = "catalogue:read"
+)
+
+var (
+	knownPermissions = []Permission{
+		PermMovieWrite, PermDirectorWrite, PermAccountManage, PermListModerate, PermCatalogueRead,
+	}
+
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrMissingRole       = errors.New("the required role is not defined")
+)
+
+// The permissions granted to each role.
+type RolePermissions map[string][]Permission
+
+// Returns the roles used when the config doesn't define them.
+// The editor manages the catalogue, but not the accounts.
+func DefaultRolePermissions() RolePermissions {
+	return RolePermissions{
+		RoleUser:   {PermCatalogueRead},
+		RoleEditor: {PermCatalogueRead, PermMovieWrite, PermDirectorWrite},
+		RoleAdmin:  {PermCatalogueRead, PermMovieWrite, PermDirectorWrite, PermAccountManage, PermListModerate},
+	}
+}
+
+// Builds the role permissions from the names of the permissions. The roles of the new accounts
+// and of the bootstrapped admin must be defined, and every permission must be known.
+// Every role reads the catalogue, only the API keys are limited to it by the scope.
+func NewRolePermissions(roles map[string][]string) (RolePermissions, error) {
+	rolePermissions := make(RolePermissions, len(roles))
+
+	for role, names := range roles {
+		permissions := make([]Permission, 0, len(names)+1)
+		permissions = append(permissions, PermCatalogueRead)
+
+		for _, name := range names {
+			if !isKnownPermission(Permission(name)) {
+				return nil, fmt.Errorf("role %q: %w: %q", role, ErrUnknownPermission, name)
+			}
+
+			permissions = append(permissions, Permission(name))
+		}
+
+		rolePermissions[role] = permissions
+	}
+
+	for _, role := range []string{RoleUser, RoleAdmin} {
+		if _, ok := rolePermissions[role]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrMissingRole, role)
+		}
+	}
+
+	return rolePermissions, nil
+}
+
+// Reports whether the role is granted the permission.
+func (r RolePermissions) Has(role string, permission Permission) bool {
+	return hasPermission(r[role], permission)
+}
+
+func (r RolePermissions) HasRole(role string) bool {
+	_, ok := r[role]
+
+	return ok
+}
+
+func isKnownPermission(permission Permission) bool {
+	return hasPermission(knownPermissions, permission)
+}
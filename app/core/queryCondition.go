@@ -14,11 +14,11 @@ import (
 var (
 	minOffset               = 0
 	maxOffset               = 1000
-	minRate                 = 0
-	maxRate                 = 10
+	minRate                 = 0.0
+	maxRate                 = 10.0
 	allowedLimitVal         = []string{"20", "50", "100"}
-	allowedFilterKey        = []string{"genre", "rate", "type", "account_id"}
-	allowedSortKey          = []string{"rate", "release_date", "duration"}
+	allowedFilterKey        = []string{"genre", "rate", "type", "account_id", "name", "name_prefix", "phone", "role", "disabled"}
+	allowedSortKey          = []string{"rate", "release_date", "duration", "votes", "name", "birth_date", "phone", "created"}
 	allowedSortValue        = []string{"asc", "desc"}
 	allowedExportValue      = []string{"csv", "none"}
 	ErrUnallowedOffset      = errors.New("unallowed offset")
@@ -27,7 +27,9 @@ var (
 	ErrUnallowedLimit       = errors.New("unallowed limit")
 	ErrUnallowedExportValue = errors.New("unallowed export value")
 	ErrUnallowedRateValue   = errors.New("unallowed rate value")
+	ErrUnallowedFilterValue = errors.New("unallowed filter value")
 	ErrUnkownConditionKey   = errors.New("condition has unknown parameters")
+	ErrMalformedCondition   = errors.New("the filter and the sort should be in the key:value form")
 )
 
 // ConditionParams represent request query params
@@ -58,25 +60,21 @@ func (cp *ConditionParams) Prepare(c *gin.Context) error {
 	cp.Export = c.Query("export")
 
 	for _, v := range c.QueryArray("f") {
-		keyval := strings.Split(v, ":")
-
-		var element QuerySliceElement
-
-		element.Key = keyval[0]
-		element.Val = keyval[1]
+		key, val, found := strings.Cut(v, ":")
+		if !found {
+			return fmt.Errorf("query preparetion failed: %q: %w", v, ErrMalformedCondition)
+		}
 
-		cp.Filter = append(cp.Filter, element)
+		cp.Filter = append(cp.Filter, QuerySliceElement{Key: key, Val: val})
 	}
 
 	for _, v := range c.QueryArray("s") {
-		keyval := strings.Split(v, ":")
-
-		var element QuerySliceElement
-
-		element.Key = keyval[0]
-		element.Val = keyval[1]
+		key, val, found := strings.Cut(v, ":")
+		if !found {
+			return fmt.Errorf("query preparetion failed: %q: %w", v, ErrMalformedCondition)
+		}
 
-		cp.Sort = append(cp.Sort, element)
+		cp.Sort = append(cp.Sort, QuerySliceElement{Key: key, Val: val})
 	}
 
 	cp.SetDefaultValues()
@@ -174,15 +172,21 @@ func (cp ConditionParams) Validate() error {
 			}
 
 			if elem.Key == "rate" {
-				i, err := strconv.Atoi(elem.Val)
+				rate, err := strconv.ParseFloat(elem.Val, 64)
 				if err != nil {
-					return fmt.Errorf("the value shlould be an integer: %w", err)
+					return fmt.Errorf("the value shlould be a number: %w", err)
 				}
 
-				if i < minRate || i > maxRate {
+				if rate < minRate || rate > maxRate {
 					return fmt.Errorf("the value should be in range from 1 to 10 :%w", ErrUnallowedRateValue)
 				}
 			}
+
+			if elem.Key == "disabled" {
+				if _, err := strconv.ParseBool(elem.Val); err != nil {
+					return fmt.Errorf("the disabled value should be a boolean: %w", ErrUnallowedFilterValue)
+				}
+			}
 		}
 	}
 
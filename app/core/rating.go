@@ -0,0 +1,39 @@
+package core
+
+import (
+	"errors"
+	"math"
+)
+
+type Rating struct {
+	AccountID string  `json:"account_id" db:"account_id"`
+	MovieID   string  `json:"movie_id" db:"movie_id"`
+	Score     float64 `json:"score" db:"score"`
+	Created   string  `json:"created" db:"created"`
+	Modified  string  `json:"modified" db:"modified"`
+}
+
+var (
+	ErrRatingNotFound  = errors.New("no rating found")
+	ErrUnallowedScore  = errors.New("the score should be in range from 0 to 10 with one decimal place")
+	minScore, maxScore = 0.0, 10.0
+)
+
+// Checks the score is in the allowed range and has no more than one decimal place.
+func (r Rating) Validate() error {
+	const (
+		decimalFactor = 10
+		epsilon       = 1e-9
+	)
+
+	if r.Score < minScore || r.Score > maxScore {
+		return ErrUnallowedScore
+	}
+
+	scaled := r.Score * decimalFactor
+	if math.Abs(scaled-math.Round(scaled)) > epsilon {
+		return ErrUnallowedScore
+	}
+
+	return nil
+}
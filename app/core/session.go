@@ -5,8 +5,12 @@ import (
 	"time"
 )
 
+// The session of one login. Every refresh rotates its refresh token,
+// and all the tokens issued for the session share its family id.
 type Session struct {
+	ID           string    `json:"id"`
 	RefreshToken string    `json:"refresh_token"`
+	FamilyID     string    `json:"family_id"`
 	AccountID    string    `json:"account_id"`
 	Role         string    `json:"role"`
 	RequestHost  string    `json:"request_host"`
@@ -16,9 +20,30 @@ type Session struct {
 	Created      time.Time `json:"created"`
 }
 
+// The session as it is shown to its owner, the refresh token is never exposed.
+type SessionInfo struct {
+	ID          string    `json:"id"`
+	RequestHost string    `json:"request_host"`
+	UserAgent   string    `json:"user_agent"`
+	ClientIP    string    `json:"client_ip"`
+	Created     time.Time `json:"created"`
+	Expired     time.Time `json:"expired"`
+	// The session of the access token the request was made with.
+	Current bool `json:"current"`
+}
+
+// The caller identity extracted from the access token.
+type Identity struct {
+	AccountID    string
+	Role         string
+	RefreshToken string
+}
+
 var (
 	ErrRefreshTokenExpired = errors.New("refresh token has expired")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
 	ErrSesseionNotFound    = errors.New("session is not found with such credentials")
+	ErrSessionRevoked      = errors.New("the session of the token is revoked")
 	ErrNotAuthenticated    = errors.New("unauthenticated")
 	ErrNoRowsEffected      = errors.New("no rows effected")
 )
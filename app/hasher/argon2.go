@@ -0,0 +1,117 @@
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var errInvalidArgon2Params = errors.New("invalid argon2id parameters")
+
+type Argon2Params struct {
+	// The memory in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// The argon2id hash is encoded in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>.
+type argon2idAlgorithm struct {
+	params Argon2Params
+}
+
+func newArgon2id(params Argon2Params) (argon2idAlgorithm, error) {
+	if params.Memory < 8*uint32(params.Parallelism) || params.Iterations < 1 || params.Parallelism < 1 ||
+		params.SaltLength < 8 || params.KeyLength < 16 {
+		return argon2idAlgorithm{}, fmt.Errorf("%w: %+v", errInvalidArgon2Params, params)
+	}
+
+	return argon2idAlgorithm{params: params}, nil
+}
+
+func (a argon2idAlgorithm) hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("can't generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt,
+		a.params.Iterations, a.params.Memory, a.params.Parallelism, a.params.KeyLength)
+
+	return encodeArgon2id(a.params, salt, key), nil
+}
+
+func (a argon2idAlgorithm) verify(password, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt,
+		params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func (a argon2idAlgorithm) owns(encoded string) bool {
+	return strings.HasPrefix(encoded, argon2idPrefix)
+}
+
+func (a argon2idAlgorithm) outdated(encoded string) bool {
+	params, _, _, err := decodeArgon2id(encoded)
+
+	return err != nil || params != a.params
+}
+
+func encodeArgon2id(params Argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2id(encoded string) (params Argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(encoded, argon2idPrefix), "$")
+
+	const partsCount = 4 // version, parameters, salt and key
+
+	if len(parts) != partsCount {
+		return Argon2Params{}, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: unsupported argon2 version", ErrMalformedHash)
+	}
+
+	_, err = fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil || params.Iterations < 1 || params.Parallelism < 1 {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: wrong argon2 parameters", ErrMalformedHash)
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: wrong salt encoding", ErrMalformedHash)
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(key) == 0 {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: wrong key encoding", ErrMalformedHash)
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,62 @@
+package hasher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// The bcrypt hash has the form $2a$<cost>$<salt and hash>, so the cost is kept in the hash itself.
+type bcryptAlgorithm struct {
+	cost int
+}
+
+func newBcrypt(cost int) (bcryptAlgorithm, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return bcryptAlgorithm{}, fmt.Errorf("bcrypt cost %d is outside allowed range [%d, %d]",
+			cost, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return bcryptAlgorithm{cost: cost}, nil
+}
+
+func (b bcryptAlgorithm) hash(password string) (string, error) {
+	encoded, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", core.ErrPasswordTooLong
+		}
+
+		return "", fmt.Errorf("bcrypt: %w", err)
+	}
+
+	return string(encoded), nil
+}
+
+func (b bcryptAlgorithm) verify(password, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("%w: %s", ErrMalformedHash, err.Error())
+	}
+
+	return true, nil
+}
+
+func (b bcryptAlgorithm) owns(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+func (b bcryptAlgorithm) outdated(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+
+	return err != nil || cost != b.cost
+}
@@ -0,0 +1,113 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+// Available password hashing algorithms.
+const (
+	Bcrypt   = "bcrypt"
+	Argon2id = "argon2id"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown password hashing algorithm")
+	ErrMalformedHash    = errors.New("malformed password hash")
+)
+
+// The algorithm of the password hashing. The encoded hash is self-describing,
+// so it records the algorithm together with the parameters it was produced with.
+type algorithm interface {
+	hash(password string) (string, error)
+	verify(password, encoded string) (bool, error)
+	// Reports whether the encoded hash belongs to the algorithm.
+	owns(encoded string) bool
+	// Reports whether the encoded hash was produced with other parameters than the current ones.
+	outdated(encoded string) bool
+}
+
+// Hasher hashes the new passwords with the configured algorithm and verifies
+// the passwords against the hashes of every known algorithm, including the legacy salted SHA-256.
+type Hasher struct {
+	primary    algorithm
+	algorithms []algorithm
+	legacySalt string
+}
+
+type Config struct {
+	// The algorithm of the new hashes. Available values: "bcrypt", "argon2id".
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+func New(cfg Config, legacySalt string) (Hasher, error) {
+	bcryptAlg, err := newBcrypt(cfg.BcryptCost)
+	if err != nil {
+		return Hasher{}, err
+	}
+
+	argon2Alg, err := newArgon2id(cfg.Argon2)
+	if err != nil {
+		return Hasher{}, err
+	}
+
+	h := Hasher{
+		algorithms: []algorithm{bcryptAlg, argon2Alg},
+		legacySalt: legacySalt,
+	}
+
+	switch cfg.Algorithm {
+	case Bcrypt:
+		h.primary = bcryptAlg
+	case Argon2id:
+		h.primary = argon2Alg
+	default:
+		return Hasher{}, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+
+	return h, nil
+}
+
+// Returns the encoded hash of the password produced by the configured algorithm.
+func (h Hasher) Hash(password string) (string, error) {
+	encoded, err := h.primary.hash(password)
+	if err != nil {
+		return "", fmt.Errorf("can't hash the password: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// Checks the password against the encoded hash. The rehash is true when the password matches,
+// but the hash was produced by the legacy scheme, another algorithm or with outdated parameters.
+func (h Hasher) Verify(password, encoded string) (match, rehash bool, err error) {
+	for _, alg := range h.algorithms {
+		if !alg.owns(encoded) {
+			continue
+		}
+
+		match, err := alg.verify(password, encoded)
+		if err != nil || !match {
+			return false, false, err
+		}
+
+		return true, alg != h.primary || alg.outdated(encoded), nil
+	}
+
+	if strings.HasPrefix(encoded, "$") {
+		return false, false, ErrMalformedHash
+	}
+
+	legacy := core.SHA256(password, h.legacySalt)
+	if subtle.ConstantTimeCompare([]byte(legacy), []byte(encoded)) != 1 {
+		return false, false, nil
+	}
+
+	return true, true, nil
+}
@@ -0,0 +1,207 @@
+package hasher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// The cheap parameters to keep the tests fast.
+var testConfig = Config{
+	Algorithm:  Argon2id,
+	BcryptCost: 4,
+	Argon2: Argon2Params{
+		Memory:      64,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	},
+}
+
+func newTestHasher(t *testing.T, algorithm string, change func(cfg *Config)) Hasher {
+	cfg := testConfig
+	cfg.Algorithm = algorithm
+
+	if change != nil {
+		change(&cfg)
+	}
+
+	h, err := New(cfg, "salt")
+	if err != nil {
+		t.FailNow()
+	}
+
+	return h
+}
+
+func TestHasher_HashVerify(t *testing.T) {
+	for _, algorithm := range []string{Argon2id, Bcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			h := newTestHasher(t, algorithm, nil)
+
+			encoded, err := h.Hash("qwerty123456")
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(encoded, "$"), "the hash should record the algorithm")
+
+			other, err := h.Hash("qwerty123456")
+			assert.NoError(t, err)
+			assert.NotEqual(t, encoded, other, "every hash should have its own salt")
+
+			match, rehash, err := h.Verify("qwerty123456", encoded)
+			assert.NoError(t, err)
+			assert.True(t, match)
+			assert.False(t, rehash)
+
+			match, rehash, err = h.Verify("qwerty654321", encoded)
+			assert.NoError(t, err)
+			assert.False(t, match)
+			assert.False(t, rehash)
+		})
+	}
+}
+
+func TestHasher_VerifyRehash(t *testing.T) {
+	argon2Hasher := newTestHasher(t, Argon2id, nil)
+	bcryptHasher := newTestHasher(t, Bcrypt, nil)
+
+	argon2Hash, err := argon2Hasher.Hash("qwerty123456")
+	if err != nil {
+		t.FailNow()
+	}
+
+	bcryptHash, err := bcryptHasher.Hash("qwerty123456")
+	if err != nil {
+		t.FailNow()
+	}
+
+	testCasesTable := map[string]struct {
+		hasher         Hasher
+		encoded        string
+		password       string
+		expectedMatch  bool
+		expectedRehash bool
+	}{
+		"Legacy hash": {
+			hasher:         argon2Hasher,
+			encoded:        core.SHA256("qwerty123456", "salt"),
+			password:       "qwerty123456",
+			expectedMatch:  true,
+			expectedRehash: true,
+		},
+		"Legacy hash with wrong password": {
+			hasher:   argon2Hasher,
+			encoded:  core.SHA256("qwerty123456", "salt"),
+			password: "qwerty654321",
+		},
+		"Legacy hash with another salt": {
+			hasher:   argon2Hasher,
+			encoded:  core.SHA256("qwerty123456", "another-salt"),
+			password: "qwerty123456",
+		},
+		"Bcrypt hash while argon2id is configured": {
+			hasher:         argon2Hasher,
+			encoded:        bcryptHash,
+			password:       "qwerty123456",
+			expectedMatch:  true,
+			expectedRehash: true,
+		},
+		"Argon2id hash while bcrypt is configured": {
+			hasher:         bcryptHasher,
+			encoded:        argon2Hash,
+			password:       "qwerty123456",
+			expectedMatch:  true,
+			expectedRehash: true,
+		},
+		"Argon2id hash with outdated parameters": {
+			hasher: newTestHasher(t, Argon2id, func(cfg *Config) {
+				cfg.Argon2.Iterations = 2
+			}),
+			encoded:        argon2Hash,
+			password:       "qwerty123456",
+			expectedMatch:  true,
+			expectedRehash: true,
+		},
+		"Bcrypt hash with outdated cost": {
+			hasher: newTestHasher(t, Bcrypt, func(cfg *Config) {
+				cfg.BcryptCost = 5
+			}),
+			encoded:        bcryptHash,
+			password:       "qwerty123456",
+			expectedMatch:  true,
+			expectedRehash: true,
+		},
+		"Outdated hash with wrong password": {
+			hasher:   bcryptHasher,
+			encoded:  argon2Hash,
+			password: "qwerty654321",
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			match, rehash, err := testCase.hasher.Verify(testCase.password, testCase.encoded)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMatch, match)
+			assert.Equal(t, testCase.expectedRehash, rehash)
+		})
+	}
+}
+
+func TestHasher_VerifyMalformed(t *testing.T) {
+	h := newTestHasher(t, Argon2id, nil)
+
+	for _, encoded := range []string{
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5a2V5a2V5a2V5",
+		"$2a$04$short",
+		"$scrypt$whatever",
+	} {
+		match, _, err := h.Verify("qwerty123456", encoded)
+
+		assert.ErrorIs(t, err, ErrMalformedHash, encoded)
+		assert.False(t, match)
+	}
+}
+
+func TestNew(t *testing.T) {
+	testCasesTable := map[string]struct {
+		change      func(cfg *Config)
+		expectedErr string
+	}{
+		"Unknown algorithm": {
+			change:      func(cfg *Config) { cfg.Algorithm = "md5" },
+			expectedErr: `unknown password hashing algorithm: "md5"`,
+		},
+		"Too low bcrypt cost": {
+			change:      func(cfg *Config) { cfg.BcryptCost = 3 },
+			expectedErr: "bcrypt cost 3 is outside allowed range [4, 31]",
+		},
+		"Zero argon2 iterations": {
+			change:      func(cfg *Config) { cfg.Argon2.Iterations = 0 },
+			expectedErr: "invalid argon2id parameters: {Memory:64 Iterations:0 Parallelism:1 SaltLength:16 KeyLength:32}",
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			cfg := testConfig
+			testCase.change(&cfg)
+
+			_, err := New(cfg, "salt")
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestHasher_BcryptTooLongPassword(t *testing.T) {
+	h := newTestHasher(t, Bcrypt, nil)
+
+	_, err := h.Hash(strings.Repeat("a", 73))
+	assert.ErrorIs(t, err, core.ErrPasswordTooLong)
+}
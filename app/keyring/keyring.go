@@ -0,0 +1,259 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/golang-jwt/jwt"
+)
+
+// Available signing algorithms of the configured keys.
+const (
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+const (
+	minRSAKeyBits = 2048
+	kidHeader     = "kid"
+)
+
+var (
+	ErrUnknownAlgorithm     = errors.New("unknown signing algorithm")
+	ErrUnknownKey           = errors.New("unknown signing key")
+	ErrInvalidSigningMethod = errors.New("invalid signing metod")
+	ErrNoSigningKey         = errors.New("no signing key")
+)
+
+// The configured key. The key without the private part can only verify the tokens,
+// it is used to keep accepting the tokens signed by the rotated out key.
+type Key struct {
+	ID            string
+	Algorithm     string
+	PrivateKeyPEM []byte
+	PublicKeyPEM  []byte
+}
+
+// Keyring signs the access tokens with the current key and verifies them
+// with the key the token header refers to by the kid.
+type Keyring struct {
+	signing *key
+	keys    map[string]*key
+}
+
+type key struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// Returns the keyring which signs the tokens with the key of signingKeyID. The non-empty legacy secret
+// keeps the tokens without the kid, which are signed by HS256, valid. The tokens are signed
+// with the legacy secret too if no signing key id is given.
+func New(signingKeyID string, keys []Key, legacySecret string) (Keyring, error) {
+	k := Keyring{keys: make(map[string]*key, len(keys)+1)}
+
+	for _, cfg := range keys {
+		if cfg.ID == "" {
+			return Keyring{}, fmt.Errorf("the key of %s algorithm has no id", cfg.Algorithm)
+		}
+
+		if _, ok := k.keys[cfg.ID]; ok {
+			return Keyring{}, fmt.Errorf("the key id %q is duplicated", cfg.ID)
+		}
+
+		parsed, err := parseKey(cfg)
+		if err != nil {
+			return Keyring{}, fmt.Errorf("can't load the key %q: %w", cfg.ID, err)
+		}
+
+		k.keys[cfg.ID] = parsed
+	}
+
+	if legacySecret != "" {
+		k.keys[""] = &key{
+			method:    jwt.SigningMethodHS256,
+			signKey:   []byte(legacySecret),
+			verifyKey: []byte(legacySecret),
+		}
+	}
+
+	signing, ok := k.keys[signingKeyID]
+	if !ok || signing.signKey == nil {
+		return Keyring{}, fmt.Errorf("%w: %q", ErrNoSigningKey, signingKeyID)
+	}
+
+	k.signing = signing
+
+	return k, nil
+}
+
+// Returns the keyring which signs and verifies the tokens by HS256 with the shared secret.
+func NewHMAC(secret string) Keyring {
+	hmacKey := &key{
+		method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+
+	return Keyring{signing: hmacKey, keys: map[string]*key{"": hmacKey}}
+}
+
+func parseKey(cfg Key) (*key, error) {
+	parsed := &key{id: cfg.ID}
+
+	var err error
+
+	switch cfg.Algorithm {
+	case RS256:
+		parsed.method = jwt.SigningMethodRS256
+		err = parseRSAKey(parsed, cfg)
+	case EdDSA:
+		parsed.method = jwt.SigningMethodEdDSA
+		err = parseEd25519Key(parsed, cfg)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func parseRSAKey(parsed *key, cfg Key) error {
+	var public *rsa.PublicKey
+
+	switch {
+	case len(cfg.PrivateKeyPEM) != 0:
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(cfg.PrivateKeyPEM)
+		if err != nil {
+			return fmt.Errorf("can't parse the private key: %w", err)
+		}
+
+		parsed.signKey = private
+		public = &private.PublicKey
+	case len(cfg.PublicKeyPEM) != 0:
+		var err error
+
+		public, err = jwt.ParseRSAPublicKeyFromPEM(cfg.PublicKeyPEM)
+		if err != nil {
+			return fmt.Errorf("can't parse the public key: %w", err)
+		}
+	default:
+		return errors.New("neither private nor public key is given")
+	}
+
+	if public.N.BitLen() < minRSAKeyBits {
+		return fmt.Errorf("the RSA key should have at least %d bits", minRSAKeyBits)
+	}
+
+	parsed.verifyKey = public
+
+	return nil
+}
+
+func parseEd25519Key(parsed *key, cfg Key) error {
+	switch {
+	case len(cfg.PrivateKeyPEM) != 0:
+		private, err := jwt.ParseEdPrivateKeyFromPEM(cfg.PrivateKeyPEM)
+		if err != nil {
+			return fmt.Errorf("can't parse the private key: %w", err)
+		}
+
+		edPrivate, ok := private.(ed25519.PrivateKey)
+		if !ok {
+			return jwt.ErrNotEdPrivateKey
+		}
+
+		parsed.signKey = edPrivate
+		parsed.verifyKey = edPrivate.Public()
+	case len(cfg.PublicKeyPEM) != 0:
+		public, err := jwt.ParseEdPublicKeyFromPEM(cfg.PublicKeyPEM)
+		if err != nil {
+			return fmt.Errorf("can't parse the public key: %w", err)
+		}
+
+		parsed.verifyKey = public
+	default:
+		return errors.New("neither private nor public key is given")
+	}
+
+	return nil
+}
+
+// Signs the claims with the current key and puts its id to the token header.
+func (k Keyring) Sign(claims jwt.Claims) (string, error) {
+	if k.signing == nil {
+		return "", ErrNoSigningKey
+	}
+
+	token := jwt.NewWithClaims(k.signing.method, claims)
+
+	if k.signing.id != "" {
+		token.Header[kidHeader] = k.signing.id
+	}
+
+	signed, err := token.SignedString(k.signing.signKey)
+	if err != nil {
+		return "", fmt.Errorf("cannot sign the token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// Returns the key to verify the token with, it implements jwt.Keyfunc.
+func (k Keyring) Verify(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header[kidHeader].(string)
+
+	verifying, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	if token.Method.Alg() != verifying.method.Alg() {
+		return nil, ErrInvalidSigningMethod
+	}
+
+	return verifying.verifyKey, nil
+}
+
+// Returns the public keys of the keyring. The shared secret of HS256 is never published.
+func (k Keyring) JWKS() core.JSONWebKeySet {
+	set := core.JSONWebKeySet{Keys: []core.JSONWebKey{}}
+
+	for _, current := range k.keys {
+		jwk := core.JSONWebKey{
+			KeyID:     current.id,
+			Algorithm: current.method.Alg(),
+			Use:       "sig",
+		}
+
+		switch public := current.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+
+	return set
+}
@@ -0,0 +1,268 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func privatePEM(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.FailNow()
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicPEM(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.FailNow()
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+type testKeys struct {
+	rsa     *rsa.PrivateKey
+	ed25519 ed25519.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	if err != nil {
+		t.FailNow()
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+
+	return testKeys{rsa: rsaKey, ed25519: edKey}
+}
+
+// Returns the error of the key lookup, which the parser wraps without the Unwrap support.
+func keyError(err error) error {
+	var validationErr *jwt.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Inner
+	}
+
+	return err
+}
+
+func claims() jwt.StandardClaims {
+	return jwt.StandardClaims{Subject: "id-111", ExpiresAt: time.Now().Add(time.Minute).Unix()}
+}
+
+func TestKeyring_SignVerify(t *testing.T) {
+	keys := newTestKeys(t)
+
+	for _, key := range []Key{
+		{ID: "rsa-1", Algorithm: RS256, PrivateKeyPEM: privatePEM(t, keys.rsa)},
+		{ID: "ed-1", Algorithm: EdDSA, PrivateKeyPEM: privatePEM(t, keys.ed25519)},
+	} {
+		t.Run(key.Algorithm, func(t *testing.T) {
+			ring, err := New(key.ID, []Key{key}, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signed, err := ring.Sign(claims())
+			assert.NoError(t, err)
+
+			parsed, err := jwt.ParseWithClaims(signed, &jwt.StandardClaims{}, ring.Verify)
+			assert.NoError(t, err)
+			assert.Equal(t, key.ID, parsed.Header["kid"])
+			assert.Equal(t, key.Algorithm, parsed.Header["alg"])
+		})
+	}
+}
+
+// The old key is left only with its public part after the rotation,
+// and the tokens signed by it stay valid.
+func TestKeyring_Rotation(t *testing.T) {
+	keys := newTestKeys(t)
+
+	oldRing, err := New("old", []Key{
+		{ID: "old", Algorithm: RS256, PrivateKeyPEM: privatePEM(t, keys.rsa)},
+	}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRing, err := New("new", []Key{
+		{ID: "new", Algorithm: EdDSA, PrivateKeyPEM: privatePEM(t, keys.ed25519)},
+		{ID: "old", Algorithm: RS256, PublicKeyPEM: publicPEM(t, &keys.rsa.PublicKey)},
+	}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldToken, err := oldRing.Sign(claims())
+	assert.NoError(t, err)
+
+	newToken, err := newRing.Sign(claims())
+	assert.NoError(t, err)
+
+	legacyToken, err := NewHMAC("secret").Sign(claims())
+	assert.NoError(t, err)
+
+	for name, signed := range map[string]string{"old": oldToken, "new": newToken, "legacy": legacyToken} {
+		_, err := jwt.ParseWithClaims(signed, &jwt.StandardClaims{}, newRing.Verify)
+		assert.NoError(t, err, name)
+	}
+
+	_, err = jwt.ParseWithClaims(newToken, &jwt.StandardClaims{}, oldRing.Verify)
+	assert.ErrorIs(t, keyError(err), ErrUnknownKey)
+}
+
+func TestKeyring_VerifyRejects(t *testing.T) {
+	keys := newTestKeys(t)
+
+	ring, err := New("rsa-1", []Key{
+		{ID: "rsa-1", Algorithm: RS256, PrivateKeyPEM: privatePEM(t, keys.rsa)},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public RSA key used as the HMAC secret is the classic algorithm confusion attack.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+	forged.Header["kid"] = "rsa-1"
+
+	signed, err := forged.SignedString(publicPEM(t, &keys.rsa.PublicKey))
+	if err != nil {
+		t.FailNow()
+	}
+
+	_, err = jwt.ParseWithClaims(signed, &jwt.StandardClaims{}, ring.Verify)
+	assert.ErrorIs(t, keyError(err), ErrInvalidSigningMethod)
+
+	legacyToken, err := NewHMAC("secret").Sign(claims())
+	assert.NoError(t, err)
+
+	_, err = jwt.ParseWithClaims(legacyToken, &jwt.StandardClaims{}, ring.Verify)
+	assert.ErrorIs(t, keyError(err), ErrUnknownKey, "the token without kid is rejected without the legacy secret")
+}
+
+func TestNew(t *testing.T) {
+	keys := newTestKeys(t)
+
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.FailNow()
+	}
+
+	testCasesTable := map[string]struct {
+		signingKeyID string
+		keys         []Key
+		legacySecret string
+		expectedErr  string
+	}{
+		"Legacy secret only": {
+			legacySecret: "secret",
+		},
+		"No keys": {
+			expectedErr: `no signing key: ""`,
+		},
+		"Unknown signing key": {
+			signingKeyID: "ed-2",
+			keys:         []Key{{ID: "ed-1", Algorithm: EdDSA, PrivateKeyPEM: privatePEM(t, keys.ed25519)}},
+			expectedErr:  `no signing key: "ed-2"`,
+		},
+		"Signing key without private part": {
+			signingKeyID: "ed-1",
+			keys:         []Key{{ID: "ed-1", Algorithm: EdDSA, PublicKeyPEM: publicPEM(t, keys.ed25519.Public())}},
+			expectedErr:  `no signing key: "ed-1"`,
+		},
+		"Duplicated key id": {
+			signingKeyID: "ed-1",
+			keys: []Key{
+				{ID: "ed-1", Algorithm: EdDSA, PrivateKeyPEM: privatePEM(t, keys.ed25519)},
+				{ID: "ed-1", Algorithm: RS256, PrivateKeyPEM: privatePEM(t, keys.rsa)},
+			},
+			expectedErr: `the key id "ed-1" is duplicated`,
+		},
+		"Key without id": {
+			keys:        []Key{{Algorithm: EdDSA, PrivateKeyPEM: privatePEM(t, keys.ed25519)}},
+			expectedErr: "the key of EdDSA algorithm has no id",
+		},
+		"Unknown algorithm": {
+			keys:        []Key{{ID: "hs-1", Algorithm: "HS256", PrivateKeyPEM: []byte("secret")}},
+			expectedErr: `can't load the key "hs-1": unknown signing algorithm: "HS256"`,
+		},
+		"Small RSA key": {
+			keys:        []Key{{ID: "rsa-1", Algorithm: RS256, PrivateKeyPEM: privatePEM(t, smallRSA)}},
+			expectedErr: `can't load the key "rsa-1": the RSA key should have at least 2048 bits`,
+		},
+		"Wrong key type": {
+			keys:        []Key{{ID: "rsa-1", Algorithm: RS256, PrivateKeyPEM: privatePEM(t, keys.ed25519)}},
+			expectedErr: `can't load the key "rsa-1": can't parse the private key: Key is not a valid RSA private key`,
+		},
+		"No key material": {
+			keys:        []Key{{ID: "rsa-1", Algorithm: RS256}},
+			expectedErr: `can't load the key "rsa-1": neither private nor public key is given`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(testCase.signingKeyID, testCase.keys, testCase.legacySecret)
+
+			if testCase.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
+func TestKeyring_JWKS(t *testing.T) {
+	keys := newTestKeys(t)
+
+	ring, err := New("rsa-1", []Key{
+		{ID: "rsa-1", Algorithm: RS256, PrivateKeyPEM: privatePEM(t, keys.rsa)},
+		{ID: "ed-1", Algorithm: EdDSA, PublicKeyPEM: publicPEM(t, keys.ed25519.Public())},
+	}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := ring.JWKS()
+
+	if !assert.Len(t, set.Keys, 2, "the shared secret should never be published") {
+		return
+	}
+
+	edKey, rsaKey := set.Keys[0], set.Keys[1]
+
+	assert.Equal(t, "ed-1", edKey.KeyID)
+	assert.Equal(t, "OKP", edKey.KeyType)
+	assert.Equal(t, "Ed25519", edKey.Curve)
+	assert.Equal(t, "EdDSA", edKey.Algorithm)
+	assert.Equal(t, "sig", edKey.Use)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(keys.ed25519.Public().(ed25519.PublicKey)), edKey.X)
+
+	assert.Equal(t, "rsa-1", rsaKey.KeyID)
+	assert.Equal(t, "RSA", rsaKey.KeyType)
+	assert.Equal(t, "RS256", rsaKey.Algorithm)
+	assert.Equal(t, "AQAB", rsaKey.E)
+
+	n, err := base64.RawURLEncoding.DecodeString(rsaKey.N)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, keys.rsa.N.Cmp(new(big.Int).SetBytes(n)))
+}
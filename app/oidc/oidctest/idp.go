@@ -0,0 +1,294 @@
+// Package oidctest serves the OpenID Connect provider for the tests of the single sign-on.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	rsaKeyBits = 2048
+	idTokenTTL = 5 * time.Minute
+)
+
+// The user who is logged in at the provider.
+type User struct {
+	Subject       string
+	Phone         string
+	PhoneVerified bool
+}
+
+// IdP is the provider which logs the User in at once at its authorization endpoint and redirects back
+// with the code. It checks the client credentials and the PKCE code verifier like the real provider.
+type IdP struct {
+	ClientID     string
+	ClientSecret string
+	User         User
+	// Changes the claims of the issued ID tokens, so the tests can break them.
+	Claims func(claims jwt.MapClaims)
+
+	server *httptest.Server
+	mu     *sync.Mutex
+	keyID  string
+	keys   map[string]*rsa.PrivateKey
+	grants map[string]grant
+}
+
+// The code issued by the authorization endpoint.
+type grant struct {
+	redirectURI string
+	challenge   string
+	nonce       string
+	user        User
+}
+
+// Starts the provider which accepts the client. Close it after the test.
+func NewIdP(clientID, clientSecret string) (*IdP, error) {
+	idp := &IdP{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		mu:           &sync.Mutex{},
+		keys:         make(map[string]*rsa.PrivateKey),
+		grants:       make(map[string]grant),
+	}
+
+	if err := idp.RotateKey(); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", idp.jwks)
+
+	idp.server = httptest.NewServer(mux)
+
+	return idp, nil
+}
+
+// The issuer is the URL of the provider.
+func (p *IdP) Issuer() string {
+	return p.server.URL
+}
+
+func (p *IdP) Close() {
+	p.server.Close()
+}
+
+// Signs the next ID tokens with the new key. The old keys are still published.
+func (p *IdP) RotateKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return fmt.Errorf("can't generate the key: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keyID = fmt.Sprintf("key-%d", len(p.keys)+1)
+	p.keys[p.keyID] = key
+
+	return nil
+}
+
+func (p *IdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.Issuer(),
+		"authorization_endpoint":                p.Issuer() + "/authorize",
+		"token_endpoint":                        p.Issuer() + "/token",
+		"jwks_uri":                              p.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	switch {
+	case query.Get("response_type") != "code":
+		http.Error(w, "unsupported response_type", http.StatusBadRequest)
+
+		return
+	case query.Get("client_id") != p.ClientID:
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+
+		return
+	case !strings.Contains(" "+query.Get("scope")+" ", " openid "):
+		http.Error(w, "the openid scope is required", http.StatusBadRequest)
+
+		return
+	case query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "":
+		http.Error(w, "the S256 code challenge is required", http.StatusBadRequest)
+
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+
+		return
+	}
+
+	code := randomString()
+
+	p.mu.Lock()
+	p.grants[code] = grant{
+		redirectURI: query.Get("redirect_uri"),
+		challenge:   query.Get("code_challenge"),
+		nonce:       query.Get("nonce"),
+		user:        p.User,
+	}
+	p.mu.Unlock()
+
+	callback := redirectURI.Query()
+	callback.Set("code", code)
+	callback.Set("state", query.Get("state"))
+	redirectURI.RawQuery = callback.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (p *IdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Method != http.MethodPost {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+
+	if clientID != p.ClientID || clientSecret != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+
+		return
+	}
+
+	p.mu.Lock()
+	code := r.PostForm.Get("code")
+	grant, ok := p.grants[code]
+	delete(p.grants, code)
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+
+	if !ok || grant.redirectURI != r.PostForm.Get("redirect_uri") ||
+		grant.challenge != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid_grant", "error_description": "the code or the code verifier is invalid",
+		})
+
+		return
+	}
+
+	idToken, err := p.signIDToken(grant)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (p *IdP) signIDToken(grant grant) (string, error) {
+	now := time.Now()
+
+	claims := jwt.MapClaims{
+		"iss":                   p.Issuer(),
+		"sub":                   grant.user.Subject,
+		"aud":                   p.ClientID,
+		"exp":                   now.Add(idTokenTTL).Unix(),
+		"iat":                   now.Unix(),
+		"nonce":                 grant.nonce,
+		"phone_number_verified": grant.user.PhoneVerified,
+	}
+
+	if grant.user.Phone != "" {
+		claims["phone_number"] = grant.user.Phone
+	}
+
+	if p.Claims != nil {
+		p.Claims(claims)
+	}
+
+	p.mu.Lock()
+	keyID, key := p.keyID, p.keys[p.keyID]
+	p.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("can't sign the ID token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (p *IdP) jwks(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keySet := core.JSONWebKeySet{Keys: make([]core.JSONWebKey, 0, len(p.keys))}
+
+	for keyID, key := range p.keys {
+		keySet.Keys = append(keySet.Keys, core.JSONWebKey{
+			KeyType:   "RSA",
+			KeyID:     keyID,
+			Algorithm: "RS256",
+			Use:       "sig",
+			N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, keySet)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body) //nolint:errcheck
+}
+
+func randomString() string {
+	buf := make([]byte, 16)
+	rand.Read(buf) //nolint:errcheck
+
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,405 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// The responses of the provider are small, the bigger ones are cut.
+	maxResponseSize = 1 << 20
+	defaultTimeout  = 10 * time.Second
+)
+
+var (
+	errUnexpectedStatus    = errors.New("unexpected status of the response")
+	errIssuerMismatch      = errors.New("the issuer of the discovery document doesn't match the configured one")
+	errIncompleteDiscovery = errors.New("the discovery document misses the endpoint")
+	errUnknownKey          = errors.New("the ID token is signed by the unknown key")
+	errUnsupportedKey      = errors.New("unsupported key")
+	errNoIDToken           = errors.New("the token response has no ID token")
+	errInvalidClaim        = errors.New("invalid claim of the ID token")
+)
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// The callback the provider redirects the user to with the code.
+	RedirectURL string
+	// The scope "openid" is always requested, the phone is shared with the scope "phone".
+	Scopes []string
+	// The allowed clock skew while validating the time claims of the ID token.
+	Leeway time.Duration
+}
+
+// Provider is the client of the OpenID Connect provider which logs the users in with the authorization code
+// flow and PKCE (RFC 7636). The endpoints and the keys are discovered by the issuer on the first use.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	cache  *providerCache
+	now    func() time.Time
+}
+
+// The endpoints of the provider from the discovery document.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type providerCache struct {
+	mu       sync.Mutex
+	metadata *metadata
+	// The public keys of the provider by their ids.
+	keys map[string]interface{}
+}
+
+// Returns the provider which calls the identity provider with the client, the default client is used if it is nil.
+func NewProvider(cfg Config, client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return Provider{cfg: cfg, client: client, cache: &providerCache{}, now: time.Now}
+}
+
+// Returns the URL of the login at the provider. The provider redirects the user back with the state
+// and the code, which is exchanged with the code verifier.
+func (p Provider) AuthCodeURL(state, nonce, codeVerifier string) (string, error) {
+	meta, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("can't parse the authorization endpoint: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(p.scopes(), " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchanges the code for the ID token and returns the identity from its verified claims.
+// The errors of the login at the provider are core.ErrOIDCAuthFailed.
+func (p Provider) Exchange(code, codeVerifier, nonce string) (core.ExternalIdentity, error) {
+	meta, err := p.discover()
+	if err != nil {
+		return core.ExternalIdentity{}, err
+	}
+
+	rawIDToken, err := p.requestIDToken(meta, code, codeVerifier)
+	if err != nil {
+		return core.ExternalIdentity{}, err
+	}
+
+	claims, err := p.verifyIDToken(meta, rawIDToken, nonce)
+	if err != nil {
+		return core.ExternalIdentity{}, fmt.Errorf("%w: %s", core.ErrOIDCAuthFailed, err.Error())
+	}
+
+	identity := core.ExternalIdentity{Issuer: meta.Issuer}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Phone, _ = claims["phone_number"].(string)
+	identity.PhoneVerified, _ = claims["phone_number_verified"].(bool)
+
+	return identity, nil
+}
+
+func (p Provider) scopes() []string {
+	scopes := []string{"openid"}
+
+	for _, scope := range p.cfg.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+// Returns the endpoints of the provider, they are requested once.
+func (p Provider) discover() (metadata, error) {
+	p.cache.mu.Lock()
+	defer p.cache.mu.Unlock()
+
+	if p.cache.metadata != nil {
+		return *p.cache.metadata, nil
+	}
+
+	var meta metadata
+
+	if err := p.getJSON(strings.TrimSuffix(p.cfg.Issuer, "/")+discoveryPath, &meta); err != nil {
+		return metadata{}, fmt.Errorf("can't discover the provider: %w", err)
+	}
+
+	if meta.Issuer != p.cfg.Issuer {
+		return metadata{}, fmt.Errorf("%w: %q", errIssuerMismatch, meta.Issuer)
+	}
+
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return metadata{}, errIncompleteDiscovery
+	}
+
+	p.cache.metadata = &meta
+
+	return meta, nil
+}
+
+// Sends the code with the verifier to the token endpoint and returns the ID token.
+func (p Provider) requestIDToken(meta metadata, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+		"client_id":     {p.cfg.ClientID},
+	}
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, meta.TokenEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("can't create the token request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	if p.cfg.ClientSecret != "" {
+		// The credentials are form encoded before the basic authentication (RFC 6749, section 2.3.1).
+		request.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("can't request the token: %w", err)
+	}
+	defer response.Body.Close()
+
+	var tokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxResponseSize)).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("can't decode the token response with the status %d: %w", response.StatusCode, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: the token request is refused: %s %s",
+			core.ErrOIDCAuthFailed, tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+
+	if tokenResponse.IDToken == "" {
+		return "", fmt.Errorf("%w: %s", core.ErrOIDCAuthFailed, errNoIDToken.Error())
+	}
+
+	return tokenResponse.IDToken, nil
+}
+
+// Checks the signature of the ID token and its claims required by OpenID Connect Core, section 3.1.3.7.
+func (p Provider) verifyIDToken(meta metadata, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	// The time claims are validated below with the leeway, which the parser doesn't support.
+	parser := jwt.Parser{
+		ValidMethods:         []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()},
+		SkipClaimsValidation: true,
+	}
+
+	claims := jwt.MapClaims{}
+
+	if _, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+
+		return p.key(meta, keyID)
+	}); err != nil {
+		return nil, fmt.Errorf("can't verify the ID token: %w", err)
+	}
+
+	now := p.now()
+
+	if !claims.VerifyIssuer(meta.Issuer, true) {
+		return nil, fmt.Errorf("%w: iss", errInvalidClaim)
+	}
+
+	if !hasAudience(claims, p.cfg.ClientID) {
+		return nil, fmt.Errorf("%w: aud", errInvalidClaim)
+	}
+
+	if !claims.VerifyExpiresAt(now.Add(-p.cfg.Leeway).Unix(), true) {
+		return nil, fmt.Errorf("%w: exp", errInvalidClaim)
+	}
+
+	if !claims.VerifyIssuedAt(now.Add(p.cfg.Leeway).Unix(), false) {
+		return nil, fmt.Errorf("%w: iat", errInvalidClaim)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce", errInvalidClaim)
+	}
+
+	if subject, _ := claims["sub"].(string); subject == "" {
+		return nil, fmt.Errorf("%w: sub", errInvalidClaim)
+	}
+
+	return claims, nil
+}
+
+// Returns the public key by its id. The keys are requested again if the key is unknown,
+// because the provider could rotate them. The id may be omitted if the provider has the single key.
+func (p Provider) key(meta metadata, keyID string) (interface{}, error) {
+	p.cache.mu.Lock()
+	defer p.cache.mu.Unlock()
+
+	if key, ok := lookupKey(p.cache.keys, keyID); ok {
+		return key, nil
+	}
+
+	var keySet core.JSONWebKeySet
+
+	if err := p.getJSON(meta.JWKSURI, &keySet); err != nil {
+		return nil, fmt.Errorf("can't get the keys of the provider: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(keySet.Keys))
+
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		// The keys of the other types are not used by the provider for the ID tokens of this client.
+		key, err := parseJSONWebKey(jwk)
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.KeyID] = key
+	}
+
+	p.cache.keys = keys
+
+	if key, ok := lookupKey(keys, keyID); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", errUnknownKey, keyID)
+}
+
+func lookupKey(keys map[string]interface{}, keyID string) (interface{}, bool) {
+	if keyID == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+
+	key, ok := keys[keyID]
+
+	return key, ok
+}
+
+func parseJSONWebKey(jwk core.JSONWebKey) (interface{}, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		modulus, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode the modulus: %w", err)
+		}
+
+		exponent, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode the exponent: %w", err)
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(new(big.Int).SetBytes(exponent).Int64()),
+		}, nil
+	case "OKP":
+		if jwk.Curve != "Ed25519" {
+			return nil, fmt.Errorf("%w: the curve %q", errUnsupportedKey, jwk.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid Ed25519 key", errUnsupportedKey)
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("%w: the type %q", errUnsupportedKey, jwk.KeyType)
+	}
+}
+
+// The audience is the string or the list of the strings.
+func hasAudience(claims jwt.MapClaims, clientID string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p Provider) getJSON(endpoint string, dest interface{}) error {
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("can't create the request: %w", err)
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("can't send the request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d", errUnexpectedStatus, response.StatusCode)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxResponseSize)).Decode(dest); err != nil {
+		return fmt.Errorf("can't decode the response: %w", err)
+	}
+
+	return nil
+}
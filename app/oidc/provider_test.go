@@ -0,0 +1,256 @@
+package oidc
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/app/oidc/oidctest"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	clientID     = "movies-api"
+	clientSecret = "client-secret"
+	redirectURL  = "http://localhost:8080/auth/oidc/callback"
+	codeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func newTestIdP(t *testing.T) *oidctest.IdP {
+	t.Helper()
+
+	idp, err := oidctest.NewIdP(clientID, clientSecret)
+	if err != nil {
+		t.FailNow()
+	}
+
+	t.Cleanup(idp.Close)
+
+	idp.User = oidctest.User{Subject: "subject-111", Phone: "+380999999999", PhoneVerified: true}
+
+	return idp
+}
+
+func newTestProvider(idp *oidctest.IdP) Provider {
+	return NewProvider(Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"openid", "phone"},
+	}, nil)
+}
+
+// Opens the login URL at the provider and returns the code and the state it redirects back with.
+func authorize(t *testing.T, provider Provider, state, nonce, verifier string) (code, returnedState string) {
+	t.Helper()
+
+	authURL, err := provider.AuthCodeURL(state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	response, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: unexpected status %d", response.StatusCode)
+	}
+
+	callback, err := url.Parse(response.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+
+	return callback.Query().Get("code"), callback.Query().Get("state")
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := newTestIdP(t)
+	provider := newTestProvider(idp)
+
+	authURL, err := provider.AuthCodeURL("state-111", "nonce-111", codeVerifier)
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	assert.NoError(t, err)
+
+	query := parsed.Query()
+	assert.Equal(t, idp.Issuer()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, clientID, query.Get("client_id"))
+	assert.Equal(t, redirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid phone", query.Get("scope"))
+	assert.Equal(t, "state-111", query.Get("state"))
+	assert.Equal(t, "nonce-111", query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	// The example of RFC 7636, appendix B.
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", query.Get("code_challenge"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	testCasesTable := map[string]struct {
+		claims           func(claims jwt.MapClaims)
+		verifier         string
+		nonce            string
+		clientSecret     string
+		expectedIdentity core.ExternalIdentity
+		expectedError    error
+	}{
+		"Success": {
+			verifier:     codeVerifier,
+			nonce:        "nonce-111",
+			clientSecret: clientSecret,
+			expectedIdentity: core.ExternalIdentity{
+				Subject: "subject-111", Phone: "+380999999999", PhoneVerified: true,
+			},
+		},
+		"Audience in the list": {
+			claims: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{"other-client", clientID}
+			},
+			verifier:     codeVerifier,
+			nonce:        "nonce-111",
+			clientSecret: clientSecret,
+			expectedIdentity: core.ExternalIdentity{
+				Subject: "subject-111", Phone: "+380999999999", PhoneVerified: true,
+			},
+		},
+		"Wrong code verifier": {
+			verifier:      "another-code-verifier-of-the-attacker-0000000",
+			nonce:         "nonce-111",
+			clientSecret:  clientSecret,
+			expectedError: core.ErrOIDCAuthFailed,
+		},
+		"Wrong client secret": {
+			verifier:      codeVerifier,
+			nonce:         "nonce-111",
+			clientSecret:  "wrong-secret",
+			expectedError: core.ErrOIDCAuthFailed,
+		},
+		"Replayed nonce": {
+			claims: func(claims jwt.MapClaims) {
+				claims["nonce"] = "nonce-of-another-login"
+			},
+			verifier:      codeVerifier,
+			nonce:         "nonce-111",
+			clientSecret:  clientSecret,
+			expectedError: core.ErrOIDCAuthFailed,
+		},
+		"Token of another client": {
+			claims: func(claims jwt.MapClaims) {
+				claims["aud"] = "other-client"
+			},
+			verifier:      codeVerifier,
+			nonce:         "nonce-111",
+			clientSecret:  clientSecret,
+			expectedError: core.ErrOIDCAuthFailed,
+		},
+		"Token of another issuer": {
+			claims: func(claims jwt.MapClaims) {
+				claims["iss"] = "https://evil.example.com"
+			},
+			verifier:      codeVerifier,
+			nonce:         "nonce-111",
+			clientSecret:  clientSecret,
+			expectedError: core.ErrOIDCAuthFailed,
+		},
+		"Expired token": {
+			claims: func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+			},
+			verifier:      codeVerifier,
+			nonce:         "nonce-111",
+			clientSecret:  clientSecret,
+			expectedError: core.ErrOIDCAuthFailed,
+		},
+		"No subject": {
+			claims: func(claims jwt.MapClaims) {
+				delete(claims, "sub")
+			},
+			verifier:      codeVerifier,
+			nonce:         "nonce-111",
+			clientSecret:  clientSecret,
+			expectedError: core.ErrOIDCAuthFailed,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			idp := newTestIdP(t)
+			idp.Claims = testCase.claims
+
+			provider := newTestProvider(idp)
+
+			code, state := authorize(t, provider, "state-111", "nonce-111", codeVerifier)
+			assert.Equal(t, "state-111", state)
+
+			provider.cfg.ClientSecret = testCase.clientSecret
+
+			identity, err := provider.Exchange(code, testCase.verifier, testCase.nonce)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			testCase.expectedIdentity.Issuer = idp.Issuer()
+			assert.Equal(t, testCase.expectedIdentity, identity)
+		})
+	}
+}
+
+func TestProvider_ExchangeCodeOnce(t *testing.T) {
+	idp := newTestIdP(t)
+	provider := newTestProvider(idp)
+
+	code, _ := authorize(t, provider, "state-111", "nonce-111", codeVerifier)
+
+	_, err := provider.Exchange(code, codeVerifier, "nonce-111")
+	assert.NoError(t, err)
+
+	_, err = provider.Exchange(code, codeVerifier, "nonce-111")
+	assert.ErrorIs(t, err, core.ErrOIDCAuthFailed)
+}
+
+func TestProvider_KeyRotation(t *testing.T) {
+	idp := newTestIdP(t)
+	provider := newTestProvider(idp)
+
+	code, _ := authorize(t, provider, "state-111", "nonce-111", codeVerifier)
+
+	_, err := provider.Exchange(code, codeVerifier, "nonce-111")
+	assert.NoError(t, err)
+
+	if err := idp.RotateKey(); err != nil {
+		t.FailNow()
+	}
+
+	code, _ = authorize(t, provider, "state-222", "nonce-222", codeVerifier)
+
+	identity, err := provider.Exchange(code, codeVerifier, "nonce-222")
+	assert.NoError(t, err, "the keys are requested again for the unknown key")
+	assert.Equal(t, "subject-111", identity.Subject)
+}
+
+func TestProvider_IssuerMismatch(t *testing.T) {
+	idp := newTestIdP(t)
+
+	provider := NewProvider(Config{Issuer: idp.Issuer() + "/", ClientID: clientID}, nil)
+
+	_, err := provider.AuthCodeURL("state-111", "nonce-111", codeVerifier)
+	assert.ErrorIs(t, err, errIssuerMismatch)
+}
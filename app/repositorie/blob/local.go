@@ -0,0 +1,96 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrInvalidKey    = errors.New("the object key should be a relative path inside the store")
+	ErrForeignObject = errors.New("the object url doesn't belong to the store")
+)
+
+// LocalStore keeps the objects in the directory of the local filesystem.
+// The objects are expected to be served by the web server under the baseURL.
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+// Creates the store directory if it doesn't exist and returns the store.
+func NewLocalStore(dir, baseURL string) (LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return LocalStore{}, fmt.Errorf("can't create the store directory: %w", err)
+	}
+
+	return LocalStore{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// Writes the object under the key and returns its url. The object is written to the temporary file
+// first and renamed after, so the half-written object is never visible.
+func (s LocalStore) Put(key, _ string, body io.Reader, _ int64) (string, error) {
+	path, err := s.objectPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("can't create the object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("can't create the temporary file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+
+		return "", fmt.Errorf("can't write the object: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("can't close the object: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("can't move the object: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Removes the object defined by its url. The missing object is not an error.
+func (s LocalStore) Delete(url string) error {
+	key, ok := strings.CutPrefix(url, s.baseURL+"/")
+	if !ok {
+		return ErrForeignObject
+	}
+
+	path, err := s.objectPath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("can't remove the object: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the path of the object in the store directory. The key can't point outside the directory.
+func (s LocalStore) objectPath(key string) (string, error) {
+	path := filepath.FromSlash(key)
+	if !filepath.IsLocal(path) {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.dir, path), nil
+}
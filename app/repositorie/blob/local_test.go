@@ -0,0 +1,43 @@
+package blob
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore_PutDelete(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewLocalStore(dir, "/photos/")
+	assert.NoError(t, err)
+
+	url, err := store.Put("director/1/photo.png", "image/png", strings.NewReader("png-data"), 8)
+	assert.NoError(t, err)
+	assert.Equal(t, "/photos/director/1/photo.png", url)
+
+	content, err := os.ReadFile(filepath.Join(dir, "director", "1", "photo.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "png-data", string(content))
+
+	assert.NoError(t, store.Delete(url))
+	assert.NoFileExists(t, filepath.Join(dir, "director", "1", "photo.png"))
+
+	assert.NoError(t, store.Delete(url), "the missing object is not an error")
+	assert.ErrorIs(t, store.Delete("/other/director/1/photo.png"), ErrForeignObject)
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir(), "/photos")
+	assert.NoError(t, err)
+
+	for _, key := range []string{"../photo.png", "/etc/passwd", "director/../../photo.png", ""} {
+		_, err := store.Put(key, "image/png", strings.NewReader("png-data"), 8)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+
+	assert.ErrorIs(t, store.Delete("/photos/../config.yml"), ErrInvalidKey)
+}
@@ -0,0 +1,201 @@
+package blob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signAlgorithm   = "AWS4-HMAC-SHA256"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateFormat   = "20060102T150405Z"
+	requestTimeout  = 30 * time.Second
+)
+
+// The settings of the S3-compatible storage (AWS S3, MinIO, etc).
+type S3Config struct {
+	// The scheme and the host of the storage API, for ex.: http://localhost:9000.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// The base url of the public objects. If it is empty the path-style url of the bucket is used.
+	PublicURL string
+}
+
+// S3Store keeps the objects in the bucket of the S3-compatible storage.
+// The requests are path-style and signed with AWS Signature Version 4.
+type S3Store struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Store(cfg S3Config) S3Store {
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = cfg.Endpoint + "/" + cfg.Bucket
+	}
+
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+
+	return S3Store{
+		cfg:    cfg,
+		client: &http.Client{Timeout: requestTimeout},
+		now:    time.Now,
+	}
+}
+
+// Uploads the object under the key and returns its public url.
+func (s S3Store) Put(key, contentType string, body io.Reader, size int64) (string, error) {
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(key), body)
+	if err != nil {
+		return "", fmt.Errorf("can't create the request: %w", err)
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	if err := s.do(req, http.StatusOK); err != nil {
+		return "", fmt.Errorf("can't put the object: %w", err)
+	}
+
+	return s.cfg.PublicURL + "/" + key, nil
+}
+
+// Removes the object defined by its public url. The missing object is not an error.
+func (s S3Store) Delete(url string) error {
+	key, ok := strings.CutPrefix(url, s.cfg.PublicURL+"/")
+	if !ok {
+		return ErrForeignObject
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("can't create the request: %w", err)
+	}
+
+	if err := s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound); err != nil {
+		return fmt.Errorf("can't delete the object: %w", err)
+	}
+
+	return nil
+}
+
+func (s S3Store) objectURL(key string) string {
+	return s.cfg.Endpoint + "/" + s.cfg.Bucket + "/" + key
+}
+
+// Signs and sends the request, the response status should be one of the expected.
+func (s S3Store) do(req *http.Request, expectedStatus ...int) error {
+	s.sign(req, unsignedPayload, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	for _, status := range expectedStatus {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512)) //nolint:errcheck
+
+	return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, message) //nolint:goerr113
+}
+
+// Adds the authorization header of the AWS Signature Version 4 to the request.
+// The host and all headers which are already set are signed.
+func (s S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	scope := amzDate[:8] + "/" + s.cfg.Region + "/s3/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{signAlgorithm, amzDate, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), amzDate[:8])
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", signAlgorithm+" Credential="+s.cfg.AccessKey+"/"+scope+
+		",SignedHeaders="+signedHeaders+",Signature="+signature)
+}
+
+// Encodes the path as it is required by the signature: every byte except the unreserved characters
+// and the slash is percent-encoded.
+func uriEncode(path string) string {
+	var encoded strings.Builder
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			encoded.WriteByte(c)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+// The number of the tracked keys after which the forgotten ones are pruned.
+const loginAttemptMaxEntries = 10000
+
+// LoginAttemptStore keeps the failed logins in the memory of the process.
+// It suits the single instance of the server, the failures are lost on restart.
+type LoginAttemptStore struct {
+	mu      *sync.Mutex
+	entries map[string]core.LoginAttempts
+	now     func() time.Time
+}
+
+func NewLoginAttemptStore() LoginAttemptStore {
+	return LoginAttemptStore{
+		mu:      &sync.Mutex{},
+		entries: make(map[string]core.LoginAttempts),
+		now:     time.Now,
+	}
+}
+
+// Returns the failed logins of the key. The key without failures is not an error.
+func (s LoginAttemptStore) SelectLoginAttempts(key string) (core.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts, ok := s.entries[key]
+	if !ok {
+		return core.LoginAttempts{Key: key}, nil
+	}
+
+	return attempts, nil
+}
+
+// Counts one more failure of the key. The failures older than the window are forgotten.
+func (s LoginAttemptStore) AddLoginFailure(key string, window time.Duration) (core.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	if len(s.entries) >= loginAttemptMaxEntries {
+		s.prune(now, window)
+	}
+
+	attempts, ok := s.entries[key]
+	if !ok || attempts.LastFailure.Before(now.Add(-window)) {
+		attempts = core.LoginAttempts{Key: key, LockedUntil: attempts.LockedUntil}
+	}
+
+	attempts.Failures++
+	attempts.LastFailure = now
+	s.entries[key] = attempts
+
+	return attempts, nil
+}
+
+func (s LoginAttemptStore) LockLogin(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempts, ok := s.entries[key]; ok {
+		attempts.LockedUntil = until
+		s.entries[key] = attempts
+	}
+
+	return nil
+}
+
+// Forgets the failures and the lock of the key.
+func (s LoginAttemptStore) DeleteLoginAttempts(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+
+	return nil
+}
+
+// Deletes the keys which have neither the recent failures nor the lock.
+func (s LoginAttemptStore) prune(now time.Time, window time.Duration) {
+	for key, attempts := range s.entries {
+		if attempts.LastFailure.Before(now.Add(-window)) && !now.Before(attempts.LockedUntil) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginAttemptStore(t *testing.T) {
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	store := NewLoginAttemptStore()
+	store.now = func() time.Time { return now }
+
+	attempts, err := store.SelectLoginAttempts("phone:+380999999999")
+	assert.NoError(t, err)
+	assert.Equal(t, "phone:+380999999999", attempts.Key)
+	assert.Zero(t, attempts.Failures)
+
+	for i := 1; i <= 3; i++ {
+		attempts, err = store.AddLoginFailure("phone:+380999999999", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, i, attempts.Failures)
+	}
+
+	assert.NoError(t, store.LockLogin("phone:+380999999999", now.Add(time.Hour)))
+
+	now = now.Add(2 * time.Minute)
+
+	attempts, err = store.AddLoginFailure("phone:+380999999999", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts.Failures, "the failures out of the window are forgotten")
+	assert.Equal(t, now.Add(time.Hour-2*time.Minute), attempts.LockedUntil, "the lock outlives the window")
+
+	assert.NoError(t, store.DeleteLoginAttempts("phone:+380999999999"))
+
+	attempts, err = store.SelectLoginAttempts("phone:+380999999999")
+	assert.NoError(t, err)
+	assert.Zero(t, attempts.Failures)
+	assert.True(t, attempts.LockedUntil.IsZero())
+}
+
+func TestLoginAttemptStore_prune(t *testing.T) {
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	store := NewLoginAttemptStore()
+	store.now = func() time.Time { return now }
+
+	_, err := store.AddLoginFailure("ip:10.0.0.1", time.Minute)
+	assert.NoError(t, err)
+	_, err = store.AddLoginFailure("ip:10.0.0.2", time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, store.LockLogin("ip:10.0.0.2", now.Add(time.Hour)))
+
+	now = now.Add(2 * time.Minute)
+	store.prune(now, time.Minute)
+
+	assert.NotContains(t, store.entries, "ip:10.0.0.1")
+	assert.Contains(t, store.entries, "ip:10.0.0.2", "the locked key is kept")
+}
@@ -1,15 +1,31 @@
 package pg
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Brigant/PetPorject/app/core"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
 
+var accountColumns = queryColumns{
+	filter: map[string]filterColumn{
+		"phone":    {name: "phone", operator: opContains},
+		"role":     {name: "role", operator: opEqual},
+		"disabled": {name: "disabled", operator: opEqual},
+	},
+	sort: map[string]string{
+		"phone":   "phone",
+		"created": "created",
+	},
+}
+
+const accountInfoColumns = `id, phone, age, role, disabled, phone_verified, created, modified`
+
 type AccountDB struct {
 	db *sqlx.DB
 }
@@ -20,8 +36,9 @@ func NewAccountDB(db *sqlx.DB) AccountDB {
 	}
 }
 
-// Insert the account model to databese and returning the newly created account id.
-func (r AccountDB) InsertAccount(account core.Account) (accountID string, err error) {
+// Insert the account model to databese together with its system lists
+// and returning the newly created account id.
+func (r AccountDB) InsertAccount(account core.Account, systemLists []core.ListType) (accountID string, err error) {
 	query := `INSERT INTO public.account(
 		phone, 
 		password, 
@@ -29,7 +46,16 @@ func (r AccountDB) InsertAccount(account core.Account) (accountID string, err er
 		role) 
 		VALUES ($1, $2, $3, $4) RETURNING id`
 
-	err = r.db.DB.QueryRow(query,
+	listQuery := `INSERT INTO public.list(account_id, type) VALUES ($1, $2)`
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return "", fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	err = tx.QueryRow(query,
 		account.Phone,
 		account.Password,
 		account.Age,
@@ -43,13 +69,23 @@ func (r AccountDB) InsertAccount(account core.Account) (accountID string, err er
 		return "", fmt.Errorf("cannot execute query: %w", err)
 	}
 
+	for _, listType := range systemLists {
+		if _, err := tx.Exec(listQuery, accountID, listType); err != nil {
+			return "", fmt.Errorf("cannot create the %s list: %w", listType, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("can't commit transaction: %w", err)
+	}
+
 	return accountID, nil
 }
 
 func (r AccountDB) SelectAccountByPhone(phone string) (core.Account, error) {
 	var account core.Account
 
-	query := `SELECT id, phone, password, age, role 
+	query := `SELECT id, phone, password, age, role, disabled, phone_verified 
 		FROM public.account WHERE phone=$1`
 
 	err := r.db.DB.QueryRow(query, phone).Scan(
@@ -58,6 +94,8 @@ func (r AccountDB) SelectAccountByPhone(phone string) (core.Account, error) {
 		&account.Password,
 		&account.Age,
 		&account.Role,
+		&account.Disabled,
+		&account.PhoneVerified,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -73,7 +111,7 @@ func (r AccountDB) SelectAccountByPhone(phone string) (core.Account, error) {
 func (r AccountDB) SelectAccountByID(accountID string) (core.Account, error) {
 	var account core.Account
 
-	query := `SELECT id, phone, password, age, role 
+	query := `SELECT id, phone, password, age, role, disabled, phone_verified 
 	FROM public.account WHERE id=$1`
 
 	err := r.db.DB.QueryRow(query, accountID).Scan(
@@ -82,6 +120,8 @@ func (r AccountDB) SelectAccountByID(accountID string) (core.Account, error) {
 		&account.Password,
 		&account.Age,
 		&account.Role,
+		&account.Disabled,
+		&account.PhoneVerified,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -94,6 +134,27 @@ func (r AccountDB) SelectAccountByID(accountID string) (core.Account, error) {
 	return account, nil
 }
 
+// Replaces the password hash of the account.
+func (r AccountDB) UpdateAccountPassword(accountID, passwordHash string) error {
+	query := `UPDATE public.account SET password=$1, modified=now() WHERE id=$2`
+
+	result, err := r.db.Exec(query, passwordHash, accountID)
+	if err != nil {
+		return fmt.Errorf("can't update the account password: %w", err)
+	}
+
+	rowAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get the affected rows: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return core.ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (r AccountDB) InsertSession(session core.Session) (core.Session, error) {
 	query := `INSERT INTO public.session(
 			account_id,
@@ -103,7 +164,7 @@ func (r AccountDB) InsertSession(session core.Session) (core.Session, error) {
 			client_ip, 
 			expired
 		) 
-		VALUES ($1, $2, $3, $4, $5, $6) RETURNING refresh_token`
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, refresh_token, family_id`
 
 	err := r.db.DB.QueryRow(query,
 		session.AccountID,
@@ -111,7 +172,7 @@ func (r AccountDB) InsertSession(session core.Session) (core.Session, error) {
 		session.RequestHost,
 		session.UserAgent,
 		session.ClientIP,
-		session.Expired).Scan(&session.RefreshToken)
+		session.Expired).Scan(&session.ID, &session.RefreshToken, &session.FamilyID)
 	if err != nil {
 		return core.Session{}, fmt.Errorf("internal error while inserting session: %w", err)
 	}
@@ -121,7 +182,7 @@ func (r AccountDB) InsertSession(session core.Session) (core.Session, error) {
 
 func (r AccountDB) SelectSession(session core.Session) (core.Session, error) {
 	query := `SELECT  
-		refresh_token, account_id, role, request_host, user_agent, client_ip, expired, created
+		id, refresh_token, family_id, account_id, role, request_host, user_agent, client_ip, expired, created
 		FROM public.session
 		WHERE refresh_token=$1 and request_host=$2 and user_agent=$3 and client_ip=$4`
 
@@ -132,7 +193,9 @@ func (r AccountDB) SelectSession(session core.Session) (core.Session, error) {
 		session.UserAgent,
 		session.ClientIP,
 	).Scan(
+		&session.ID,
 		&session.RefreshToken,
+		&session.FamilyID,
 		&session.AccountID,
 		&session.Role,
 		&session.RequestHost,
@@ -152,15 +215,79 @@ func (r AccountDB) SelectSession(session core.Session) (core.Session, error) {
 	return session, nil
 }
 
-func (r AccountDB) RefreshSession(session core.Session) error {
+// Replaces the refresh token of the session with the new one and prolongs the session.
+// The old token is kept as retired to detect its reuse.
+func (r AccountDB) RotateSession(refreshToken string, expired time.Time) (core.Session, error) {
+	var session core.Session
+
 	query := `UPDATE public.session 
-		SET expired = $1
+		SET refresh_token=gen_random_uuid(), expired=$1
 		WHERE refresh_token=$2
-		RETURNING account_id, role`
+		RETURNING id, refresh_token, family_id, account_id, role, request_host, user_agent, client_ip, expired, created`
+
+	retireQuery := `INSERT INTO public.retired_refresh_token(refresh_token, family_id, account_id) 
+		VALUES ($1, $2, $3)`
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return core.Session{}, fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	err = tx.QueryRow(query, expired, refreshToken).Scan(
+		&session.ID,
+		&session.RefreshToken,
+		&session.FamilyID,
+		&session.AccountID,
+		&session.Role,
+		&session.RequestHost,
+		&session.UserAgent,
+		&session.ClientIP,
+		&session.Expired,
+		&session.Created,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Session{}, core.ErrSesseionNotFound
+		}
+
+		return core.Session{}, fmt.Errorf("can't UPDATE session cuase of: %w", err)
+	}
 
-	_, err := r.db.DB.Exec(query, session.Expired, session.RefreshToken)
+	if _, err := tx.Exec(retireQuery, refreshToken, session.FamilyID, session.AccountID); err != nil {
+		return core.Session{}, fmt.Errorf("can't retire the refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Session{}, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return session, nil
+}
+
+// Returns the family and the account of the retired refresh token.
+func (r AccountDB) SelectRetiredRefreshToken(refreshToken string) (familyID, accountID string, err error) {
+	query := `SELECT family_id, account_id FROM public.retired_refresh_token WHERE refresh_token=$1`
+
+	err = r.db.QueryRow(query, refreshToken).Scan(&familyID, &accountID)
 	if err != nil {
-		return fmt.Errorf("can't UPDATE session cuase of: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", core.ErrSesseionNotFound
+		}
+
+		return "", "", fmt.Errorf("internal error while scanning row: %w", err)
+	}
+
+	return familyID, accountID, nil
+}
+
+// Deletes the session of the token family, the retired tokens of the family are deleted by the cascade.
+func (r AccountDB) DeleteSessionFamily(familyID string) error {
+	query := `DELETE FROM public.session WHERE family_id=$1`
+
+	if _, err := r.db.Exec(query, familyID); err != nil {
+		return fmt.Errorf("error while deleting session family: %w", err)
 	}
 
 	return nil
@@ -188,3 +315,628 @@ func (r AccountDB) DeleteSesions(accountID string) error {
 
 	return nil
 }
+
+// Returns the not expired sessions of the account, the newest first.
+func (r AccountDB) SelectAccountSessions(accountID string) ([]core.Session, error) {
+	sessions := []core.Session{}
+
+	query := `SELECT 
+		id, refresh_token, family_id, account_id, role, request_host, user_agent, client_ip, expired, created
+		FROM public.session
+		WHERE account_id=$1 AND expired > now()
+		ORDER BY created DESC`
+
+	rows, err := r.db.Query(query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("error while selecting sessions: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			session     core.Session
+			requestHost sql.NullString
+			userAgent   sql.NullString
+			clientIP    sql.NullString
+		)
+
+		err := rows.Scan(
+			&session.ID,
+			&session.RefreshToken,
+			&session.FamilyID,
+			&session.AccountID,
+			&session.Role,
+			&requestHost,
+			&userAgent,
+			&clientIP,
+			&session.Expired,
+			&session.Created,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("internal error while scanning row: %w", err)
+		}
+
+		session.RequestHost = requestHost.String
+		session.UserAgent = userAgent.String
+		session.ClientIP = clientIP.String
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Deletes the session of the account by its id.
+func (r AccountDB) DeleteSession(accountID, sessionID string) error {
+	query := `DELETE FROM public.session WHERE id=$1 AND account_id=$2`
+
+	result, err := r.db.Exec(query, sessionID, accountID)
+	if err != nil {
+		return fmt.Errorf("error while deleting session: %w", err)
+	}
+
+	rowAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unexpected error while RowsAffected: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return core.ErrSesseionNotFound
+	}
+
+	return nil
+}
+
+// Deletes the session of the account by its refresh token. The access token may keep
+// the refresh token which is already retired by the rotation, so the family of such token is looked up as well.
+func (r AccountDB) DeleteSessionByRefreshToken(accountID, refreshToken string) error {
+	query := `DELETE FROM public.session 
+		WHERE account_id=$2 AND (refresh_token=$1 OR family_id IN (
+			SELECT family_id FROM public.retired_refresh_token WHERE refresh_token=$1))`
+
+	result, err := r.db.Exec(query, refreshToken, accountID)
+	if err != nil {
+		return fmt.Errorf("error while deleting session: %w", err)
+	}
+
+	rowAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unexpected error while RowsAffected: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return core.ErrNoRowsEffected
+	}
+
+	return nil
+}
+
+// Reports whether the not expired session of the refresh token exists. The refresh token
+// may be already retired by the rotation, then the session of its family is checked.
+func (r AccountDB) IsSessionLive(refreshToken string) (bool, error) {
+	var live bool
+
+	query := `SELECT EXISTS (
+		SELECT 1 FROM public.session 
+		WHERE expired > now() AND (refresh_token=$1 OR family_id IN (
+			SELECT family_id FROM public.retired_refresh_token WHERE refresh_token=$1)))`
+
+	if err := r.db.QueryRow(query, refreshToken).Scan(&live); err != nil {
+		return false, fmt.Errorf("internal error while checking session: %w", err)
+	}
+
+	return live, nil
+}
+
+// Returns the accounts which satisfy the condition.
+func (r AccountDB) SelectAccounts(qp core.ConditionParams) ([]core.AccountInfo, error) {
+	builder := newQueryBuilder(accountColumns)
+
+	queryCondition, err := builder.build(qp)
+	if err != nil {
+		return nil, fmt.Errorf("can't build the query condition: %w", err)
+	}
+
+	query := `SELECT ` + accountInfoColumns + ` FROM public.account`
+
+	rows, err := r.db.Query(query+queryCondition, builder.args...)
+	if err != nil {
+		return nil, fmt.Errorf("error while selecting accounts: %w", err)
+	}
+
+	defer rows.Close()
+
+	accounts := []core.AccountInfo{}
+
+	for rows.Next() {
+		var account core.AccountInfo
+
+		if err := rows.Scan(
+			&account.ID,
+			&account.Phone,
+			&account.Age,
+			&account.Role,
+			&account.Disabled,
+			&account.PhoneVerified,
+			&account.Created,
+			&account.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("internal error while scanning row: %w", err)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (r AccountDB) SelectAccountInfo(accountID string) (core.AccountInfo, error) {
+	query := `SELECT ` + accountInfoColumns + ` FROM public.account WHERE id=$1`
+
+	return scanAccountInfo(r.db.QueryRow(query, accountID))
+}
+
+// Changes the role of the account and deletes its sessions,
+// so the access tokens with the previous role stop working.
+func (r AccountDB) UpdateAccountRole(accountID, role string) (core.AccountInfo, error) {
+	query := `UPDATE public.account SET role=$1 WHERE id=$2 RETURNING ` + accountInfoColumns
+
+	return r.updateAccount(accountID, true, query, role, accountID)
+}
+
+// Disables or enables the account. The sessions of the disabled account are deleted.
+func (r AccountDB) UpdateAccountDisabled(accountID string, disabled bool) (core.AccountInfo, error) {
+	query := `UPDATE public.account SET disabled=$1 WHERE id=$2 RETURNING ` + accountInfoColumns
+
+	return r.updateAccount(accountID, disabled, query, disabled, accountID)
+}
+
+// Executes the update query of the account and deletes the account sessions if it is requested.
+func (r AccountDB) updateAccount(accountID string, deleteSessions bool, query string, args ...any,
+) (core.AccountInfo, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return core.AccountInfo{}, fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	account, err := scanAccountInfo(tx.QueryRow(query, args...))
+	if err != nil {
+		return core.AccountInfo{}, err
+	}
+
+	if deleteSessions {
+		if _, err := tx.Exec(`DELETE FROM public.session WHERE account_id=$1`, accountID); err != nil {
+			return core.AccountInfo{}, fmt.Errorf("error while deleting sessions: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.AccountInfo{}, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return account, nil
+}
+
+// Makes the account with the phone an admin, but only if there is no admin yet.
+// Reports whether the account was promoted.
+func (r AccountDB) PromoteFirstAdmin(phone string) (bool, error) {
+	query := `UPDATE public.account SET role=$1 
+		WHERE phone=$2 AND NOT EXISTS (SELECT 1 FROM public.account WHERE role=$1)`
+
+	result, err := r.db.Exec(query, core.RoleAdmin, phone)
+	if err != nil {
+		return false, fmt.Errorf("error while promoting the first admin: %w", err)
+	}
+
+	rowAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("unexpected error while RowsAffected: %w", err)
+	}
+
+	return rowAffected > 0, nil
+}
+
+func scanAccountInfo(row *sql.Row) (core.AccountInfo, error) {
+	var account core.AccountInfo
+
+	err := row.Scan(
+		&account.ID,
+		&account.Phone,
+		&account.Age,
+		&account.Role,
+		&account.Disabled,
+		&account.PhoneVerified,
+		&account.Created,
+		&account.Modified,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.AccountInfo{}, core.ErrUserNotFound
+		}
+
+		return core.AccountInfo{}, fmt.Errorf("internal error while scanning row: %w", err)
+	}
+
+	return account, nil
+}
+
+func (r AccountDB) UpdateAccountAge(accountID string, age int) (core.AccountInfo, error) {
+	query := `UPDATE public.account SET age=$1 WHERE id=$2 RETURNING ` + accountInfoColumns
+
+	return scanAccountInfo(r.db.QueryRow(query, age, accountID))
+}
+
+// Replaces the password hash and deletes the sessions of the account except the one of the refresh token
+// in one transaction. The refresh token may be already retired by the rotation, then the session of its family is kept.
+func (r AccountDB) ChangePassword(accountID, passwordHash, keepRefreshToken string) error {
+	return r.inTransaction(func(tx *sqlx.Tx) error {
+		result, err := tx.Exec(`UPDATE public.account SET password=$1, modified=now() WHERE id=$2`,
+			passwordHash, accountID)
+		if err != nil {
+			return fmt.Errorf("can't update the account password: %w", err)
+		}
+
+		rowAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't get the affected rows: %w", err)
+		}
+
+		if rowAffected == 0 {
+			return core.ErrUserNotFound
+		}
+
+		query := `DELETE FROM public.session 
+			WHERE account_id=$2 AND refresh_token<>$1 AND family_id NOT IN (
+				SELECT family_id FROM public.retired_refresh_token WHERE refresh_token=$1)`
+
+		if _, err := tx.Exec(query, keepRefreshToken, accountID); err != nil {
+			return fmt.Errorf("error while deleting sessions: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Deletes the account together with its sessions, lists, ratings, linked identities and one-time codes
+// and reports how many rows were removed.
+// The average rate and votes of the movies the account rated are recounted.
+func (r AccountDB) DeleteAccount(accountID string) (core.ErasureReport, error) {
+	report := core.ErasureReport{AccountID: accountID}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return core.ErasureReport{}, fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	// The movie rows are locked in the same way as the rating changes lock them.
+	lockQuery := `SELECT m.id FROM public.movie m 
+		JOIN public.rating r ON r.movie_id=m.id 
+		WHERE r.account_id=$1 ORDER BY m.id FOR UPDATE OF m`
+
+	var ratedMovies []string
+
+	if err := tx.Select(&ratedMovies, lockQuery, accountID); err != nil {
+		return core.ErasureReport{}, fmt.Errorf("error while locking rated movies: %w", err)
+	}
+
+	deletions := []struct {
+		query   string
+		removed *int64
+	}{
+		{`DELETE FROM public.rating WHERE account_id=$1`, &report.Ratings},
+		{`DELETE FROM public.movie_list WHERE list_id IN (SELECT id FROM public.list WHERE account_id=$1)`, &report.ListMovies},
+		{`DELETE FROM public.list WHERE account_id=$1`, &report.Lists},
+		{`DELETE FROM public.session WHERE account_id=$1`, &report.Sessions},
+		{`DELETE FROM public.account_identity WHERE account_id=$1`, &report.Identities},
+		{`DELETE FROM public.one_time_code WHERE account_id=$1`, &report.OneTimeCodes},
+	}
+
+	for _, deletion := range deletions {
+		result, err := tx.Exec(deletion.query, accountID)
+		if err != nil {
+			return core.ErasureReport{}, fmt.Errorf("error while deleting account data: %w", err)
+		}
+
+		if *deletion.removed, err = result.RowsAffected(); err != nil {
+			return core.ErasureReport{}, fmt.Errorf("unexpected error while RowsAffected: %w", err)
+		}
+	}
+
+	recountQuery := `UPDATE public.movie m SET
+		avg_rate=COALESCE((SELECT ROUND(AVG(score), 1) FROM public.rating WHERE movie_id=m.id), 0),
+		votes=(SELECT COUNT(*) FROM public.rating WHERE movie_id=m.id)
+		WHERE m.id=ANY($1)`
+
+	if _, err := tx.Exec(recountQuery, pq.Array(ratedMovies)); err != nil {
+		return core.ErasureReport{}, fmt.Errorf("error while recounting movie ratings: %w", err)
+	}
+
+	// The failed logins are counted by the phone, which must not outlive the account.
+	attemptsQuery := `DELETE FROM public.login_attempt 
+		WHERE key = 'phone:' || (SELECT phone FROM public.account WHERE id=$1)`
+
+	if _, err := tx.Exec(attemptsQuery, accountID); err != nil {
+		return core.ErasureReport{}, fmt.Errorf("error while deleting login attempts: %w", err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM public.account WHERE id=$1`, accountID)
+	if err != nil {
+		return core.ErasureReport{}, fmt.Errorf("error while deleting account: %w", err)
+	}
+
+	rowAffected, err := result.RowsAffected()
+	if err != nil {
+		return core.ErasureReport{}, fmt.Errorf("unexpected error while RowsAffected: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return core.ErasureReport{}, core.ErrUserNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.ErasureReport{}, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return report, nil
+}
+
+// Returns everything stored about the account. The data is read in one snapshot,
+// so the export is consistent even if the account is changed at the same time.
+func (r AccountDB) SelectAccountExport(accountID string) (core.AccountExport, error) {
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return core.AccountExport{}, fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	var export core.AccountExport
+
+	export.Account, err = scanAccountInfo(tx.QueryRow(
+		`SELECT `+accountInfoColumns+` FROM public.account WHERE id=$1`, accountID))
+	if err != nil {
+		return core.AccountExport{}, err
+	}
+
+	if export.Sessions, err = selectExportedSessions(tx, accountID); err != nil {
+		return core.AccountExport{}, err
+	}
+
+	if export.Lists, err = selectExportedLists(tx, accountID); err != nil {
+		return core.AccountExport{}, err
+	}
+
+	export.Ratings = []core.Rating{}
+
+	ratingQuery := `SELECT account_id, movie_id, score, created, modified 
+		FROM public.rating WHERE account_id=$1 ORDER BY created`
+
+	if err := tx.Select(&export.Ratings, ratingQuery, accountID); err != nil {
+		return core.AccountExport{}, fmt.Errorf("error while selecting ratings: %w", err)
+	}
+
+	export.Identities = []core.ExportedIdentity{}
+
+	identityQuery := `SELECT issuer, subject, created 
+		FROM public.account_identity WHERE account_id=$1 ORDER BY created`
+
+	if err := tx.Select(&export.Identities, identityQuery, accountID); err != nil {
+		return core.AccountExport{}, fmt.Errorf("error while selecting identities: %w", err)
+	}
+
+	return export, nil
+}
+
+// Returns all the sessions of the account including the expired ones.
+func selectExportedSessions(tx *sqlx.Tx, accountID string) ([]core.SessionInfo, error) {
+	query := `SELECT id, request_host, user_agent, client_ip, created, expired 
+		FROM public.session WHERE account_id=$1 ORDER BY created`
+
+	rows, err := tx.Query(query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("error while selecting sessions: %w", err)
+	}
+
+	defer rows.Close()
+
+	sessions := []core.SessionInfo{}
+
+	for rows.Next() {
+		var (
+			session     core.SessionInfo
+			requestHost sql.NullString
+			userAgent   sql.NullString
+			clientIP    sql.NullString
+		)
+
+		if err := rows.Scan(
+			&session.ID,
+			&requestHost,
+			&userAgent,
+			&clientIP,
+			&session.Created,
+			&session.Expired,
+		); err != nil {
+			return nil, fmt.Errorf("internal error while scanning row: %w", err)
+		}
+
+		session.RequestHost = requestHost.String
+		session.UserAgent = userAgent.String
+		session.ClientIP = clientIP.String
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Returns the lists of the account together with all their movies.
+func selectExportedLists(tx *sqlx.Tx, accountID string) ([]core.ListExport, error) {
+	var lists []core.MovieList
+
+	listQuery := `SELECT id, type, account_id, created, modified 
+		FROM public.list WHERE account_id=$1 ORDER BY created`
+
+	if err := tx.Select(&lists, listQuery, accountID); err != nil {
+		return nil, fmt.Errorf("error while selecting lists: %w", err)
+	}
+
+	var movies []struct {
+		ListID string `db:"list_id"`
+		core.ExportedListMovie
+	}
+
+	movieQuery := `SELECT ml.list_id, m.id AS movie_id, m.title 
+		FROM public.movie_list ml 
+		JOIN public.list l ON l.id=ml.list_id 
+		JOIN public.movie m ON m.id=ml.movie_id 
+		WHERE l.account_id=$1 ORDER BY m.title`
+
+	if err := tx.Select(&movies, movieQuery, accountID); err != nil {
+		return nil, fmt.Errorf("error while selecting list movies: %w", err)
+	}
+
+	exported := make([]core.ListExport, 0, len(lists))
+
+	for _, list := range lists {
+		listExport := core.ListExport{MovieList: list, Movies: []core.ExportedListMovie{}}
+
+		for _, movie := range movies {
+			if movie.ListID == list.ID.String() {
+				listExport.Movies = append(listExport.Movies, movie.ExportedListMovie)
+			}
+		}
+
+		exported = append(exported, listExport)
+	}
+
+	return exported, nil
+}
+
+// Stores the code replacing the previous code of the same purpose, the attempts are counted from zero.
+func (r AccountDB) UpsertOneTimeCode(code core.OneTimeCode) error {
+	query := `INSERT INTO public.one_time_code(account_id, purpose, code_hash, expired) 
+		VALUES ($1, $2, $3, $4) 
+		ON CONFLICT (account_id, purpose) DO UPDATE 
+		SET code_hash=EXCLUDED.code_hash, expired=EXCLUDED.expired, attempts=0, created=now()`
+
+	if _, err := r.db.Exec(query, code.AccountID, code.Purpose, code.CodeHash, code.Expired); err != nil {
+		return fmt.Errorf("error while storing the code: %w", err)
+	}
+
+	return nil
+}
+
+func (r AccountDB) SelectOneTimeCode(accountID string, purpose core.CodePurpose) (core.OneTimeCode, error) {
+	query := `SELECT account_id, purpose, code_hash, attempts, expired, created 
+		FROM public.one_time_code WHERE account_id=$1 AND purpose=$2`
+
+	return scanOneTimeCode(r.db.QueryRow(query, accountID, purpose))
+}
+
+// Counts one more attempt to confirm the code and returns the code with the counted attempt.
+// The attempt is counted before the code is compared, so the parallel guesses can't exceed the limit.
+func (r AccountDB) AddCodeAttempt(accountID string, purpose core.CodePurpose) (core.OneTimeCode, error) {
+	query := `UPDATE public.one_time_code SET attempts=attempts+1 
+		WHERE account_id=$1 AND purpose=$2 
+		RETURNING account_id, purpose, code_hash, attempts, expired, created`
+
+	return scanOneTimeCode(r.db.QueryRow(query, accountID, purpose))
+}
+
+// Marks the phone of the account as verified and deletes the used code.
+func (r AccountDB) ConfirmPhone(accountID string) error {
+	return r.inTransaction(func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(`UPDATE public.account SET phone_verified=true WHERE id=$1`, accountID); err != nil {
+			return fmt.Errorf("error while confirming the phone: %w", err)
+		}
+
+		return deleteOneTimeCode(tx, accountID, core.PhoneVerification)
+	})
+}
+
+// Replaces the password, deletes the used code and all the sessions of the account.
+// The phone is marked as verified, because the code was received on it.
+func (r AccountDB) ResetPassword(accountID, passwordHash string) error {
+	return r.inTransaction(func(tx *sqlx.Tx) error {
+		query := `UPDATE public.account SET password=$1, phone_verified=true WHERE id=$2`
+
+		if _, err := tx.Exec(query, passwordHash, accountID); err != nil {
+			return fmt.Errorf("error while resetting the password: %w", err)
+		}
+
+		if _, err := tx.Exec(`DELETE FROM public.session WHERE account_id=$1`, accountID); err != nil {
+			return fmt.Errorf("error while deleting sessions: %w", err)
+		}
+
+		return deleteOneTimeCode(tx, accountID, core.PasswordReset)
+	})
+}
+
+func (r AccountDB) inTransaction(change func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := change(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func deleteOneTimeCode(tx *sqlx.Tx, accountID string, purpose core.CodePurpose) error {
+	query := `DELETE FROM public.one_time_code WHERE account_id=$1 AND purpose=$2`
+
+	if _, err := tx.Exec(query, accountID, purpose); err != nil {
+		return fmt.Errorf("error while deleting the code: %w", err)
+	}
+
+	return nil
+}
+
+func scanOneTimeCode(row *sql.Row) (core.OneTimeCode, error) {
+	var code core.OneTimeCode
+
+	err := row.Scan(
+		&code.AccountID,
+		&code.Purpose,
+		&code.CodeHash,
+		&code.Attempts,
+		&code.Expired,
+		&code.Created,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.OneTimeCode{}, core.ErrCodeNotFound
+		}
+
+		return core.OneTimeCode{}, fmt.Errorf("internal error while scanning row: %w", err)
+	}
+
+	return code, nil
+}
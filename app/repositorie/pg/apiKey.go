@@ -0,0 +1,135 @@
+package pg
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// The key is used often, so the last use is written not more often than this.
+const apiKeyLastUsedPrecision = time.Minute
+
+const apiKeyColumns = `id, name, prefix, scopes, COALESCE(created_by::text, ''), expires, last_used, revoked, created`
+
+type APIKeyDB struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyDB(db *sqlx.DB) APIKeyDB {
+	return APIKeyDB{db: db}
+}
+
+// Inserts the key with the hash of its secret and returns it with the generated fields.
+func (d APIKeyDB) InsertAPIKey(key core.APIKey, keyHash string) (core.APIKey, error) {
+	query := `INSERT INTO public.api_key(name, prefix, key_hash, scopes, created_by, expires)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + apiKeyColumns
+
+	key, err := scanAPIKey(d.db.QueryRow(query,
+		key.Name, key.Prefix, keyHash, pq.Array(key.Scopes), key.CreatedBy, key.Expires))
+	if err != nil {
+		return core.APIKey{}, fmt.Errorf("an error occurs while inserting the api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Returns all the keys including the revoked and the expired ones, the newest first.
+func (d APIKeyDB) SelectAPIKeys() ([]core.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM public.api_key ORDER BY created DESC`
+
+	rows, err := d.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurs while getting the api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []core.APIKey{}
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("an error occurs while scanning the api key: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("an error occurs while iterating the api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (d APIKeyDB) SelectAPIKeyByHash(keyHash string) (core.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM public.api_key WHERE key_hash=$1`
+
+	key, err := scanAPIKey(d.db.QueryRow(query, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.APIKey{}, core.ErrAPIKeyNotFound
+		}
+
+		return core.APIKey{}, fmt.Errorf("an error occurs while getting the api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Marks the key as revoked. The key stays in the list, so the admin sees when it was revoked.
+func (d APIKeyDB) RevokeAPIKey(id string) error {
+	query := `UPDATE public.api_key SET revoked=now() WHERE id=$1 AND revoked IS NULL`
+
+	result, err := d.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("an error occurs while revoking the api key: %w", err)
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected row: %w", err)
+	}
+
+	if affectedRows == 0 {
+		return core.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// Records the use of the key unless it was recorded recently.
+func (d APIKeyDB) UpdateAPIKeyLastUsed(id string, used time.Time) error {
+	query := `UPDATE public.api_key SET last_used=$2
+		WHERE id=$1 AND (last_used IS NULL OR last_used < $2 - make_interval(secs => $3))`
+
+	if _, err := d.db.Exec(query, id, used, apiKeyLastUsedPrecision.Seconds()); err != nil {
+		return fmt.Errorf("an error occurs while updating the last use of the api key: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (core.APIKey, error) {
+	var (
+		key    core.APIKey
+		scopes []string
+	)
+
+	if err := row.Scan(&key.ID, &key.Name, &key.Prefix, pq.Array(&scopes), &key.CreatedBy,
+		&key.Expires, &key.LastUsed, &key.Revoked, &key.Created); err != nil {
+		return core.APIKey{}, fmt.Errorf("can't scan the api key: %w", err)
+	}
+
+	key.Scopes = make([]core.Permission, 0, len(scopes))
+
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, core.Permission(scope))
+	}
+
+	return key, nil
+}
@@ -4,12 +4,25 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/Brigant/PetPorject/app/core"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq" // the blank import is needed beceause of sqlx requirements
+	"github.com/lib/pq"
 )
 
+// The columns of the director table which are allowed for filtering and sorting.
+var directorColumns = queryColumns{
+	filter: map[string]filterColumn{
+		"name":        {name: "name", operator: opContains},
+		"name_prefix": {name: "name", operator: opStartsWith},
+	},
+	sort: map[string]string{
+		"name":       "name",
+		"birth_date": "birth_date",
+	},
+}
+
 type DirectorDB struct {
 	db *sqlx.DB
 }
@@ -27,6 +40,11 @@ func (d DirectorDB) InsertDirector(director core.Director) error {
 
 	result, err := d.db.DB.Exec(query, director.Name, director.BirthDate.Time)
 	if err != nil {
+		pqErr := new(pq.Error)
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeUniqueViolation {
+			return core.ErrDublicatDirector
+		}
+
 		return fmt.Errorf("can't exec because: %w", err)
 	}
 
@@ -42,16 +60,32 @@ func (d DirectorDB) InsertDirector(director core.Director) error {
 	return nil
 }
 
-// The method selects the director speciofied by ID and returns it.
+// The method selects the director speciofied by ID with the summary of the director movies and returns it.
 func (d DirectorDB) SelectDirectorByID(directorID string) (core.Director, error) {
-	query := `SELECT id, name, birth_date, created, modified
-		FROM public.director
-		WHERE id=$1`
+	query := `SELECT d.id, d.name, d.birth_date, d.created, d.modified, d.photo_url,
+			s.movies_count, s.avg_rate, s.first_release_year, s.latest_release_year, s.last_modified
+		FROM public.director AS d
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS movies_count,
+				ROUND(AVG(m.avg_rate) FILTER (WHERE m.votes > 0), 1)::float AS avg_rate,
+				MIN(EXTRACT(YEAR FROM m.release_date))::int AS first_release_year,
+				MAX(EXTRACT(YEAR FROM m.release_date))::int AS latest_release_year,
+				COALESCE(MAX(m.modified)::text, '') AS last_modified
+			FROM public.movie AS m
+			WHERE m.director_id=d.id
+		) AS s
+		WHERE d.id=$1`
 
-	var director core.Director
+	var (
+		director     core.Director
+		summary      core.DirectorSummary
+		lastModified string
+	)
 
 	err := d.db.DB.QueryRow(query, directorID).Scan(
-		&director.ID, &director.Name, &director.BirthDate.Time, &director.Created, &director.Modified)
+		&director.ID, &director.Name, &director.BirthDate.Time, &director.Created, &director.Modified,
+		&director.PhotoURL, &summary.MoviesCount, &summary.AvgRate, &summary.FirstReleaseYear,
+		&summary.LatestReleaseYear, &lastModified)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return core.Director{}, core.ErrNowDirectorFound
@@ -60,17 +94,63 @@ func (d DirectorDB) SelectDirectorByID(directorID string) (core.Director, error)
 		return core.Director{}, fmt.Errorf("errow while Select director: %w", err)
 	}
 
+	// The count changes when the movie is added or removed, the latest modified timestamp
+	// changes when any movie is changed, including its average rate.
+	summary.Version = strconv.Itoa(summary.MoviesCount) + "-" + lastModified
+	director.Summary = &summary
+
 	return director, nil
 }
 
-// The method grabs the all directors and returns it in the slice.
-func (d DirectorDB) SelectDirectorList() ([]core.Director, error) {
-	query := `SELECT id, name, birth_date::timestamp, created, modified
+// The method grabs the page of the director movies which satisfy the conditions.
+// The conditions have the same rules as for the list of all movies.
+func (d DirectorDB) SelectDirectorMovies(directorID string, qp core.ConditionParams) ([]core.Movie, error) {
+	builder := newQueryBuilder(movieColumns, directorID)
+
+	queryCondition, err := builder.buildWithPredicate("director_id=$1", qp)
+	if err != nil {
+		return nil, fmt.Errorf("can't build the query condition: %w", err)
+	}
+
+	query := `SELECT id, director_id, title, genre, rate, release_date, duration, avg_rate, votes, created, modified
+		FROM public.movie`
+
+	var movieList []core.Movie
+	if err := d.db.Select(&movieList, query+queryCondition, builder.args...); err != nil {
+		return nil, fmt.Errorf("an error occurs while getting the director movies: %w", err)
+	}
+
+	if len(movieList) > 0 {
+		return movieList, nil
+	}
+
+	var exists bool
+	if err := d.db.Get(&exists, `SELECT EXISTS(SELECT 1 FROM public.director WHERE id=$1)`, directorID); err != nil {
+		return nil, fmt.Errorf("can't check the director existence: %w", err)
+	}
+
+	if !exists {
+		return nil, core.ErrNowDirectorFound
+	}
+
+	return []core.Movie{}, nil
+}
+
+// The method grabs the page of the directors which satisfy the conditions and returns it in the slice.
+func (d DirectorDB) SelectDirectorList(qp core.ConditionParams) ([]core.Director, error) {
+	builder := newQueryBuilder(directorColumns)
+
+	queryCondition, err := builder.build(qp)
+	if err != nil {
+		return nil, fmt.Errorf("can't build the query condition: %w", err)
+	}
+
+	query := `SELECT id, name, birth_date::timestamp, created, modified, photo_url
 		FROM public.director`
 
 	var directorsList []core.Director
 
-	rows, err := d.db.DB.Query(query)
+	rows, err := d.db.DB.Query(query+queryCondition, builder.args...)
 	if err != nil {
 		return nil, fmt.Errorf("error while Query: %w", err)
 	}
@@ -88,7 +168,8 @@ func (d DirectorDB) SelectDirectorList() ([]core.Director, error) {
 			&director.Name,
 			&director.BirthDate.Time,
 			&director.Created,
-			&director.Modified); err != nil {
+			&director.Modified,
+			&director.PhotoURL); err != nil {
 			return nil, fmt.Errorf("error while scan director list: %w", err)
 		}
 
@@ -97,3 +178,98 @@ func (d DirectorDB) SelectDirectorList() ([]core.Director, error) {
 
 	return directorsList, nil
 }
+
+// The method updates the name and the birth date of the director and returns the new modified timestamp.
+// If the director has the Modified field, it is updated only when the stored timestamp is the same,
+// otherwise core.ErrPreconditionFailed is returned.
+func (d DirectorDB) UpdateDirector(director core.Director) (string, error) {
+	query := `UPDATE public.director SET name=$1, birth_date=$2 WHERE id=$3`
+	args := []any{director.Name, director.BirthDate.Time, director.ID}
+
+	if director.Modified != "" {
+		query += ` AND modified=CAST($4 AS timestamptz)`
+
+		args = append(args, director.Modified)
+	}
+
+	query += ` RETURNING modified`
+
+	var modified string
+
+	err := d.db.DB.QueryRow(query, args...).Scan(&modified)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", d.missingOrModified(director)
+		}
+
+		pqErr := new(pq.Error)
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeUniqueViolation {
+			return "", core.ErrDublicatDirector
+		}
+
+		return "", fmt.Errorf("can't update the director: %w", err)
+	}
+
+	return modified, nil
+}
+
+// Explains why the conditional update of the director has not changed any row.
+func (d DirectorDB) missingOrModified(director core.Director) error {
+	if director.Modified == "" {
+		return core.ErrNowDirectorFound
+	}
+
+	var exists bool
+	if err := d.db.Get(&exists, `SELECT EXISTS(SELECT 1 FROM public.director WHERE id=$1)`, director.ID); err != nil {
+		return fmt.Errorf("can't check the director existence: %w", err)
+	}
+
+	if !exists {
+		return core.ErrNowDirectorFound
+	}
+
+	return core.ErrPreconditionFailed
+}
+
+// The method sets the new photo url of the director and returns the previous one,
+// so the replaced object can be removed from the blob store.
+func (d DirectorDB) UpdateDirectorPhoto(directorID, photoURL string) (string, error) {
+	query := `UPDATE public.director AS d SET photo_url=$1
+		FROM (SELECT id, photo_url FROM public.director WHERE id=$2 FOR UPDATE) AS previous
+		WHERE d.id=previous.id
+		RETURNING previous.photo_url`
+
+	var previousURL string
+
+	if err := d.db.DB.QueryRow(query, photoURL, directorID).Scan(&previousURL); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", core.ErrNowDirectorFound
+		}
+
+		return "", fmt.Errorf("can't update the director photo: %w", err)
+	}
+
+	return previousURL, nil
+}
+
+// The method deletes the director which has no movies and returns the url of the director photo.
+func (d DirectorDB) DeleteDirector(directorID string) (string, error) {
+	query := `DELETE FROM public.director WHERE id=$1 RETURNING photo_url`
+
+	var photoURL string
+
+	if err := d.db.DB.QueryRow(query, directorID).Scan(&photoURL); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", core.ErrNowDirectorFound
+		}
+
+		pqErr := new(pq.Error)
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeForeignKeyViolation {
+			return "", core.ErrDirectorHasMovie
+		}
+
+		return "", fmt.Errorf("can't delete the director: %w", err)
+	}
+
+	return photoURL, nil
+}
@@ -1,15 +1,23 @@
 package pg
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/Brigant/PetPorject/app/core"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
 
+// The columns of the list table which are allowed for filtering.
+var listColumns = queryColumns{
+	filter: map[string]filterColumn{
+		"account_id": {name: "account_id", operator: opEqual},
+		"type":       {name: "type", operator: opEqual},
+	},
+}
+
 type ListDB struct {
 	db *sqlx.DB
 }
@@ -81,13 +89,18 @@ func (d ListDB) InsertMovieToList(listID, moviID string) error {
 func (d ListDB) SelectAllUsersLists(conditions []core.QuerySliceElement) ([]core.MovieList, error) {
 	var list []core.MovieList
 
-	query := `SELECT * FROM public.list `
+	query := `SELECT * FROM public.list`
+
+	builder := newQueryBuilder(listColumns)
 
-	where := d.prepareCond(conditions)
+	where, err := builder.where(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("can't build the query condition: %w", err)
+	}
 
 	fullQuery := query + where
 
-	if err := d.db.Select(&list, fullQuery); err != nil {
+	if err := d.db.Select(&list, fullQuery, builder.args...); err != nil {
 		pqErr := new(pq.Error)
 		if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeUndefinedColumn {
 			return nil, core.ErrUnkownConditionKey
@@ -99,24 +112,181 @@ func (d ListDB) SelectAllUsersLists(conditions []core.QuerySliceElement) ([]core
 	return list, nil
 }
 
-func (d ListDB) prepareCond(cond []core.QuerySliceElement) string {
-	where := "WHERE "
+// Select the list which belongs to the account. The list of another account is not found as well.
+func (d ListDB) SelectListByID(listID, accountID string) (core.MovieList, error) {
+	query := `SELECT id, type, account_id, created, modified
+		FROM public.list WHERE id=$1 AND account_id=$2`
 
-	if len(cond) == 1 {
-		where += cond[0].Key + "='" + cond[0].Val + "'"
+	var list core.MovieList
+	if err := d.db.Get(&list, query, listID, accountID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.MovieList{}, core.ErrListNotFound
+		}
 
-		return where
+		return core.MovieList{}, fmt.Errorf("an error occurs while getting the list: %w", err)
 	}
 
-	for i := 0; i < len(cond); i++ {
-		if i == 0 {
-			where += cond[0].Key + "='" + cond[0].Val + "' AND ("
-		} else {
-			where = where + cond[i].Key + "='" + cond[i].Val + "' OR "
+	return list, nil
+}
+
+// Select the page of the list movies with the names of their directors.
+// The conditions have the same rules as for the list of all movies.
+func (d ListDB) SelectListMovies(listID string, qp core.ConditionParams) ([]core.ListMovie, error) {
+	builder := newQueryBuilder(joinedMovieColumns, listID)
+
+	queryCondition, err := builder.buildWithPredicate("ml.list_id=$1", qp)
+	if err != nil {
+		return nil, fmt.Errorf("can't build the query condition: %w", err)
+	}
+
+	query := `SELECT m.id, m.director_id, m.title, m.genre, m.rate, m.release_date, m.duration,
+			m.avg_rate, m.votes, m.created, m.modified, d.name AS director_name
+		FROM public.movie_list AS ml
+		INNER JOIN public.movie AS m ON m.id=ml.movie_id
+		INNER JOIN public.director AS d ON d.id=m.director_id`
+
+	movies := []core.ListMovie{}
+	if err := d.db.Select(&movies, query+queryCondition, builder.args...); err != nil {
+		return nil, fmt.Errorf("an error occurs while getting the list movies: %w", err)
+	}
+
+	return movies, nil
+}
+
+// Delete the movie from the list.
+func (d ListDB) DeleteMovieFromList(listID, movieID string) error {
+	query := `DELETE FROM public.movie_list WHERE list_id=$1 AND movie_id=$2`
+
+	result, err := d.db.Exec(query, listID, movieID)
+	if err != nil {
+		return fmt.Errorf("delete from movie_list got the error: %w", err)
+	}
+
+	affectedRow, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected row: %w", err)
+	}
+
+	if affectedRow == 0 {
+		return core.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete the list of the account together with its movie_list rows.
+func (d ListDB) DeleteList(listID, accountID string) error {
+	lockQuery := `SELECT id FROM public.list WHERE id=$1 AND account_id=$2 FOR UPDATE`
+
+	tx, err := d.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	var lockedID string
+	if err := tx.QueryRow(lockQuery, listID, accountID).Scan(&lockedID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.ErrListNotFound
 		}
+
+		return fmt.Errorf("can't lock the list: %w", err)
 	}
 
-	where = strings.TrimSuffix(where, " OR ") + ")"
+	if _, err := tx.Exec(`DELETE FROM public.movie_list WHERE list_id=$1`, listID); err != nil {
+		return fmt.Errorf("can't delete the list movies: %w", err)
+	}
 
-	return where
+	if _, err := tx.Exec(`DELETE FROM public.list WHERE id=$1`, listID); err != nil {
+		return fmt.Errorf("can't delete the list: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Change the type of the list of the account and return the updated list.
+func (d ListDB) UpdateListType(listID, accountID, listType string) (core.MovieList, error) {
+	query := `UPDATE public.list SET type=$1 WHERE id=$2 AND account_id=$3
+		RETURNING id, type, account_id, created, modified`
+
+	var list core.MovieList
+	if err := d.db.Get(&list, query, listType, listID, accountID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.MovieList{}, core.ErrListNotFound
+		}
+
+		pqErr := new(pq.Error)
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeUniqueViolation {
+			return core.MovieList{}, core.ErrDuplicateRow
+		}
+
+		return core.MovieList{}, fmt.Errorf("can't update the list: %w", err)
+	}
+
+	return list, nil
+}
+
+// Add the movie to the system list of the account. The list is created if the account has not got it yet,
+// and the movie which is already in the list is not an error.
+func (d ListDB) InsertMovieToSystemList(accountID string, listType core.ListType, movieID string) error {
+	listQuery := `INSERT INTO public.list(account_id, type) VALUES ($1, $2)
+		ON CONFLICT (account_id, type) DO NOTHING`
+
+	movieQuery := `INSERT INTO public.movie_list(list_id, movie_id)
+		SELECT id, $3 FROM public.list WHERE account_id=$1 AND type=$2
+		ON CONFLICT (list_id, movie_id) DO NOTHING`
+
+	tx, err := d.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.Exec(listQuery, accountID, listType); err != nil {
+		return fmt.Errorf("can't create the %s list: %w", listType, err)
+	}
+
+	if _, err := tx.Exec(movieQuery, accountID, listType, movieID); err != nil {
+		pqErr := new(pq.Error)
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeForeignKeyViolation {
+			return core.ErrForeignKeyViolation
+		}
+
+		return fmt.Errorf("insert to movie_list got the error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete the movie from the system list of the account.
+func (d ListDB) DeleteMovieFromSystemList(accountID string, listType core.ListType, movieID string) error {
+	query := `DELETE FROM public.movie_list AS ml
+		USING public.list AS l
+		WHERE ml.list_id=l.id AND l.account_id=$1 AND l.type=$2 AND ml.movie_id=$3`
+
+	result, err := d.db.Exec(query, accountID, listType, movieID)
+	if err != nil {
+		return fmt.Errorf("delete from movie_list got the error: %w", err)
+	}
+
+	affectedRow, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected row: %w", err)
+	}
+
+	if affectedRow == 0 {
+		return core.ErrNotFound
+	}
+
+	return nil
 }
@@ -0,0 +1,86 @@
+package pg
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/jmoiron/sqlx"
+)
+
+type LoginAttemptDB struct {
+	db *sqlx.DB
+}
+
+func NewLoginAttemptDB(db *sqlx.DB) LoginAttemptDB {
+	return LoginAttemptDB{db: db}
+}
+
+// Returns the failed logins of the key. The key without failures is not an error.
+func (r LoginAttemptDB) SelectLoginAttempts(key string) (core.LoginAttempts, error) {
+	query := `SELECT key, failures, last_failure, locked_until FROM public.login_attempt WHERE key=$1`
+
+	attempts, err := scanLoginAttempts(r.db.QueryRow(query, key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return core.LoginAttempts{Key: key}, nil
+	}
+
+	return attempts, err
+}
+
+// Counts one more failure of the key. The failures older than the window are forgotten,
+// the keys with only such failures and without the active lock are deleted on the way.
+func (r LoginAttemptDB) AddLoginFailure(key string, window time.Duration) (core.LoginAttempts, error) {
+	cleanupQuery := `DELETE FROM public.login_attempt 
+		WHERE last_failure < now() - make_interval(secs => $1) 
+			AND (locked_until IS NULL OR locked_until < now())`
+
+	if _, err := r.db.Exec(cleanupQuery, window.Seconds()); err != nil {
+		return core.LoginAttempts{}, fmt.Errorf("error while deleting the forgotten login attempts: %w", err)
+	}
+
+	query := `INSERT INTO public.login_attempt(key, failures, last_failure) VALUES ($1, 1, now()) 
+		ON CONFLICT (key) DO UPDATE SET 
+			failures=CASE WHEN login_attempt.last_failure < now() - make_interval(secs => $2) 
+				THEN 1 ELSE login_attempt.failures + 1 END, 
+			last_failure=now() 
+		RETURNING key, failures, last_failure, locked_until`
+
+	return scanLoginAttempts(r.db.QueryRow(query, key, window.Seconds()))
+}
+
+func (r LoginAttemptDB) LockLogin(key string, until time.Time) error {
+	query := `UPDATE public.login_attempt SET locked_until=$1 WHERE key=$2`
+
+	if _, err := r.db.Exec(query, until, key); err != nil {
+		return fmt.Errorf("error while locking the login: %w", err)
+	}
+
+	return nil
+}
+
+// Forgets the failures and the lock of the key.
+func (r LoginAttemptDB) DeleteLoginAttempts(key string) error {
+	if _, err := r.db.Exec(`DELETE FROM public.login_attempt WHERE key=$1`, key); err != nil {
+		return fmt.Errorf("error while deleting the login attempts: %w", err)
+	}
+
+	return nil
+}
+
+func scanLoginAttempts(row *sql.Row) (core.LoginAttempts, error) {
+	var (
+		attempts    core.LoginAttempts
+		lockedUntil sql.NullTime
+	)
+
+	if err := row.Scan(&attempts.Key, &attempts.Failures, &attempts.LastFailure, &lockedUntil); err != nil {
+		return core.LoginAttempts{}, fmt.Errorf("internal error while scanning row: %w", err)
+	}
+
+	attempts.LockedUntil = lockedUntil.Time
+
+	return attempts, nil
+}
@@ -10,6 +10,36 @@ import (
 	"github.com/lib/pq"
 )
 
+// The columns of the movie table which are allowed for filtering and sorting.
+// The rate key is related to the average of the users ratings.
+var movieColumns = queryColumns{
+	filter: map[string]filterColumn{
+		"genre": {name: "genre", operator: opEqual},
+		"rate":  {name: "avg_rate", operator: opGreaterOrEqual},
+	},
+	sort: map[string]string{
+		"rate":         "avg_rate",
+		"votes":        "votes",
+		"release_date": "release_date",
+		"duration":     "duration",
+	},
+}
+
+// The same columns as movieColumns, but qualified with the alias of the movie table
+// for the queries which join the movie with other tables.
+var joinedMovieColumns = queryColumns{
+	filter: map[string]filterColumn{
+		"genre": {name: "m.genre", operator: opEqual},
+		"rate":  {name: "m.avg_rate", operator: opGreaterOrEqual},
+	},
+	sort: map[string]string{
+		"rate":         "m.avg_rate",
+		"votes":        "m.votes",
+		"release_date": "m.release_date",
+		"duration":     "m.duration",
+	},
+}
+
 type MovieDB struct {
 	db *sqlx.DB
 }
@@ -54,7 +84,7 @@ func (d MovieDB) InsertMovie(movie core.Movie) error {
 
 // Select and return the movie entities via movie ID.
 func (d MovieDB) SelectMovieByID(movieID string) (core.Movie, error) {
-	query := `SELECT id, director_id, title, genre, rate, release_date, duration, created, modified
+	query := `SELECT id, director_id, title, genre, rate, release_date, duration, avg_rate, votes, created, modified
 	FROM public.movie WHERE id=$1`
 
 	var movie core.Movie
@@ -70,14 +100,20 @@ func (d MovieDB) SelectMovieByID(movieID string) (core.Movie, error) {
 }
 
 func (d MovieDB) SelectAllMovies(qp core.ConditionParams) ([]core.Movie, error) {
-	queryCondition := buildQueryCondition(qp)
+	builder := newQueryBuilder(movieColumns)
 
-	query := `SELECT id, director_id, title, genre, rate, release_date, duration, created, modified FROM public.movie `
+	queryCondition, err := builder.build(qp)
+	if err != nil {
+		return nil, fmt.Errorf("can't build the query condition: %w", err)
+	}
+
+	query := `SELECT id, director_id, title, genre, rate, release_date, duration, avg_rate, votes, created, modified
+		FROM public.movie`
 
 	fullQuery := query + queryCondition
 
 	var movieList []core.Movie
-	if err := d.db.Select(&movieList, fullQuery); err != nil {
+	if err := d.db.Select(&movieList, fullQuery, builder.args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, core.ErrNotFound
 		}
@@ -89,16 +125,22 @@ func (d MovieDB) SelectAllMovies(qp core.ConditionParams) ([]core.Movie, error)
 }
 
 func (d MovieDB) SelectMoviesCSV(qp core.ConditionParams) ([]core.MovieCSV, error) {
-	queryCondition := buildQueryCondition(qp)
+	builder := newQueryBuilder(joinedMovieColumns)
+
+	queryCondition, err := builder.build(qp)
+	if err != nil {
+		return nil, fmt.Errorf("can't build the query condition: %w", err)
+	}
 
-	query := `SELECT m.title, m.genre, d.name as director_name, m.rate, m.release_date, m.duration FROM public.movie AS m
-		INNER JOIN public.director AS d ON d.id=m.director_id `
+	query := `SELECT m.title, m.genre, d.name as director_name, m.rate, m.release_date, m.duration, m.avg_rate, m.votes
+		FROM public.movie AS m
+		INNER JOIN public.director AS d ON d.id=m.director_id`
 
 	fullQuery := query + queryCondition
 
 	var csvList []core.MovieCSV
 
-	rows, err := d.db.DB.Query(fullQuery)
+	rows, err := d.db.DB.Query(fullQuery, builder.args...)
 	if err != nil {
 		return nil, fmt.Errorf("error while Query: %w", err)
 	}
@@ -115,7 +157,9 @@ func (d MovieDB) SelectMoviesCSV(qp core.ConditionParams) ([]core.MovieCSV, erro
 			&movie.DirectorName,
 			&movie.Rate,
 			&movie.ReleaseDate.Time,
-			&movie.Duration); err != nil {
+			&movie.Duration,
+			&movie.AvgRate,
+			&movie.Votes); err != nil {
 			return nil, fmt.Errorf("error while scan director list: %w", err)
 		}
 
@@ -126,3 +170,114 @@ func (d MovieDB) SelectMoviesCSV(qp core.ConditionParams) ([]core.MovieCSV, erro
 
 	return csvList, nil
 }
+
+// Update all editable fields of the movie specified by ID and return the new modified timestamp.
+// If the movie has the Modified field, it is updated only when the stored timestamp is the same,
+// otherwise core.ErrPreconditionFailed is returned.
+func (d MovieDB) UpdateMovie(movie core.Movie) (string, error) {
+	query := `UPDATE public.movie
+		SET director_id=:director_id, title=:title, genre=:genre, rate=:rate, release_date=:release_date, duration=:duration
+		WHERE id=:id`
+
+	if movie.Modified != "" {
+		query += ` AND modified=CAST(:modified AS timestamptz)`
+	}
+
+	query += ` RETURNING modified`
+
+	rows, err := d.db.NamedQuery(query, &movie)
+	if err != nil {
+		pqError := new(pq.Error)
+		if errors.As(err, &pqError) && pqError.Code.Name() == ErrCodeForeignKeyViolation {
+			return "", core.ErrForeignViolation
+		}
+
+		if errors.As(err, &pqError) && pqError.Code.Name() == ErrCodeUniqueViolation {
+			return "", core.ErrUniqueMovie
+		}
+
+		return "", fmt.Errorf("error in NamedQuery: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", fmt.Errorf("rows.Err(): %w", err)
+		}
+
+		return "", d.missingOrModified(movie)
+	}
+
+	var modified string
+	if err := rows.Scan(&modified); err != nil {
+		return "", fmt.Errorf("error while scaning: %w", err)
+	}
+
+	return modified, nil
+}
+
+// Explains why the conditional update of the movie has not changed any row.
+func (d MovieDB) missingOrModified(movie core.Movie) error {
+	if movie.Modified == "" {
+		return core.ErrNotFound
+	}
+
+	var exists bool
+	if err := d.db.Get(&exists, `SELECT EXISTS(SELECT 1 FROM public.movie WHERE id=$1)`, movie.ID); err != nil {
+		return fmt.Errorf("can't check the movie existence: %w", err)
+	}
+
+	if !exists {
+		return core.ErrNotFound
+	}
+
+	return core.ErrPreconditionFailed
+}
+
+// Delete the movie with its ratings. If the movie is in some lists and cascade is false
+// nothing is deleted and the number of the lists is returned with core.ErrMovieInLists,
+// otherwise the movie is removed from the lists as well.
+func (d MovieDB) DeleteMovie(movieID string, cascade bool) (int, error) {
+	tx, err := d.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	var lockedID string
+	if err := tx.QueryRow(`SELECT id FROM public.movie WHERE id=$1 FOR UPDATE`, movieID).Scan(&lockedID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, core.ErrNotFound
+		}
+
+		return 0, fmt.Errorf("can't lock the movie: %w", err)
+	}
+
+	var listCount int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM public.movie_list WHERE movie_id=$1`, movieID).Scan(&listCount); err != nil {
+		return 0, fmt.Errorf("can't count the lists of the movie: %w", err)
+	}
+
+	if listCount > 0 && !cascade {
+		return listCount, core.ErrMovieInLists
+	}
+
+	queries := []string{
+		`DELETE FROM public.movie_list WHERE movie_id=$1`,
+		`DELETE FROM public.rating WHERE movie_id=$1`,
+		`DELETE FROM public.movie WHERE id=$1`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query, movieID); err != nil {
+			return 0, fmt.Errorf("can't delete the movie: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return listCount, nil
+}
@@ -0,0 +1,141 @@
+package pg
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Stores the started login. The expired logins which were never finished are deleted on the way.
+func (r AccountDB) InsertOIDCLogin(login core.OIDCLogin) error {
+	cleanupQuery := `DELETE FROM public.oidc_login WHERE expired < now()`
+
+	query := `INSERT INTO public.oidc_login(state, code_verifier, nonce, expired) VALUES ($1, $2, $3, $4)`
+
+	return r.inTransaction(func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(cleanupQuery); err != nil {
+			return fmt.Errorf("error while deleting the expired logins: %w", err)
+		}
+
+		if _, err := tx.Exec(query, login.State, login.CodeVerifier, login.Nonce, login.Expired); err != nil {
+			return fmt.Errorf("error while inserting the login: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Deletes the login and returns it, so the state can't be used twice.
+func (r AccountDB) TakeOIDCLogin(state string) (core.OIDCLogin, error) {
+	query := `DELETE FROM public.oidc_login WHERE state=$1
+		RETURNING state, code_verifier, nonce, expired, created`
+
+	var login core.OIDCLogin
+
+	err := r.db.QueryRow(query, state).Scan(
+		&login.State,
+		&login.CodeVerifier,
+		&login.Nonce,
+		&login.Expired,
+		&login.Created,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.OIDCLogin{}, core.ErrOIDCLoginNotFound
+		}
+
+		return core.OIDCLogin{}, fmt.Errorf("error while taking the login: %w", err)
+	}
+
+	return login, nil
+}
+
+// Returns the account linked to the identity.
+func (r AccountDB) SelectAccountByIdentity(issuer, subject string) (core.Account, error) {
+	var account core.Account
+
+	query := `SELECT a.id, a.phone, a.password, a.age, a.role, a.disabled, a.phone_verified
+		FROM public.account a
+		JOIN public.account_identity i ON i.account_id=a.id
+		WHERE i.issuer=$1 AND i.subject=$2`
+
+	err := r.db.QueryRow(query, issuer, subject).Scan(
+		&account.ID,
+		&account.Phone,
+		&account.Password,
+		&account.Age,
+		&account.Role,
+		&account.Disabled,
+		&account.PhoneVerified,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Account{}, core.ErrIdentityNotFound
+		}
+
+		return core.Account{}, fmt.Errorf("internal error while scanning row: %w", err)
+	}
+
+	return account, nil
+}
+
+// Links the identity to the existing account.
+func (r AccountDB) InsertIdentity(accountID string, identity core.ExternalIdentity) error {
+	return r.inTransaction(func(tx *sqlx.Tx) error {
+		return insertIdentity(tx, accountID, identity)
+	})
+}
+
+// Creates the account with the verified phone and the system lists, and links the identity to it.
+func (r AccountDB) InsertAccountWithIdentity(account core.Account, identity core.ExternalIdentity,
+	systemLists []core.ListType,
+) (accountID string, err error) {
+	query := `INSERT INTO public.account(phone, password, age, role, phone_verified)
+		VALUES ($1, $2, $3, $4, true) RETURNING id`
+
+	listQuery := `INSERT INTO public.list(account_id, type) VALUES ($1, $2)`
+
+	err = r.inTransaction(func(tx *sqlx.Tx) error {
+		err := tx.QueryRow(query, account.Phone, account.Password, account.Age, account.Role).Scan(&accountID)
+		if err != nil {
+			pqErr := new(pq.Error)
+			if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeUniqueViolation {
+				return core.ErrDuplicatePhone
+			}
+
+			return fmt.Errorf("cannot execute query: %w", err)
+		}
+
+		for _, listType := range systemLists {
+			if _, err := tx.Exec(listQuery, accountID, listType); err != nil {
+				return fmt.Errorf("cannot create the %s list: %w", listType, err)
+			}
+		}
+
+		return insertIdentity(tx, accountID, identity)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return accountID, nil
+}
+
+func insertIdentity(tx *sqlx.Tx, accountID string, identity core.ExternalIdentity) error {
+	query := `INSERT INTO public.account_identity(issuer, subject, account_id) VALUES ($1, $2, $3)`
+
+	if _, err := tx.Exec(query, identity.Issuer, identity.Subject, accountID); err != nil {
+		pqErr := new(pq.Error)
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == ErrCodeUniqueViolation {
+			return core.ErrDuplicateIdentity
+		}
+
+		return fmt.Errorf("error while linking the identity: %w", err)
+	}
+
+	return nil
+}
@@ -2,10 +2,7 @@ package pg
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
-	"github.com/Brigant/PetPorject/app/core"
 	"github.com/Brigant/PetPorject/config"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" // nececarry blank import
@@ -23,6 +20,10 @@ type Repository struct {
 	DirectorDB DirectorDB
 	MovieDB    MovieDB
 	ListDB     ListDB
+	RatingDB   RatingDB
+	APIKeyDB   APIKeyDB
+	// The failed logins, it is used if the config keeps them in the database.
+	LoginAttemptDB LoginAttemptDB
 }
 
 // NewPostgresDB function returns object of datatabase.
@@ -39,50 +40,12 @@ func NewPostgresDB(cfg config.Config) (*sqlx.DB, error) {
 // Returns an object of the Ropository.
 func NewRepository(db *sqlx.DB) Repository {
 	return Repository{
-		AccountDB:  NewAccountDB(db),
-		DirectorDB: NewDirectorDB(db),
-		MovieDB:    NewMovieDB(db),
-		ListDB:     NewListDB(db),
+		AccountDB:      NewAccountDB(db),
+		DirectorDB:     NewDirectorDB(db),
+		MovieDB:        NewMovieDB(db),
+		ListDB:         NewListDB(db),
+		RatingDB:       NewRatingDB(db),
+		APIKeyDB:       NewAPIKeyDB(db),
+		LoginAttemptDB: NewLoginAttemptDB(db),
 	}
 }
-
-func buildQueryCondition(condiotion core.ConditionParams) string {
-	var queryCondition string
-
-	if len(condiotion.Filter) > 0 {
-		where := "WHERE "
-
-		for i := 0; i < len(condiotion.Filter); i++ {
-			if condiotion.Filter[i].Val != "" {
-				if _, err := strconv.Atoi(condiotion.Filter[i].Val); err == nil {
-					where = where + condiotion.Filter[i].Key + ">=" + condiotion.Filter[i].Val + " AND "
-				} else {
-					where = where + condiotion.Filter[i].Key + "='" + condiotion.Filter[i].Val + "' AND "
-				}
-			}
-		}
-
-		where = strings.TrimSuffix(where, "AND ")
-
-		queryCondition += where
-	}
-
-	if len(condiotion.Sort) > 0 {
-		order := "ORDER BY "
-
-		for i := 0; i < len(condiotion.Sort); i++ {
-			if condiotion.Sort[i].Val != "" {
-				order = order + condiotion.Sort[i].Key + " " + condiotion.Sort[i].Val + ", "
-			}
-		}
-
-		order = strings.TrimSuffix(order, ", ")
-
-		queryCondition += order
-	}
-
-	queryCondition = queryCondition + " LIMIT " + condiotion.Limit
-	queryCondition = queryCondition + " OFFSET " + condiotion.Offset
-
-	return queryCondition
-}
@@ -0,0 +1,197 @@
+package pg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+// The comparison operator which is applied to a filter column.
+type filterOperator string
+
+const (
+	opEqual          filterOperator = "="
+	opGreaterOrEqual filterOperator = ">="
+	// The case-insensitive search of the value in any part of the column.
+	opContains filterOperator = "contains"
+	// The case-insensitive search of the value at the beginning of the column.
+	opStartsWith filterOperator = "starts_with"
+)
+
+// Escapes the wildcard characters, so the value is matched literally by the LIKE operator.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// The whitelisted column that can be used in the WHERE clause.
+type filterColumn struct {
+	name     string
+	operator filterOperator
+}
+
+// The whitelisted identifiers of the columns which are allowed in the WHERE and ORDER BY clauses.
+// The keys are the names which come from the transport level, the values are the real SQL identifiers.
+type queryColumns struct {
+	filter map[string]filterColumn
+	sort   map[string]string
+}
+
+var sortDirections = map[string]string{
+	"asc":  "ASC",
+	"desc": "DESC",
+}
+
+// The queryBuilder turns the condition parameters into the SQL clauses with positional arguments.
+// None of the values received from the client is ever concatenated to the query,
+// it only goes to the args slice, and all identifiers are taken from the whitelist.
+type queryBuilder struct {
+	columns queryColumns
+	args    []any
+}
+
+func newQueryBuilder(columns queryColumns, args ...any) *queryBuilder {
+	return &queryBuilder{columns: columns, args: args}
+}
+
+// Adds the value to the argument list and returns its placeholder.
+func (b *queryBuilder) bind(value any) string {
+	b.args = append(b.args, value)
+
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// Builds the WHERE clause. The conditions with the same key are joined by OR,
+// the groups of the different keys are joined by AND. Returns an empty string if there are no conditions.
+func (b *queryBuilder) where(conditions []core.QuerySliceElement) (string, error) {
+	var (
+		keys   []string
+		groups = make(map[string][]string)
+	)
+
+	for _, cond := range conditions {
+		if cond.Val == "" {
+			continue
+		}
+
+		column, ok := b.columns.filter[cond.Key]
+		if !ok {
+			return "", fmt.Errorf("filter key %q: %w", cond.Key, core.ErrUnkownConditionKey)
+		}
+
+		if _, exists := groups[cond.Key]; !exists {
+			keys = append(keys, cond.Key)
+		}
+
+		groups[cond.Key] = append(groups[cond.Key], b.compare(column, cond.Val))
+	}
+
+	if len(keys) == 0 {
+		return "", nil
+	}
+
+	clauses := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if len(groups[key]) == 1 {
+			clauses = append(clauses, groups[key][0])
+
+			continue
+		}
+
+		clauses = append(clauses, "("+strings.Join(groups[key], " OR ")+")")
+	}
+
+	return " WHERE " + strings.Join(clauses, " AND "), nil
+}
+
+// Returns the comparison of the column with the bound value.
+func (b *queryBuilder) compare(column filterColumn, value string) string {
+	switch column.operator {
+	case opContains:
+		value = "%" + likeEscaper.Replace(value) + "%"
+	case opStartsWith:
+		value = likeEscaper.Replace(value) + "%"
+	case opEqual, opGreaterOrEqual:
+		return column.name + string(column.operator) + b.bind(value)
+	}
+
+	return column.name + " ILIKE " + b.bind(value)
+}
+
+// Builds the ORDER BY clause. Returns an empty string if there is nothing to sort.
+func (b *queryBuilder) orderBy(sort []core.QuerySliceElement) (string, error) {
+	orders := make([]string, 0, len(sort))
+
+	for _, elem := range sort {
+		if elem.Val == "" {
+			continue
+		}
+
+		column, ok := b.columns.sort[elem.Key]
+		if !ok {
+			return "", fmt.Errorf("sort key %q: %w", elem.Key, core.ErrUnkownConditionKey)
+		}
+
+		direction, ok := sortDirections[elem.Val]
+		if !ok {
+			return "", fmt.Errorf("sort value %q: %w", elem.Val, core.ErrUnallowedSort)
+		}
+
+		orders = append(orders, column+" "+direction)
+	}
+
+	if len(orders) == 0 {
+		return "", nil
+	}
+
+	return " ORDER BY " + strings.Join(orders, ", "), nil
+}
+
+// Builds the LIMIT and OFFSET clause, both values have to be integers.
+func (b *queryBuilder) limitOffset(limit, offset string) (string, error) {
+	limitVal, err := strconv.Atoi(limit)
+	if err != nil {
+		return "", fmt.Errorf("limit %q: %w", limit, core.ErrUnallowedLimit)
+	}
+
+	offsetVal, err := strconv.Atoi(offset)
+	if err != nil {
+		return "", fmt.Errorf("offset %q: %w", offset, core.ErrUnallowedOffset)
+	}
+
+	return " LIMIT " + b.bind(limitVal) + " OFFSET " + b.bind(offsetVal), nil
+}
+
+// Builds the whole condition part of the query: WHERE, ORDER BY, LIMIT and OFFSET.
+func (b *queryBuilder) build(condition core.ConditionParams) (string, error) {
+	return b.buildWithPredicate("", condition)
+}
+
+// Builds the condition part of the query like build does, but the WHERE clause always contains
+// the predicate. The predicate can refer only to the arguments passed to the newQueryBuilder.
+func (b *queryBuilder) buildWithPredicate(predicate string, condition core.ConditionParams) (string, error) {
+	where, err := b.where(condition.Filter)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case predicate == "":
+	case where == "":
+		where = " WHERE " + predicate
+	default:
+		where = " WHERE " + predicate + " AND " + strings.TrimPrefix(where, " WHERE ")
+	}
+
+	order, err := b.orderBy(condition.Sort)
+	if err != nil {
+		return "", err
+	}
+
+	limit, err := b.limitOffset(condition.Limit, condition.Offset)
+	if err != nil {
+		return "", err
+	}
+
+	return where + order + limit, nil
+}
@@ -0,0 +1,221 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryBuilder_build(t *testing.T) {
+	testCasesTable := map[string]struct {
+		columns       queryColumns
+		condition     core.ConditionParams
+		expectedQuery string
+		expectedArgs  []any
+		expectedError error
+	}{
+		"Only limit and offset": {
+			columns:       movieColumns,
+			condition:     core.ConditionParams{Limit: "20", Offset: "0"},
+			expectedQuery: " LIMIT $1 OFFSET $2",
+			expectedArgs:  []any{20, 0},
+		},
+		"Filter and sort": {
+			columns: movieColumns,
+			condition: core.ConditionParams{
+				Limit:  "50",
+				Offset: "10",
+				Filter: []core.QuerySliceElement{{Key: "genre", Val: "comedy"}, {Key: "rate", Val: "7.9"}},
+				Sort:   []core.QuerySliceElement{{Key: "rate", Val: "desc"}, {Key: "duration", Val: "asc"}},
+			},
+			expectedQuery: " WHERE genre=$1 AND avg_rate>=$2 ORDER BY avg_rate DESC, duration ASC LIMIT $3 OFFSET $4",
+			expectedArgs:  []any{"comedy", "7.9", 50, 10},
+		},
+		"Same keys are joined by OR": {
+			columns: joinedMovieColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Filter: []core.QuerySliceElement{
+					{Key: "genre", Val: "comedy"}, {Key: "rate", Val: "5"}, {Key: "genre", Val: "drama"},
+				},
+			},
+			expectedQuery: " WHERE (m.genre=$1 OR m.genre=$3) AND m.avg_rate>=$2 LIMIT $4 OFFSET $5",
+			expectedArgs:  []any{"comedy", "5", "drama", 20, 0},
+		},
+		"Injection in the filter value is passed as an argument": {
+			columns: movieColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Filter: []core.QuerySliceElement{{Key: "genre", Val: "x' OR '1'='1"}},
+			},
+			expectedQuery: " WHERE genre=$1 LIMIT $2 OFFSET $3",
+			expectedArgs:  []any{"x' OR '1'='1", 20, 0},
+		},
+		"Injection with statement terminator is passed as an argument": {
+			columns: movieColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Filter: []core.QuerySliceElement{{Key: "genre", Val: "'; DROP TABLE movie; --"}},
+			},
+			expectedQuery: " WHERE genre=$1 LIMIT $2 OFFSET $3",
+			expectedArgs:  []any{"'; DROP TABLE movie; --", 20, 0},
+		},
+		"Director name search with wildcards": {
+			columns: directorColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Filter: []core.QuerySliceElement{{Key: "name", Val: `50%_off\`}, {Key: "name_prefix", Val: "Ja"}},
+				Sort:   []core.QuerySliceElement{{Key: "birth_date", Val: "desc"}},
+			},
+			expectedQuery: " WHERE name ILIKE $1 AND name ILIKE $2 ORDER BY birth_date DESC LIMIT $3 OFFSET $4",
+			expectedArgs:  []any{`%50\%\_off\\%`, "Ja%", 20, 0},
+		},
+		"Injection in the filter key": {
+			columns: movieColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Filter: []core.QuerySliceElement{{Key: "1=1 OR genre", Val: "comedy"}},
+			},
+			expectedError: core.ErrUnkownConditionKey,
+		},
+		"Filter key which is not the column of the table": {
+			columns: movieColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Filter: []core.QuerySliceElement{{Key: "account_id", Val: "some-id"}},
+			},
+			expectedError: core.ErrUnkownConditionKey,
+		},
+		"Injection in the sort key": {
+			columns: movieColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Sort:   []core.QuerySliceElement{{Key: "(SELECT password FROM account)", Val: "asc"}},
+			},
+			expectedError: core.ErrUnkownConditionKey,
+		},
+		"Injection in the sort direction": {
+			columns: movieColumns,
+			condition: core.ConditionParams{
+				Limit:  "20",
+				Offset: "0",
+				Sort:   []core.QuerySliceElement{{Key: "rate", Val: "asc; DELETE FROM movie"}},
+			},
+			expectedError: core.ErrUnallowedSort,
+		},
+		"Injection in the limit": {
+			columns:       movieColumns,
+			condition:     core.ConditionParams{Limit: "20; DROP TABLE movie", Offset: "0"},
+			expectedError: core.ErrUnallowedLimit,
+		},
+		"Injection in the offset": {
+			columns:       movieColumns,
+			condition:     core.ConditionParams{Limit: "20", Offset: "0 UNION SELECT * FROM account"},
+			expectedError: core.ErrUnallowedOffset,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			builder := newQueryBuilder(testCase.columns)
+
+			query, err := builder.build(testCase.condition)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedQuery, query)
+			assert.Equal(t, testCase.expectedArgs, builder.args)
+		})
+	}
+}
+
+func TestQueryBuilder_where(t *testing.T) {
+	testCasesTable := map[string]struct {
+		conditions    []core.QuerySliceElement
+		args          []any
+		expectedWhere string
+		expectedArgs  []any
+	}{
+		"No conditions": {
+			conditions:    nil,
+			expectedWhere: "",
+			expectedArgs:  nil,
+		},
+		"Account with several list types": {
+			conditions: []core.QuerySliceElement{
+				{Key: "account_id", Val: "8c172d76-f750-4369-a5e2-27c877299168"},
+				{Key: "type", Val: "favorite"},
+				{Key: "type", Val: "wish' OR 1=1 --"},
+			},
+			expectedWhere: " WHERE account_id=$1 AND (type=$2 OR type=$3)",
+			expectedArgs:  []any{"8c172d76-f750-4369-a5e2-27c877299168", "favorite", "wish' OR 1=1 --"},
+		},
+		"Numbering continues after the predefined arguments": {
+			conditions:    []core.QuerySliceElement{{Key: "type", Val: "favorite"}},
+			args:          []any{"list-id"},
+			expectedWhere: " WHERE type=$2",
+			expectedArgs:  []any{"list-id", "favorite"},
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			builder := newQueryBuilder(listColumns, testCase.args...)
+
+			where, err := builder.where(testCase.conditions)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedWhere, where)
+			assert.Equal(t, testCase.expectedArgs, builder.args)
+		})
+	}
+}
+
+func TestQueryBuilder_buildWithPredicate(t *testing.T) {
+	testCasesTable := map[string]struct {
+		condition     core.ConditionParams
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		"Only predicate": {
+			condition:     core.ConditionParams{Limit: "20", Offset: "0"},
+			expectedQuery: " WHERE director_id=$1 LIMIT $2 OFFSET $3",
+			expectedArgs:  []any{"director-id", 20, 0},
+		},
+		"Predicate with filter and sort": {
+			condition: core.ConditionParams{
+				Limit:  "50",
+				Offset: "0",
+				Filter: []core.QuerySliceElement{{Key: "genre", Val: "comedy"}, {Key: "genre", Val: "drama"}},
+				Sort:   []core.QuerySliceElement{{Key: "release_date", Val: "asc"}},
+			},
+			expectedQuery: " WHERE director_id=$1 AND (genre=$2 OR genre=$3) ORDER BY release_date ASC LIMIT $4 OFFSET $5",
+			expectedArgs:  []any{"director-id", "comedy", "drama", 50, 0},
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			builder := newQueryBuilder(movieColumns, "director-id")
+
+			query, err := builder.buildWithPredicate("director_id=$1", testCase.condition)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedQuery, query)
+			assert.Equal(t, testCase.expectedArgs, builder.args)
+		})
+	}
+}
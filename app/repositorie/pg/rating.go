@@ -0,0 +1,122 @@
+package pg
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/jmoiron/sqlx"
+)
+
+type RatingDB struct {
+	db *sqlx.DB
+}
+
+func NewRatingDB(db *sqlx.DB) RatingDB {
+	return RatingDB{db: db}
+}
+
+// Select the score which the account gave to the movie.
+func (d RatingDB) SelectRating(accountID, movieID string) (core.Rating, error) {
+	query := `SELECT account_id, movie_id, score, created, modified
+		FROM public.rating WHERE account_id=$1 AND movie_id=$2`
+
+	var rating core.Rating
+	if err := d.db.Get(&rating, query, accountID, movieID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Rating{}, core.ErrRatingNotFound
+		}
+
+		return core.Rating{}, fmt.Errorf("an error occurs while getting the rating: %w", err)
+	}
+
+	return rating, nil
+}
+
+// Insert the score or change the existing one and recalculate the movie average rate
+// in the same transaction.
+func (d RatingDB) UpsertRating(rating core.Rating) (core.Rating, error) {
+	query := `INSERT INTO public.rating(account_id, movie_id, score)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (account_id, movie_id) DO UPDATE SET score=EXCLUDED.score
+		RETURNING created, modified`
+
+	err := d.inTransaction(rating.MovieID, func(tx *sqlx.Tx) error {
+		if err := tx.QueryRow(query, rating.AccountID, rating.MovieID, rating.Score).Scan(
+			&rating.Created, &rating.Modified); err != nil {
+			return fmt.Errorf("can't upsert the rating: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return core.Rating{}, err
+	}
+
+	return rating, nil
+}
+
+// Delete the score of the account and recalculate the movie average rate in the same transaction.
+func (d RatingDB) DeleteRating(accountID, movieID string) error {
+	query := `DELETE FROM public.rating WHERE account_id=$1 AND movie_id=$2`
+
+	return d.inTransaction(movieID, func(tx *sqlx.Tx) error {
+		result, err := tx.Exec(query, accountID, movieID)
+		if err != nil {
+			return fmt.Errorf("can't delete the rating: %w", err)
+		}
+
+		affectedRows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't get affected row: %w", err)
+		}
+
+		if affectedRows == 0 {
+			return core.ErrRatingNotFound
+		}
+
+		return nil
+	})
+}
+
+// The function locks the movie row, so the concurrent votes are counted one after another,
+// runs the change of the rating and updates the denormalized average rate and votes of the movie.
+func (d RatingDB) inTransaction(movieID string, change func(tx *sqlx.Tx) error) error {
+	lockQuery := `SELECT id FROM public.movie WHERE id=$1 FOR UPDATE`
+
+	updateQuery := `UPDATE public.movie SET
+		avg_rate=COALESCE((SELECT ROUND(AVG(score), 1) FROM public.rating WHERE movie_id=$1), 0),
+		votes=(SELECT COUNT(*) FROM public.rating WHERE movie_id=$1)
+		WHERE id=$1`
+
+	tx, err := d.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	var lockedID string
+	if err := tx.QueryRow(lockQuery, movieID).Scan(&lockedID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.ErrNotFound
+		}
+
+		return fmt.Errorf("can't lock the movie: %w", err)
+	}
+
+	if err := change(tx); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(updateQuery, movieID); err != nil {
+		return fmt.Errorf("can't update the movie average rate: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
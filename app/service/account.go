@@ -11,17 +11,35 @@ import (
 )
 
 type AccountService struct {
-	storage AccountStorage
-	cfg     config.Config
+	storage  AccountStorage
+	hasher   PasswordHasher
+	keys     TokenKeys
+	sms      SMSSender
+	throttle loginThrottle
+	oidc     OIDCProvider
+	sessions *sessionCache
+	cfg      config.Config
 }
 
-func NewAccountService(storage AccountStorage, cfg config.Config) AccountService {
-	return AccountService{storage: storage, cfg: cfg}
+func NewAccountService(storage AccountStorage, hasher PasswordHasher, keys TokenKeys, sms SMSSender,
+	attempts LoginAttemptStorage, oidc OIDCProvider, cfg config.Config,
+) AccountService {
+	return AccountService{
+		storage:  storage,
+		hasher:   hasher,
+		keys:     keys,
+		sms:      sms,
+		throttle: newLoginThrottle(attempts, cfg.LoginThrottle),
+		oidc:     oidc,
+		sessions: newSessionCache(cfg.SessionCacheTTL),
+		cfg:      cfg,
+	}
 }
 
 var (
-	errInvalidSigningMethod = errors.New("invalid signing metod")
-	errWrongTokenClaimType  = errors.New("token claims are not of type *tokenClaims")
+	errWrongTokenClaimType = errors.New("token claims are not of type *tokenClaims")
+	errTokenExpired        = errors.New("token is expired")
+	errTokenNotValidYet    = errors.New("token is not valid yet")
 )
 
 type ClinteSideInfo struct {
@@ -38,12 +56,31 @@ type Claims struct {
 	Info ClinteSideInfo
 }
 
-// The function receives the account model and store it in the repository, after that returns account id
-// of the new created account or an error if it occures.
+// Validates the time claims of the token allowing the clock skew between the servers.
+func (c Claims) validate(now time.Time, leeway time.Duration) error {
+	if !c.VerifyExpiresAt(now.Add(-leeway).Unix(), true) {
+		return errTokenExpired
+	}
+
+	if !c.VerifyIssuedAt(now.Add(leeway).Unix(), false) || !c.VerifyNotBefore(now.Add(leeway).Unix(), false) {
+		return errTokenNotValidYet
+	}
+
+	return nil
+}
+
+// The function receives the account model and store it in the repository together with the system lists,
+// after that returns account id of the new created account or an error if it occures.
 func (a AccountService) CreateUser(account core.Account) (string, error) {
-	account.Password = core.SHA256(account.Password, a.cfg.Salt)
+	passwordHash, err := a.hasher.Hash(account.Password)
+	if err != nil {
+		return "", fmt.Errorf("service CreateUser get an error: %w", err)
+	}
+
+	account.Password = passwordHash
+	account.Role = core.RoleUser
 
-	id, err := a.storage.InsertAccount(account)
+	id, err := a.storage.InsertAccount(account, core.SystemListTypes)
 	if err != nil {
 		return "", fmt.Errorf("service CreateUser get an error: %w", err)
 	}
@@ -51,26 +88,70 @@ func (a AccountService) CreateUser(account core.Account) (string, error) {
 	return id, nil
 }
 
-// The service implementation of login functionality.
+// The service implementation of login functionality. The failed logins are counted
+// per phone and per client IP, the login is refused while either of them is locked.
 func (a AccountService) Login(phone, password string, session core.Session) (core.TokenPair, error) {
-	var tokenPair core.TokenPair
+	throttleKeys := a.throttle.keys(phone, session.ClientIP)
+
+	if err := a.throttle.check(throttleKeys); err != nil {
+		return core.TokenPair{}, fmt.Errorf("service Login got the error: %w", err)
+	}
 
 	account, err := a.storage.SelectAccountByPhone(phone)
 	if err != nil {
+		if errors.Is(err, core.ErrUserNotFound) {
+			if err := a.throttle.fail(throttleKeys); err != nil {
+				return core.TokenPair{}, fmt.Errorf("service Login got the error: %w", err)
+			}
+		}
+
 		return core.TokenPair{}, fmt.Errorf("service Login got the error: %w", err)
 	}
 
-	if core.SHA256(password, a.cfg.Salt) != account.Password {
+	match, rehash, err := a.hasher.Verify(password, account.Password)
+	if err != nil {
+		return core.TokenPair{}, fmt.Errorf("service Login got the error: %w", err)
+	}
+
+	if !match {
+		if err := a.throttle.fail(throttleKeys); err != nil {
+			return core.TokenPair{}, fmt.Errorf("service Login got the error: %w", err)
+		}
+
 		return core.TokenPair{}, core.ErrWrongPassword
 	}
 
+	// The client IP is not reset, otherwise the own account would let the attacker guess the others.
+	if err := a.throttle.reset(phoneThrottleKey + phone); err != nil {
+		return core.TokenPair{}, fmt.Errorf("service Login got the error: %w", err)
+	}
+
+	if account.Disabled {
+		return core.TokenPair{}, core.ErrAccountDisabled
+	}
+
+	if a.cfg.RequirePhoneVerification && !account.PhoneVerified {
+		return core.TokenPair{}, core.ErrPhoneNotVerified
+	}
+
+	if rehash {
+		a.rehashPassword(account.ID, password)
+	}
+
+	return a.startSession(account, session)
+}
+
+// Creates the session of the logged in account and returns its token pair.
+func (a AccountService) startSession(account core.Account, session core.Session) (core.TokenPair, error) {
+	var tokenPair core.TokenPair
+
 	expired := time.Now().Add(a.cfg.RefreshTokenTTL)
 
 	session.AccountID = account.ID
 	session.Role = account.Role
 	session.Expired = expired
 
-	session, err = a.storage.InsertSession(session)
+	session, err := a.storage.InsertSession(session)
 	if err != nil {
 		return core.TokenPair{}, fmt.Errorf("error occures in service Loign: %w", err)
 	}
@@ -82,34 +163,82 @@ func (a AccountService) Login(phone, password string, session core.Session) (cor
 
 	tokenPair.AccessToken = accesstoken
 	tokenPair.RefreshToken = session.RefreshToken
+	tokenPair.ExpiresIn = int64(a.cfg.AccessTokenTTL.Seconds())
 
 	return tokenPair, nil
 }
 
-// The function returns user ID if accessToken is valid.
-func (a AccountService) ParseToken(accesToken string) (string, string, error) {
-	token, err := jwt.ParseWithClaims(accesToken, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errInvalidSigningMethod
-		}
+// Replaces the legacy or outdated password hash with the hash of the current scheme.
+// The failure is not reported, because the old hash stays valid and the next login retries the upgrade.
+func (a AccountService) rehashPassword(accountID, password string) {
+	passwordHash, err := a.hasher.Hash(password)
+	if err != nil {
+		return
+	}
 
-		return []byte(a.cfg.SigningKey), nil
-	})
+	a.storage.UpdateAccountPassword(accountID, passwordHash) //nolint:errcheck
+}
+
+// The function returns the identity of the caller if accessToken is valid and its session is not revoked.
+func (a AccountService) ParseToken(accesToken string) (core.Identity, error) {
+	// The time claims are validated below with the leeway, which the parser doesn't support.
+	parser := jwt.Parser{SkipClaimsValidation: true}
+
+	token, err := parser.ParseWithClaims(accesToken, &Claims{}, a.keys.Verify)
 	if err != nil {
-		return "", "", fmt.Errorf("accessToken throws an error during parsing: %w", err)
+		return core.Identity{}, fmt.Errorf("accessToken throws an error during parsing: %w", err)
 	}
 
 	claims, ok := token.Claims.(*Claims)
 	if !ok {
-		return "", "", errWrongTokenClaimType
+		return core.Identity{}, errWrongTokenClaimType
+	}
+
+	if err := claims.validate(time.Now(), a.cfg.TokenLeeway); err != nil {
+		return core.Identity{}, fmt.Errorf("accessToken throws an error during parsing: %w", err)
 	}
 
-	return claims.Info.AccountID, claims.Info.Role, nil
+	live, err := a.isSessionLive(claims.Info.RefreshToken)
+	if err != nil {
+		return core.Identity{}, fmt.Errorf("can't check the session of the accessToken: %w", err)
+	}
+
+	if !live {
+		return core.Identity{}, core.ErrSessionRevoked
+	}
+
+	return core.Identity{
+		AccountID:    claims.Info.AccountID,
+		Role:         claims.Info.Role,
+		RefreshToken: claims.Info.RefreshToken,
+	}, nil
 }
 
+// Reports whether the session of the refresh token is not revoked, using the session cache before the storage.
+func (a AccountService) isSessionLive(refreshToken string) (bool, error) {
+	if live, ok := a.sessions.get(refreshToken); ok {
+		return live, nil
+	}
+
+	live, err := a.storage.IsSessionLive(refreshToken)
+	if err != nil {
+		return false, err
+	}
+
+	a.sessions.set(refreshToken, live)
+
+	return live, nil
+}
+
+// Issues the new token pair for the session of the refresh token. Every refresh rotates the refresh token,
+// and presenting the retired one revokes the whole token family, because it means the token was stolen.
 func (a AccountService) RefreshTokenpair(session core.Session) (core.TokenPair, error) {
 	sessionFromDB, err := a.storage.SelectSession(session)
 	if err != nil {
+		if errors.Is(err, core.ErrSesseionNotFound) {
+			return core.TokenPair{}, a.detectReuse(session.RefreshToken, err)
+		}
+
 		return core.TokenPair{}, fmt.Errorf("can't Select Session: %w", err)
 	}
 
@@ -117,31 +246,58 @@ func (a AccountService) RefreshTokenpair(session core.Session) (core.TokenPair,
 		return core.TokenPair{}, core.ErrRefreshTokenExpired
 	}
 
-	sessionFromDB.Expired = time.Now().Add(a.cfg.RefreshTokenTTL)
-
-	err = a.storage.RefreshSession(sessionFromDB)
+	rotated, err := a.storage.RotateSession(sessionFromDB.RefreshToken, time.Now().Add(a.cfg.RefreshTokenTTL))
 	if err != nil {
+		// The concurrent refresh has already rotated the token.
+		if errors.Is(err, core.ErrSesseionNotFound) {
+			return core.TokenPair{}, a.detectReuse(session.RefreshToken, err)
+		}
+
 		return core.TokenPair{}, fmt.Errorf("storege can't refress this session: %w", err)
 	}
 
-	accessToken, err := a.generateAccessToken(sessionFromDB)
+	accessToken, err := a.generateAccessToken(rotated)
 	if err != nil {
 		return core.TokenPair{}, fmt.Errorf("error happened while generating Access Token: %w", err)
 	}
 
 	tokenPair := core.TokenPair{
 		AccessToken:  accessToken,
-		RefreshToken: session.RefreshToken,
+		RefreshToken: rotated.RefreshToken,
+		ExpiresIn:    int64(a.cfg.AccessTokenTTL.Seconds()),
 	}
 
 	return tokenPair, nil
 }
 
+// Checks if the unknown refresh token is the retired one and revokes its family in that case.
+func (a AccountService) detectReuse(refreshToken string, notFoundErr error) error {
+	familyID, accountID, err := a.storage.SelectRetiredRefreshToken(refreshToken)
+	if err != nil {
+		if errors.Is(err, core.ErrSesseionNotFound) {
+			return fmt.Errorf("can't Select Session: %w", notFoundErr)
+		}
+
+		return fmt.Errorf("can't check the retired refresh tokens: %w", err)
+	}
+
+	if err := a.storage.DeleteSessionFamily(familyID); err != nil {
+		return fmt.Errorf("can't revoke the token family %s of the account %s: %w", familyID, accountID, err)
+	}
+
+	a.sessions.reset()
+
+	return fmt.Errorf("%w: the token family %s of the account %s is revoked",
+		core.ErrRefreshTokenReused, familyID, accountID)
+}
+
 func (a AccountService) generateAccessToken(session core.Session) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+	now := time.Now()
+
+	accessToken, err := a.keys.Sign(Claims{
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(a.cfg.RefreshTokenTTL).Unix(),
-			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: now.Add(a.cfg.AccessTokenTTL).Unix(),
+			IssuedAt:  now.Unix(),
 		},
 		Info: ClinteSideInfo{
 			AccountID:    session.AccountID,
@@ -152,8 +308,6 @@ func (a AccountService) generateAccessToken(session core.Session) (string, error
 			ClientIP:     session.ClientIP,
 		},
 	})
-
-	accessToken, err := token.SignedString([]byte(a.cfg.SigningKey))
 	if err != nil {
 		return "", fmt.Errorf("cannot get SignetString token: %w", err)
 	}
@@ -161,10 +315,53 @@ func (a AccountService) generateAccessToken(session core.Session) (string, error
 	return accessToken, nil
 }
 
-func (a AccountService) Logout(acountID string) error {
-	if err := a.storage.DeleteSesions(acountID); err != nil {
-		return fmt.Errorf("can't delete the account sessions: %w", err)
+// Revokes the session the access token was issued for, the other sessions of the account stay alive.
+func (a AccountService) Logout(acountID, refreshToken string) error {
+	if err := a.storage.DeleteSessionByRefreshToken(acountID, refreshToken); err != nil {
+		return fmt.Errorf("can't delete the account session: %w", err)
 	}
 
+	a.sessions.reset()
+
 	return nil
 }
+
+// Returns the active sessions of the account and marks the one with the given refresh token as current.
+func (a AccountService) Sessions(accountID, currentRefreshToken string) ([]core.SessionInfo, error) {
+	sessions, err := a.storage.SelectAccountSessions(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("service Sessions got the error: %w", err)
+	}
+
+	infos := make([]core.SessionInfo, 0, len(sessions))
+
+	for _, session := range sessions {
+		infos = append(infos, core.SessionInfo{
+			ID:          session.ID,
+			RequestHost: session.RequestHost,
+			UserAgent:   session.UserAgent,
+			ClientIP:    session.ClientIP,
+			Created:     session.Created,
+			Expired:     session.Expired,
+			Current:     session.RefreshToken == currentRefreshToken,
+		})
+	}
+
+	return infos, nil
+}
+
+// Revokes the session of the account by the session id.
+func (a AccountService) RevokeSession(accountID, sessionID string) error {
+	if err := a.storage.DeleteSession(accountID, sessionID); err != nil {
+		return fmt.Errorf("service RevokeSession got the error: %w", err)
+	}
+
+	a.sessions.reset()
+
+	return nil
+}
+
+// Returns the public keys the access tokens can be verified with.
+func (a AccountService) JWKS() core.JSONWebKeySet {
+	return a.keys.JWKS()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/app/keyring"
 	"github.com/Brigant/PetPorject/config"
 	"github.com/golang-jwt/jwt"
 	gomock "github.com/golang/mock/gomock"
@@ -38,7 +39,9 @@ func TestService_CreateUser(t *testing.T) {
 				Role:     "admin",
 			},
 			mockBehavior: func(s *MockAccountStorage, account core.Account) {
-				s.EXPECT().InsertAccount(gomock.Any()).Return("id-111", nil)
+				account.Password = "password-hash"
+				account.Role = core.RoleUser
+				s.EXPECT().InsertAccount(account, core.SystemListTypes).Return("id-111", nil)
 			},
 			expectedResult:       "id-111",
 			expectedErrorMessage: "",
@@ -52,7 +55,7 @@ func TestService_CreateUser(t *testing.T) {
 				Role:     "admin",
 			},
 			mockBehavior: func(s *MockAccountStorage, account core.Account) {
-				s.EXPECT().InsertAccount(gomock.Any()).Return("", errors.New("XXX"))
+				s.EXPECT().InsertAccount(gomock.Any(), core.SystemListTypes).Return("", errors.New("XXX"))
 			},
 			expectedResult:       "",
 			expectedErrorMessage: "service CreateUser get an error: XXX",
@@ -69,8 +72,12 @@ func TestService_CreateUser(t *testing.T) {
 			AccountStorage := NewMockAccountStorage(ctrl)
 			testCase.mockBehavior(AccountStorage, testCase.account)
 
+			passwordHasher := NewMockPasswordHasher(ctrl)
+			passwordHasher.EXPECT().Hash(testCase.account.Password).Return("password-hash", nil)
+
 			accountService := AccountService{
 				storage: AccountStorage,
+				hasher:  passwordHasher,
 				cfg:     cfg,
 			}
 
@@ -89,6 +96,120 @@ func TestService_CreateUser(t *testing.T) {
 	}
 }
 
+func TestService_Login(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage, h *MockPasswordHasher)
+
+	const (
+		phone    = "+380999999999"
+		password = "qwerty123456"
+	)
+
+	account := core.Account{ID: "id-111", Phone: phone, Password: "stored-hash", Role: "user"}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedRefreshToken string
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(true, false, nil)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{
+					RefreshToken: "refresh-111", AccountID: account.ID, Role: account.Role,
+				}, nil)
+			},
+			expectedRefreshToken: "refresh-111",
+		},
+		"Success with rehash of the legacy hash": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(true, true, nil)
+				h.EXPECT().Hash(password).Return("new-hash", nil)
+				s.EXPECT().UpdateAccountPassword(account.ID, "new-hash").Return(nil)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{
+					RefreshToken: "refresh-111", AccountID: account.ID, Role: account.Role,
+				}, nil)
+			},
+			expectedRefreshToken: "refresh-111",
+		},
+		"The failed rehash doesn't break the login": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(true, true, nil)
+				h.EXPECT().Hash(password).Return("new-hash", nil)
+				s.EXPECT().UpdateAccountPassword(account.ID, "new-hash").Return(errors.New("db is down"))
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{
+					RefreshToken: "refresh-111", AccountID: account.ID, Role: account.Role,
+				}, nil)
+			},
+			expectedRefreshToken: "refresh-111",
+		},
+		"Disabled account": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				disabled := account
+				disabled.Disabled = true
+
+				s.EXPECT().SelectAccountByPhone(phone).Return(disabled, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(true, false, nil)
+			},
+			expectedErrorMessage: "the account is disabled",
+			wantError:            true,
+		},
+		"Wrong password": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(false, false, nil)
+			},
+			expectedErrorMessage: "wrong passord",
+			wantError:            true,
+		},
+		"Malformed hash": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(false, false, errors.New("malformed password hash"))
+			},
+			expectedErrorMessage: "service Login got the error: malformed password hash",
+			wantError:            true,
+		},
+		"No account": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(core.Account{}, core.ErrUserNotFound)
+			},
+			expectedErrorMessage: "service Login got the error: user is not found with such credentials",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			passwordHasher := NewMockPasswordHasher(ctrl)
+			testCase.mockBehavior(accountStorage, passwordHasher)
+
+			accountService := NewAccountService(accountStorage, passwordHasher, keyring.NewHMAC("key"), nil, nil, nil, config.Config{
+				AccessTokenTTL:  time.Minute,
+				RefreshTokenTTL: time.Hour,
+			})
+
+			tokenPair, err := accountService.Login(phone, password, core.Session{})
+
+			assert.Equal(t, testCase.expectedRefreshToken, tokenPair.RefreshToken)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokenPair.AccessToken)
+			}
+		})
+	}
+}
+
 func TestService_GenerateAccessToken(t *testing.T) {
 	signingKey := "key"
 	testCasesTable := map[string]struct {
@@ -115,13 +236,13 @@ func TestService_GenerateAccessToken(t *testing.T) {
 
 	for name, testCase := range testCasesTable {
 		t.Run(name, func(t *testing.T) {
-			var accountService AccountService
+			accountService := AccountService{keys: keyring.NewHMAC(signingKey)}
 
 			accessToken, _ := accountService.generateAccessToken(testCase.session)
 
 			token, _ := jwt.ParseWithClaims(accessToken, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, errInvalidSigningMethod
+					return nil, keyring.ErrInvalidSigningMethod
 				}
 
 				return []byte(signingKey), nil
@@ -178,9 +299,10 @@ func TestService_RefreshTokenpair(t *testing.T) {
 				}, nil)
 			},
 			behaviorRefresh: func(s *MockAccountStorage, session core.Session) {
-				s.EXPECT().RefreshSession(gomock.Any()).Return(nil)
+				session.RefreshToken = "RefreshToken-222"
+				s.EXPECT().RotateSession("RefreshToken-111", gomock.Any()).Return(session, nil)
 			},
-			expectedRefreshToken: "RefreshToken-111",
+			expectedRefreshToken: "RefreshToken-222",
 		},
 		"RefreshToken expired": {
 			session: core.Session{
@@ -225,6 +347,64 @@ func TestService_RefreshTokenpair(t *testing.T) {
 			expectedRefreshToken: "",
 			expectedErrorMessage: "can't Select Session: no session",
 		},
+		"Retired token is reused": {
+			session: core.Session{
+				RefreshToken: "RefreshToken-111",
+				RequestHost:  "example.com",
+				UserAgent:    "Some mozilla agent",
+				ClientIP:     "127.0.0.1",
+			},
+			behaviorInsert: func(s *MockAccountStorage, session core.Session) {
+				s.EXPECT().SelectSession(session).Return(core.Session{}, core.ErrSesseionNotFound)
+			},
+			behaviorRefresh: func(s *MockAccountStorage, session core.Session) {
+				s.EXPECT().SelectRetiredRefreshToken("RefreshToken-111").Return("family-1", "Some-ID", nil)
+				s.EXPECT().DeleteSessionFamily("family-1").Return(nil)
+			},
+			expectedRefreshToken: "",
+			expectedErrorMessage: "refresh token has already been used: " +
+				"the token family family-1 of the account Some-ID is revoked",
+		},
+		"Token is rotated by the concurrent refresh": {
+			session: core.Session{
+				RefreshToken: "RefreshToken-111",
+				RequestHost:  "example.com",
+				UserAgent:    "Some mozilla agent",
+				ClientIP:     "127.0.0.1",
+			},
+			behaviorInsert: func(s *MockAccountStorage, session core.Session) {
+				s.EXPECT().SelectSession(session).Return(core.Session{
+					RefreshToken: session.RefreshToken,
+					FamilyID:     "family-1",
+					AccountID:    "Some-ID",
+					Expired:      time.Now().Add(5 * time.Minute),
+				}, nil)
+			},
+			behaviorRefresh: func(s *MockAccountStorage, session core.Session) {
+				s.EXPECT().RotateSession("RefreshToken-111", gomock.Any()).Return(core.Session{}, core.ErrSesseionNotFound)
+				s.EXPECT().SelectRetiredRefreshToken("RefreshToken-111").Return("family-1", "Some-ID", nil)
+				s.EXPECT().DeleteSessionFamily("family-1").Return(nil)
+			},
+			expectedRefreshToken: "",
+			expectedErrorMessage: "refresh token has already been used: " +
+				"the token family family-1 of the account Some-ID is revoked",
+		},
+		"Unknown token": {
+			session: core.Session{
+				RefreshToken: "RefreshToken-111",
+				RequestHost:  "example.com",
+				UserAgent:    "Some mozilla agent",
+				ClientIP:     "127.0.0.1",
+			},
+			behaviorInsert: func(s *MockAccountStorage, session core.Session) {
+				s.EXPECT().SelectSession(session).Return(core.Session{}, core.ErrSesseionNotFound)
+			},
+			behaviorRefresh: func(s *MockAccountStorage, session core.Session) {
+				s.EXPECT().SelectRetiredRefreshToken("RefreshToken-111").Return("", "", core.ErrSesseionNotFound)
+			},
+			expectedRefreshToken: "",
+			expectedErrorMessage: "can't Select Session: session is not found with such credentials",
+		},
 		"Error with session ipdate": {
 			session: core.Session{
 				AccountID:    "Some-ID",
@@ -247,7 +427,7 @@ func TestService_RefreshTokenpair(t *testing.T) {
 				}, nil)
 			},
 			behaviorRefresh: func(s *MockAccountStorage, session core.Session) {
-				s.EXPECT().RefreshSession(gomock.Any()).Return(errors.New("error while update"))
+				s.EXPECT().RotateSession("RefreshToken-111", gomock.Any()).Return(core.Session{}, errors.New("error while update"))
 			},
 			expectedRefreshToken: "",
 			expectedErrorMessage: "storege can't refress this session: error while update",
@@ -265,6 +445,7 @@ func TestService_RefreshTokenpair(t *testing.T) {
 
 			accountService := AccountService{
 				storage: accountStorage,
+				keys:    keyring.NewHMAC(signingKey),
 			}
 
 			tokenPair, err := accountService.RefreshTokenpair(testCase.session)
@@ -276,7 +457,7 @@ func TestService_RefreshTokenpair(t *testing.T) {
 			} else {
 				token, _ := jwt.ParseWithClaims(tokenPair.AccessToken, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 					if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-						return nil, errInvalidSigningMethod
+						return nil, keyring.ErrInvalidSigningMethod
 					}
 
 					return []byte(signingKey), nil
@@ -291,7 +472,7 @@ func TestService_RefreshTokenpair(t *testing.T) {
 					"ExpiresAt cat't be less or equal to IssueAt")
 				assert.Equal(t, testCase.session.AccountID, claims.Info.AccountID)
 				assert.Equal(t, testCase.session.Role, claims.Info.Role)
-				assert.Equal(t, testCase.session.RefreshToken, claims.Info.RefreshToken)
+				assert.Equal(t, testCase.expectedRefreshToken, claims.Info.RefreshToken)
 				assert.Equal(t, testCase.session.RequestHost, claims.Info.RequestHost)
 				assert.Equal(t, testCase.session.UserAgent, claims.Info.UserAgent)
 				assert.Equal(t, testCase.session.ClientIP, claims.Info.ClientIP)
@@ -313,7 +494,7 @@ func TestService_logout(t *testing.T) {
 		"Succes": {
 			accountID: "id-111",
 			mockBehavior: func(s *MockAccountStorage, accountID string) {
-				s.EXPECT().DeleteSesions(accountID).Return(nil)
+				s.EXPECT().DeleteSessionByRefreshToken(accountID, "refresh-111").Return(nil)
 			},
 			expectedErrorMessage: "",
 			wantError:            false,
@@ -321,9 +502,9 @@ func TestService_logout(t *testing.T) {
 		"Should be an error": {
 			accountID: "id-111",
 			mockBehavior: func(s *MockAccountStorage, accountID string) {
-				s.EXPECT().DeleteSesions(accountID).Return(errors.New("some error"))
+				s.EXPECT().DeleteSessionByRefreshToken(accountID, "refresh-111").Return(errors.New("some error"))
 			},
-			expectedErrorMessage: "can't delete the account sessions: some error",
+			expectedErrorMessage: "can't delete the account session: some error",
 			wantError:            true,
 		},
 	}
@@ -341,7 +522,7 @@ func TestService_logout(t *testing.T) {
 				storage: AccountStorage,
 			}
 
-			err := accountService.Logout(testCase.accountID)
+			err := accountService.Logout(testCase.accountID, "refresh-111")
 			if testCase.wantError {
 				assert.Equal(t, testCase.expectedErrorMessage, err.Error())
 			} else {
@@ -350,3 +531,318 @@ func TestService_logout(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Sessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	expired := created.Add(24 * time.Hour)
+
+	accountStorage := NewMockAccountStorage(ctrl)
+	accountStorage.EXPECT().SelectAccountSessions("id-111").Return([]core.Session{
+		{
+			ID: "session-1", RefreshToken: "refresh-1", AccountID: "id-111", UserAgent: "firefox",
+			ClientIP: "10.0.0.1", RequestHost: "example.com", Created: created, Expired: expired,
+		},
+		{
+			ID: "session-2", RefreshToken: "refresh-2", AccountID: "id-111", UserAgent: "curl",
+			ClientIP: "10.0.0.2", RequestHost: "example.com", Created: created, Expired: expired,
+		},
+	}, nil)
+	accountStorage.EXPECT().SelectAccountSessions("id-222").Return(nil, errors.New("db is down"))
+
+	accountService := AccountService{storage: accountStorage}
+
+	sessions, err := accountService.Sessions("id-111", "refresh-2")
+	assert.NoError(t, err)
+	assert.Equal(t, []core.SessionInfo{
+		{
+			ID: "session-1", UserAgent: "firefox", ClientIP: "10.0.0.1", RequestHost: "example.com",
+			Created: created, Expired: expired, Current: false,
+		},
+		{
+			ID: "session-2", UserAgent: "curl", ClientIP: "10.0.0.2", RequestHost: "example.com",
+			Created: created, Expired: expired, Current: true,
+		},
+	}, sessions)
+
+	_, err = accountService.Sessions("id-222", "refresh-2")
+	assert.EqualError(t, err, "service Sessions got the error: db is down")
+}
+
+func TestService_RevokeSession(t *testing.T) {
+	testCasesTable := map[string]struct {
+		storageErr           error
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {},
+		"The session of another account": {
+			storageErr:           core.ErrSesseionNotFound,
+			expectedErrorMessage: "service RevokeSession got the error: session is not found with such credentials",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			accountStorage.EXPECT().DeleteSession("id-111", "session-1").Return(testCase.storageErr)
+
+			err := AccountService{storage: accountStorage}.RevokeSession("id-111", "session-1")
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_ParseToken(t *testing.T) {
+	const signingKey = "key"
+
+	session := core.Session{RefreshToken: "refresh-111", AccountID: "id-111", Role: "user"}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         func(s *MockAccountStorage)
+		expectedIdentity     core.Identity
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Live session": {
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().IsSessionLive("refresh-111").Return(true, nil).Times(1)
+			},
+			expectedIdentity: core.Identity{AccountID: "id-111", Role: "user", RefreshToken: "refresh-111"},
+		},
+		"Revoked session": {
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().IsSessionLive("refresh-111").Return(false, nil).Times(1)
+			},
+			expectedErrorMessage: "the session of the token is revoked",
+			wantError:            true,
+		},
+		"Storage failure": {
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().IsSessionLive("refresh-111").Return(false, errors.New("db is down")).Times(1)
+			},
+			expectedErrorMessage: "can't check the session of the accessToken: db is down",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			testCase.mockBehavior(accountStorage)
+
+			accountService := NewAccountService(accountStorage, nil, keyring.NewHMAC(signingKey), nil, nil, nil, config.Config{
+				AccessTokenTTL:  time.Minute,
+				RefreshTokenTTL: time.Hour,
+				SessionCacheTTL: time.Minute,
+			})
+
+			accessToken, err := accountService.generateAccessToken(session)
+			if err != nil {
+				t.FailNow()
+			}
+
+			// The second parsing should be served by the cache unless the storage failed.
+			for i := 0; i < 2; i++ {
+				identity, err := accountService.ParseToken(accessToken)
+
+				if testCase.wantError {
+					assert.EqualError(t, err, testCase.expectedErrorMessage)
+
+					if !errors.Is(err, core.ErrSessionRevoked) {
+						break
+					}
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, testCase.expectedIdentity, identity)
+				}
+			}
+		})
+	}
+}
+
+func TestService_LogoutInvalidatesAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := core.Session{RefreshToken: "refresh-111", AccountID: "id-111", Role: "user"}
+
+	accountStorage := NewMockAccountStorage(ctrl)
+
+	gomock.InOrder(
+		accountStorage.EXPECT().IsSessionLive("refresh-111").Return(true, nil),
+		accountStorage.EXPECT().DeleteSessionByRefreshToken("id-111", "refresh-111").Return(nil),
+		accountStorage.EXPECT().IsSessionLive("refresh-111").Return(false, nil),
+	)
+
+	accountService := NewAccountService(accountStorage, nil, keyring.NewHMAC("key"), nil, nil, nil, config.Config{
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+		SessionCacheTTL: time.Minute,
+	})
+
+	accessToken, err := accountService.generateAccessToken(session)
+	if err != nil {
+		t.FailNow()
+	}
+
+	_, err = accountService.ParseToken(accessToken)
+	assert.NoError(t, err)
+
+	assert.NoError(t, accountService.Logout("id-111", "refresh-111"))
+
+	_, err = accountService.ParseToken(accessToken)
+	assert.ErrorIs(t, err, core.ErrSessionRevoked)
+}
+
+func TestSessionCache(t *testing.T) {
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	cache := newSessionCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.get("refresh-111")
+	assert.False(t, ok)
+
+	cache.set("refresh-111", true)
+	cache.set("refresh-222", false)
+
+	live, ok := cache.get("refresh-111")
+	assert.True(t, ok)
+	assert.True(t, live)
+
+	live, ok = cache.get("refresh-222")
+	assert.True(t, ok)
+	assert.False(t, live)
+
+	now = now.Add(time.Minute)
+
+	_, ok = cache.get("refresh-111")
+	assert.False(t, ok, "the entry should expire after the ttl")
+
+	cache.set("refresh-111", true)
+	cache.reset()
+
+	_, ok = cache.get("refresh-111")
+	assert.False(t, ok)
+
+	disabled := newSessionCache(0)
+	disabled.set("refresh-111", true)
+
+	_, ok = disabled.get("refresh-111")
+	assert.False(t, ok, "the zero ttl disables the cache")
+}
+
+func TestService_TokenLifetimes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		accessTTL  = 10 * time.Minute
+		refreshTTL = 24 * time.Hour
+	)
+
+	accountStorage := NewMockAccountStorage(ctrl)
+	passwordHasher := NewMockPasswordHasher(ctrl)
+
+	var insertedSession core.Session
+
+	accountStorage.EXPECT().SelectAccountByPhone("+380999999999").Return(
+		core.Account{ID: "id-111", Password: "hash", Role: "user"}, nil)
+	passwordHasher.EXPECT().Verify("qwerty123456", "hash").Return(true, false, nil)
+	accountStorage.EXPECT().InsertSession(gomock.Any()).DoAndReturn(func(session core.Session) (core.Session, error) {
+		insertedSession = session
+		session.RefreshToken = "refresh-111"
+
+		return session, nil
+	})
+
+	accountService := NewAccountService(accountStorage, passwordHasher, keyring.NewHMAC("key"), nil, nil, nil, config.Config{
+		AccessTokenTTL:  accessTTL,
+		RefreshTokenTTL: refreshTTL,
+	})
+
+	now := time.Now()
+
+	tokenPair, err := accountService.Login("+380999999999", "qwerty123456", core.Session{})
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, int64(accessTTL.Seconds()), tokenPair.ExpiresIn)
+	assert.WithinDuration(t, now.Add(refreshTTL), insertedSession.Expired, time.Minute,
+		"the session should live as long as the refresh token")
+
+	token, err := jwt.ParseWithClaims(tokenPair.AccessToken, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte("key"), nil
+	})
+	if err != nil {
+		t.FailNow()
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		t.FailNow()
+	}
+
+	assert.WithinDuration(t, now.Add(accessTTL), time.Unix(claims.ExpiresAt, 0), time.Minute,
+		"the access token should live for the access token ttl")
+}
+
+func TestClaims_validate(t *testing.T) {
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	leeway := 30 * time.Second
+
+	testCasesTable := map[string]struct {
+		expiresAt   time.Time
+		issuedAt    time.Time
+		expectedErr error
+	}{
+		"Valid token": {
+			expiresAt: now.Add(time.Minute),
+			issuedAt:  now.Add(-time.Minute),
+		},
+		"Expired within the leeway": {
+			expiresAt: now.Add(-20 * time.Second),
+			issuedAt:  now.Add(-time.Minute),
+		},
+		"Expired beyond the leeway": {
+			expiresAt:   now.Add(-40 * time.Second),
+			issuedAt:    now.Add(-time.Minute),
+			expectedErr: errTokenExpired,
+		},
+		"Issued by the server with the clock ahead": {
+			expiresAt: now.Add(time.Minute),
+			issuedAt:  now.Add(20 * time.Second),
+		},
+		"Issued in the future": {
+			expiresAt:   now.Add(time.Minute),
+			issuedAt:    now.Add(40 * time.Second),
+			expectedErr: errTokenNotValidYet,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			claims := Claims{StandardClaims: jwt.StandardClaims{
+				ExpiresAt: testCase.expiresAt.Unix(),
+				IssuedAt:  testCase.issuedAt.Unix(),
+			}}
+
+			assert.Equal(t, testCase.expectedErr, claims.validate(now, leeway))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+// Returns the accounts which satisfy the condition.
+func (a AccountService) ListAccounts(qp core.ConditionParams) ([]core.AccountInfo, error) {
+	accounts, err := a.storage.SelectAccounts(qp)
+	if err != nil {
+		return nil, fmt.Errorf("service ListAccounts got the error: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (a AccountService) GetAccount(accountID string) (core.AccountInfo, error) {
+	account, err := a.storage.SelectAccountInfo(accountID)
+	if err != nil {
+		return core.AccountInfo{}, fmt.Errorf("service GetAccount got the error: %w", err)
+	}
+
+	return account, nil
+}
+
+// Changes the role of the account. The sessions of the account are revoked,
+// so the access tokens with the previous role stop working. The admin can't change the own role.
+func (a AccountService) ChangeRole(actorID, accountID, role string) (core.AccountInfo, error) {
+	if actorID == accountID {
+		return core.AccountInfo{}, core.ErrSelfManagement
+	}
+
+	account, err := a.storage.UpdateAccountRole(accountID, role)
+	if err != nil {
+		return core.AccountInfo{}, fmt.Errorf("service ChangeRole got the error: %w", err)
+	}
+
+	a.sessions.reset()
+
+	return account, nil
+}
+
+// Disables the account and revokes its sessions. The admin can't disable the own account.
+func (a AccountService) DisableAccount(actorID, accountID string) (core.AccountInfo, error) {
+	if actorID == accountID {
+		return core.AccountInfo{}, core.ErrSelfManagement
+	}
+
+	account, err := a.storage.UpdateAccountDisabled(accountID, true)
+	if err != nil {
+		return core.AccountInfo{}, fmt.Errorf("service DisableAccount got the error: %w", err)
+	}
+
+	a.sessions.reset()
+
+	return account, nil
+}
+
+func (a AccountService) EnableAccount(accountID string) (core.AccountInfo, error) {
+	account, err := a.storage.UpdateAccountDisabled(accountID, false)
+	if err != nil {
+		return core.AccountInfo{}, fmt.Errorf("service EnableAccount got the error: %w", err)
+	}
+
+	return account, nil
+}
+
+// Revokes all the sessions of the account. The account without sessions is not an error.
+func (a AccountService) ForceLogout(accountID string) error {
+	if _, err := a.storage.SelectAccountInfo(accountID); err != nil {
+		return fmt.Errorf("service ForceLogout got the error: %w", err)
+	}
+
+	if err := a.storage.DeleteSesions(accountID); err != nil && !errors.Is(err, core.ErrNoRowsEffected) {
+		return fmt.Errorf("service ForceLogout got the error: %w", err)
+	}
+
+	a.sessions.reset()
+
+	return nil
+}
+
+// Makes the account with the phone the admin if there is no admin yet.
+// It lets the first admin appear without the database access. Reports whether the account was promoted.
+func (a AccountService) BootstrapAdmin(phone string) (bool, error) {
+	promoted, err := a.storage.PromoteFirstAdmin(phone)
+	if err != nil {
+		return false, fmt.Errorf("service BootstrapAdmin got the error: %w", err)
+	}
+
+	return promoted, nil
+}
+
+// Unlocks the login of the account locked after the failed attempts.
+func (a AccountService) ClearLoginLockout(accountID string) error {
+	account, err := a.storage.SelectAccountInfo(accountID)
+	if err != nil {
+		return fmt.Errorf("service ClearLoginLockout got the error: %w", err)
+	}
+
+	if err := a.throttle.reset(phoneThrottleKey + account.Phone); err != nil {
+		return fmt.Errorf("service ClearLoginLockout got the error: %w", err)
+	}
+
+	return nil
+}
+
+// Unlocks the login from the client IP locked after the failed attempts.
+func (a AccountService) ClearIPLockout(clientIP string) error {
+	if err := a.throttle.reset(ipThrottleKey + clientIP); err != nil {
+		return fmt.Errorf("service ClearIPLockout got the error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Brigant/PetPorject/app/core"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ChangeRole(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage)
+
+	testCasesTable := map[string]struct {
+		actorID              string
+		mockBehavior         mockBehavior
+		expectedAccount      core.AccountInfo
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			actorID: "admin-1",
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().UpdateAccountRole("id-111", core.RoleAdmin).
+					Return(core.AccountInfo{ID: "id-111", Role: core.RoleAdmin}, nil)
+			},
+			expectedAccount: core.AccountInfo{ID: "id-111", Role: core.RoleAdmin},
+		},
+		"The own role": {
+			actorID:              "id-111",
+			mockBehavior:         func(s *MockAccountStorage) {},
+			expectedErrorMessage: core.ErrSelfManagement.Error(),
+			wantError:            true,
+		},
+		"No account": {
+			actorID: "admin-1",
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().UpdateAccountRole("id-111", core.RoleAdmin).Return(core.AccountInfo{}, core.ErrUserNotFound)
+			},
+			expectedErrorMessage: "service ChangeRole got the error: user is not found with such credentials",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			testCase.mockBehavior(accountStorage)
+
+			account, err := AccountService{storage: accountStorage}.ChangeRole(testCase.actorID, "id-111", core.RoleAdmin)
+
+			assert.Equal(t, testCase.expectedAccount, account)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_DisableAccount(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage)
+
+	testCasesTable := map[string]struct {
+		actorID              string
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			actorID: "admin-1",
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().UpdateAccountDisabled("id-111", true).
+					Return(core.AccountInfo{ID: "id-111", Disabled: true}, nil)
+			},
+		},
+		"The own account": {
+			actorID:              "id-111",
+			mockBehavior:         func(s *MockAccountStorage) {},
+			expectedErrorMessage: core.ErrSelfManagement.Error(),
+			wantError:            true,
+		},
+		"Storage failure": {
+			actorID: "admin-1",
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().UpdateAccountDisabled("id-111", true).Return(core.AccountInfo{}, errors.New("db is down"))
+			},
+			expectedErrorMessage: "service DisableAccount got the error: db is down",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			testCase.mockBehavior(accountStorage)
+
+			_, err := AccountService{storage: accountStorage}.DisableAccount(testCase.actorID, "id-111")
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_ForceLogout(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage)
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().SelectAccountInfo("id-111").Return(core.AccountInfo{ID: "id-111"}, nil)
+				s.EXPECT().DeleteSesions("id-111").Return(nil)
+			},
+		},
+		"No sessions": {
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().SelectAccountInfo("id-111").Return(core.AccountInfo{ID: "id-111"}, nil)
+				s.EXPECT().DeleteSesions("id-111").Return(core.ErrNoRowsEffected)
+			},
+		},
+		"No account": {
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().SelectAccountInfo("id-111").Return(core.AccountInfo{}, core.ErrUserNotFound)
+			},
+			expectedErrorMessage: "service ForceLogout got the error: user is not found with such credentials",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			testCase.mockBehavior(accountStorage)
+
+			err := AccountService{storage: accountStorage}.ForceLogout("id-111")
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
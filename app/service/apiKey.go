@@ -0,0 +1,118 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+const (
+	// The keys start with the prefix, so the leaked ones are easy to find by the secret scanners.
+	apiKeyPrefix = "ppk_"
+	// The random bytes of the key secret.
+	apiKeyBytes = 32
+	// The length of the beginning of the key which is stored in plain to tell the keys apart.
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+)
+
+type APIKeyService struct {
+	storage APIKeyStorage
+	now     func() time.Time
+}
+
+func NewAPIKeyService(storage APIKeyStorage) APIKeyService {
+	return APIKeyService{storage: storage, now: time.Now}
+}
+
+// Creates the key granted the scopes. The returned secret is not stored and can't be shown again.
+func (s APIKeyService) Create(actorID string, input core.APIKeyInput) (core.IssuedAPIKey, error) {
+	if err := input.Validate(s.now()); err != nil {
+		return core.IssuedAPIKey{}, fmt.Errorf("api key validation failed: %w", err)
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		return core.IssuedAPIKey{}, fmt.Errorf("service Create api key got the error: %w", err)
+	}
+
+	key, err := s.storage.InsertAPIKey(core.APIKey{
+		Name:      input.Name,
+		Prefix:    secret[:apiKeyShownLength],
+		Scopes:    input.Scopes,
+		CreatedBy: actorID,
+		Expires:   input.Expires,
+	}, hashAPIKey(secret))
+	if err != nil {
+		return core.IssuedAPIKey{}, fmt.Errorf("service Create api key got the error: %w", err)
+	}
+
+	return core.IssuedAPIKey{APIKey: key, Key: secret}, nil
+}
+
+func (s APIKeyService) List() ([]core.APIKey, error) {
+	keys, err := s.storage.SelectAPIKeys()
+	if err != nil {
+		return nil, fmt.Errorf("service List api keys got the error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revokes the key, the requests with it are refused at once.
+func (s APIKeyService) Revoke(id string) error {
+	if err := s.storage.RevokeAPIKey(id); err != nil {
+		return fmt.Errorf("service Revoke api key got the error: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the key if it is known, not revoked and not expired, and records its use.
+func (s APIKeyService) Authenticate(secret string) (core.APIKey, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return core.APIKey{}, core.ErrInvalidAPIKey
+	}
+
+	key, err := s.storage.SelectAPIKeyByHash(hashAPIKey(secret))
+	if err != nil {
+		return core.APIKey{}, fmt.Errorf("service Authenticate got the error: %w", err)
+	}
+
+	now := s.now()
+
+	if key.Revoked != nil {
+		return core.APIKey{}, core.ErrAPIKeyRevoked
+	}
+
+	if key.Expires != nil && !now.Before(*key.Expires) {
+		return core.APIKey{}, core.ErrAPIKeyExpired
+	}
+
+	// The failure is not reported, the last use is only informational.
+	s.storage.UpdateAPIKeyLastUsed(key.ID, now) //nolint:errcheck
+
+	return key, nil
+}
+
+func generateAPIKey() (string, error) {
+	secret := make([]byte, apiKeyBytes)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("can't generate the api key: %w", err)
+	}
+
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// The secret is random and long, so the plain hash is enough, unlike the passwords.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyService_Create(t *testing.T) {
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	expires := now.Add(24 * time.Hour)
+	expired := now.Add(-time.Second)
+
+	type mockBehavior func(s *MockAPIKeyStorage, keyHash *string)
+
+	testCasesTable := map[string]struct {
+		input                core.APIKeyInput
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			input: core.APIKeyInput{Name: "recommendations", Scopes: []core.Permission{core.PermMovieWrite}, Expires: &expires},
+			mockBehavior: func(s *MockAPIKeyStorage, keyHash *string) {
+				s.EXPECT().InsertAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(key core.APIKey, hash string) (core.APIKey, error) {
+						*keyHash = hash
+						key.ID = "key-111"
+
+						return key, nil
+					})
+			},
+		},
+		"Unknown scope": {
+			input:                core.APIKeyInput{Name: "dashboard", Scopes: []core.Permission{"movie:read"}},
+			mockBehavior:         func(s *MockAPIKeyStorage, keyHash *string) {},
+			expectedErrorMessage: `api key validation failed: unknown permission: "movie:read"`,
+			wantError:            true,
+		},
+		"Scope of the account": {
+			input:                core.APIKeyInput{Name: "dashboard", Scopes: []core.Permission{core.PermAccountManage}},
+			mockBehavior:         func(s *MockAPIKeyStorage, keyHash *string) {},
+			expectedErrorMessage: `api key validation failed: the permission can't be granted to the api key: "account:manage"`,
+			wantError:            true,
+		},
+		"Missing scopes": {
+			input:                core.APIKeyInput{Name: "dashboard"},
+			mockBehavior:         func(s *MockAPIKeyStorage, keyHash *string) {},
+			expectedErrorMessage: "api key validation failed: the api key should be granted at least one scope",
+			wantError:            true,
+		},
+		"Empty scopes": {
+			input:                core.APIKeyInput{Name: "dashboard", Scopes: []core.Permission{}},
+			mockBehavior:         func(s *MockAPIKeyStorage, keyHash *string) {},
+			expectedErrorMessage: "api key validation failed: the api key should be granted at least one scope",
+			wantError:            true,
+		},
+		"Expiry in the past": {
+			input: core.APIKeyInput{
+				Name: "dashboard", Scopes: []core.Permission{core.PermCatalogueRead}, Expires: &expired,
+			},
+			mockBehavior:         func(s *MockAPIKeyStorage, keyHash *string) {},
+			expectedErrorMessage: "api key validation failed: the expiry should be in the future",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var keyHash string
+
+			storage := NewMockAPIKeyStorage(ctrl)
+			testCase.mockBehavior(storage, &keyHash)
+
+			apiKeyService := NewAPIKeyService(storage)
+			apiKeyService.now = func() time.Time { return now }
+
+			issued, err := apiKeyService.Create("admin-111", testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "key-111", issued.ID)
+			assert.Equal(t, "admin-111", issued.CreatedBy)
+			assert.True(t, strings.HasPrefix(issued.Key, "ppk_"))
+			assert.Equal(t, issued.Key[:apiKeyShownLength], issued.Prefix)
+			assert.Equal(t, hashAPIKey(issued.Key), keyHash, "only the hash of the key is stored")
+			assert.NotEqual(t, issued.Key, keyHash)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	const secret = "ppk_c2VjcmV0LWtleS1vZi10aGUtc2VydmljZQ"
+
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	type mockBehavior func(s *MockAPIKeyStorage)
+
+	testCasesTable := map[string]struct {
+		secret        string
+		mockBehavior  mockBehavior
+		expectedError error
+	}{
+		"Success": {
+			secret: secret,
+			mockBehavior: func(s *MockAPIKeyStorage) {
+				s.EXPECT().SelectAPIKeyByHash(hashAPIKey(secret)).Return(core.APIKey{ID: "key-111", Expires: &later}, nil)
+				s.EXPECT().UpdateAPIKeyLastUsed("key-111", now).Return(nil)
+			},
+		},
+		"The failed update of the last use doesn't refuse the key": {
+			secret: secret,
+			mockBehavior: func(s *MockAPIKeyStorage) {
+				s.EXPECT().SelectAPIKeyByHash(hashAPIKey(secret)).Return(core.APIKey{ID: "key-111"}, nil)
+				s.EXPECT().UpdateAPIKeyLastUsed("key-111", now).Return(core.ErrAPIKeyNotFound)
+			},
+		},
+		"Not the api key": {
+			secret:        "some-access-token",
+			mockBehavior:  func(s *MockAPIKeyStorage) {},
+			expectedError: core.ErrInvalidAPIKey,
+		},
+		"Unknown key": {
+			secret: secret,
+			mockBehavior: func(s *MockAPIKeyStorage) {
+				s.EXPECT().SelectAPIKeyByHash(hashAPIKey(secret)).Return(core.APIKey{}, core.ErrAPIKeyNotFound)
+			},
+			expectedError: core.ErrAPIKeyNotFound,
+		},
+		"Revoked key": {
+			secret: secret,
+			mockBehavior: func(s *MockAPIKeyStorage) {
+				s.EXPECT().SelectAPIKeyByHash(hashAPIKey(secret)).Return(core.APIKey{ID: "key-111", Revoked: &earlier}, nil)
+			},
+			expectedError: core.ErrAPIKeyRevoked,
+		},
+		"Expired key": {
+			secret: secret,
+			mockBehavior: func(s *MockAPIKeyStorage) {
+				s.EXPECT().SelectAPIKeyByHash(hashAPIKey(secret)).Return(core.APIKey{ID: "key-111", Expires: &now}, nil)
+			},
+			expectedError: core.ErrAPIKeyExpired,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := NewMockAPIKeyStorage(ctrl)
+			testCase.mockBehavior(storage)
+
+			apiKeyService := NewAPIKeyService(storage)
+			apiKeyService.now = func() time.Time { return now }
+
+			key, err := apiKeyService.Authenticate(testCase.secret)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "key-111", key.ID)
+		})
+	}
+}
@@ -1,25 +1,101 @@
 package service
 
 import (
+	"io"
+	"time"
+
 	"github.com/Brigant/PetPorject/app/core"
+	"github.com/golang-jwt/jwt"
 )
 
 //go:generate mockgen -source=./contract.go -destination=./contract_mock_test.go -package=service
 
 type AccountStorage interface {
-	InsertAccount(account core.Account) (accountID string, err error)
+	InsertAccount(account core.Account, systemLists []core.ListType) (accountID string, err error)
 	SelectAccountByPhone(phone string) (core.Account, error)
 	SelectAccountByID(accountID string) (core.Account, error)
 	InsertSession(session core.Session) (core.Session, error)
 	SelectSession(session core.Session) (core.Session, error)
-	RefreshSession(session core.Session) error
+	RotateSession(refreshToken string, expired time.Time) (core.Session, error)
+	SelectRetiredRefreshToken(refreshToken string) (familyID, accountID string, err error)
+	DeleteSessionFamily(familyID string) error
+	IsSessionLive(refreshToken string) (bool, error)
+	UpdateAccountPassword(accountID, passwordHash string) error
+	SelectAccountSessions(accountID string) ([]core.Session, error)
+	DeleteSession(accountID, sessionID string) error
+	DeleteSessionByRefreshToken(accountID, refreshToken string) error
 	DeleteSesions(accountID string) error
+	SelectAccounts(qp core.ConditionParams) ([]core.AccountInfo, error)
+	SelectAccountInfo(accountID string) (core.AccountInfo, error)
+	UpdateAccountRole(accountID, role string) (core.AccountInfo, error)
+	UpdateAccountDisabled(accountID string, disabled bool) (core.AccountInfo, error)
+	PromoteFirstAdmin(phone string) (bool, error)
+	UpdateAccountAge(accountID string, age int) (core.AccountInfo, error)
+	ChangePassword(accountID, passwordHash, keepRefreshToken string) error
+	DeleteAccount(accountID string) (core.ErasureReport, error)
+	SelectAccountExport(accountID string) (core.AccountExport, error)
+	UpsertOneTimeCode(code core.OneTimeCode) error
+	SelectOneTimeCode(accountID string, purpose core.CodePurpose) (core.OneTimeCode, error)
+	AddCodeAttempt(accountID string, purpose core.CodePurpose) (core.OneTimeCode, error)
+	ConfirmPhone(accountID string) error
+	ResetPassword(accountID, passwordHash string) error
+	InsertOIDCLogin(login core.OIDCLogin) error
+	TakeOIDCLogin(state string) (core.OIDCLogin, error)
+	SelectAccountByIdentity(issuer, subject string) (core.Account, error)
+	InsertIdentity(accountID string, identity core.ExternalIdentity) error
+	InsertAccountWithIdentity(account core.Account, identity core.ExternalIdentity,
+		systemLists []core.ListType) (accountID string, err error)
+}
+
+// The OpenID Connect provider the accounts log in with instead of the password.
+type OIDCProvider interface {
+	AuthCodeURL(state, nonce, codeVerifier string) (string, error)
+	Exchange(code, codeVerifier, nonce string) (core.ExternalIdentity, error)
+}
+
+type LoginAttemptStorage interface {
+	SelectLoginAttempts(key string) (core.LoginAttempts, error)
+	AddLoginFailure(key string, window time.Duration) (core.LoginAttempts, error)
+	LockLogin(key string, until time.Time) error
+	DeleteLoginAttempts(key string) error
+}
+
+type APIKeyStorage interface {
+	InsertAPIKey(key core.APIKey, keyHash string) (core.APIKey, error)
+	SelectAPIKeys() ([]core.APIKey, error)
+	SelectAPIKeyByHash(keyHash string) (core.APIKey, error)
+	RevokeAPIKey(id string) error
+	UpdateAPIKeyLastUsed(id string, used time.Time) error
 }
 
 type DirectorStorage interface {
 	InsertDirector(director core.Director) error
 	SelectDirectorByID(directorID string) (core.Director, error)
-	SelectDirectorList() ([]core.Director, error)
+	SelectDirectorList(qp core.ConditionParams) ([]core.Director, error)
+	SelectDirectorMovies(directorID string, qp core.ConditionParams) ([]core.Movie, error)
+	UpdateDirector(director core.Director) (string, error)
+	UpdateDirectorPhoto(directorID, photoURL string) (previousURL string, err error)
+	DeleteDirector(directorID string) (photoURL string, err error)
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (match, rehash bool, err error)
+}
+
+type TokenKeys interface {
+	Sign(claims jwt.Claims) (string, error)
+	Verify(token *jwt.Token) (interface{}, error)
+	JWKS() core.JSONWebKeySet
+}
+
+type SMSSender interface {
+	Send(phone, text string) error
+}
+
+type BlobStore interface {
+	Put(key, contentType string, body io.Reader, size int64) (url string, err error)
+	Delete(url string) error
 }
 
 type MovieStorage interface {
@@ -27,10 +103,25 @@ type MovieStorage interface {
 	SelectAllMovies(core.ConditionParams) ([]core.Movie, error)
 	SelectMoviesCSV(core.ConditionParams) ([]core.MovieCSV, error)
 	SelectMovieByID(movieID string) (core.Movie, error)
+	UpdateMovie(movie core.Movie) (string, error)
+	DeleteMovie(movieID string, cascade bool) (int, error)
 }
 
 type ListSorage interface {
 	Insert(core.MovieList) (string, error)
 	SelectAllUsersLists([]core.QuerySliceElement) ([]core.MovieList, error)
 	InsertMovieToList(moviID, listID string) error
+	SelectListByID(listID, accountID string) (core.MovieList, error)
+	SelectListMovies(listID string, qp core.ConditionParams) ([]core.ListMovie, error)
+	DeleteMovieFromList(listID, movieID string) error
+	DeleteList(listID, accountID string) error
+	UpdateListType(listID, accountID, listType string) (core.MovieList, error)
+	InsertMovieToSystemList(accountID string, listType core.ListType, movieID string) error
+	DeleteMovieFromSystemList(accountID string, listType core.ListType, movieID string) error
+}
+
+type RatingStorage interface {
+	SelectRating(accountID, movieID string) (core.Rating, error)
+	UpsertRating(rating core.Rating) (core.Rating, error)
+	DeleteRating(accountID, movieID string) error
 }
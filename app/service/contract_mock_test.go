@@ -5,9 +5,12 @@
 package service
 
 import (
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	core "github.com/Brigant/PetPorject/app/core"
+	jwt "github.com/golang-jwt/jwt"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -34,174 +37,1056 @@ func (m *MockAccountStorage) EXPECT() *MockAccountStorageMockRecorder {
 	return m.recorder
 }
 
+// AddCodeAttempt mocks base method.
+func (m *MockAccountStorage) AddCodeAttempt(accountID string, purpose core.CodePurpose) (core.OneTimeCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCodeAttempt", accountID, purpose)
+	ret0, _ := ret[0].(core.OneTimeCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCodeAttempt indicates an expected call of AddCodeAttempt.
+func (mr *MockAccountStorageMockRecorder) AddCodeAttempt(accountID, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCodeAttempt", reflect.TypeOf((*MockAccountStorage)(nil).AddCodeAttempt), accountID, purpose)
+}
+
+// ChangePassword mocks base method.
+func (m *MockAccountStorage) ChangePassword(accountID, passwordHash, keepRefreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", accountID, passwordHash, keepRefreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAccountStorageMockRecorder) ChangePassword(accountID, passwordHash, keepRefreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAccountStorage)(nil).ChangePassword), accountID, passwordHash, keepRefreshToken)
+}
+
+// ConfirmPhone mocks base method.
+func (m *MockAccountStorage) ConfirmPhone(accountID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPhone", accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmPhone indicates an expected call of ConfirmPhone.
+func (mr *MockAccountStorageMockRecorder) ConfirmPhone(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPhone", reflect.TypeOf((*MockAccountStorage)(nil).ConfirmPhone), accountID)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccountStorage) DeleteAccount(accountID string) (core.ErasureReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", accountID)
+	ret0, _ := ret[0].(core.ErasureReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountStorageMockRecorder) DeleteAccount(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountStorage)(nil).DeleteAccount), accountID)
+}
+
 // DeleteSesions mocks base method.
 func (m *MockAccountStorage) DeleteSesions(accountID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSesions", accountID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteSesions", accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSesions indicates an expected call of DeleteSesions.
+func (mr *MockAccountStorageMockRecorder) DeleteSesions(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSesions", reflect.TypeOf((*MockAccountStorage)(nil).DeleteSesions), accountID)
+}
+
+// DeleteSession mocks base method.
+func (m *MockAccountStorage) DeleteSession(accountID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", accountID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockAccountStorageMockRecorder) DeleteSession(accountID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAccountStorage)(nil).DeleteSession), accountID, sessionID)
+}
+
+// DeleteSessionByRefreshToken mocks base method.
+func (m *MockAccountStorage) DeleteSessionByRefreshToken(accountID, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionByRefreshToken", accountID, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionByRefreshToken indicates an expected call of DeleteSessionByRefreshToken.
+func (mr *MockAccountStorageMockRecorder) DeleteSessionByRefreshToken(accountID, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionByRefreshToken", reflect.TypeOf((*MockAccountStorage)(nil).DeleteSessionByRefreshToken), accountID, refreshToken)
+}
+
+// DeleteSessionFamily mocks base method.
+func (m *MockAccountStorage) DeleteSessionFamily(familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionFamily", familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionFamily indicates an expected call of DeleteSessionFamily.
+func (mr *MockAccountStorageMockRecorder) DeleteSessionFamily(familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionFamily", reflect.TypeOf((*MockAccountStorage)(nil).DeleteSessionFamily), familyID)
+}
+
+// InsertAccount mocks base method.
+func (m *MockAccountStorage) InsertAccount(account core.Account, systemLists []core.ListType) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccount", account, systemLists)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAccount indicates an expected call of InsertAccount.
+func (mr *MockAccountStorageMockRecorder) InsertAccount(account, systemLists interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccount", reflect.TypeOf((*MockAccountStorage)(nil).InsertAccount), account, systemLists)
+}
+
+// InsertAccountWithIdentity mocks base method.
+func (m *MockAccountStorage) InsertAccountWithIdentity(account core.Account, identity core.ExternalIdentity, systemLists []core.ListType) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountWithIdentity", account, identity, systemLists)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAccountWithIdentity indicates an expected call of InsertAccountWithIdentity.
+func (mr *MockAccountStorageMockRecorder) InsertAccountWithIdentity(account, identity, systemLists interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountWithIdentity", reflect.TypeOf((*MockAccountStorage)(nil).InsertAccountWithIdentity), account, identity, systemLists)
+}
+
+// InsertIdentity mocks base method.
+func (m *MockAccountStorage) InsertIdentity(accountID string, identity core.ExternalIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertIdentity", accountID, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertIdentity indicates an expected call of InsertIdentity.
+func (mr *MockAccountStorageMockRecorder) InsertIdentity(accountID, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIdentity", reflect.TypeOf((*MockAccountStorage)(nil).InsertIdentity), accountID, identity)
+}
+
+// InsertOIDCLogin mocks base method.
+func (m *MockAccountStorage) InsertOIDCLogin(login core.OIDCLogin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOIDCLogin", login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOIDCLogin indicates an expected call of InsertOIDCLogin.
+func (mr *MockAccountStorageMockRecorder) InsertOIDCLogin(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOIDCLogin", reflect.TypeOf((*MockAccountStorage)(nil).InsertOIDCLogin), login)
+}
+
+// InsertSession mocks base method.
+func (m *MockAccountStorage) InsertSession(session core.Session) (core.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSession", session)
+	ret0, _ := ret[0].(core.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertSession indicates an expected call of InsertSession.
+func (mr *MockAccountStorageMockRecorder) InsertSession(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockAccountStorage)(nil).InsertSession), session)
+}
+
+// IsSessionLive mocks base method.
+func (m *MockAccountStorage) IsSessionLive(refreshToken string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionLive", refreshToken)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionLive indicates an expected call of IsSessionLive.
+func (mr *MockAccountStorageMockRecorder) IsSessionLive(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionLive", reflect.TypeOf((*MockAccountStorage)(nil).IsSessionLive), refreshToken)
+}
+
+// PromoteFirstAdmin mocks base method.
+func (m *MockAccountStorage) PromoteFirstAdmin(phone string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteFirstAdmin", phone)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteFirstAdmin indicates an expected call of PromoteFirstAdmin.
+func (mr *MockAccountStorageMockRecorder) PromoteFirstAdmin(phone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteFirstAdmin", reflect.TypeOf((*MockAccountStorage)(nil).PromoteFirstAdmin), phone)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAccountStorage) ResetPassword(accountID, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", accountID, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAccountStorageMockRecorder) ResetPassword(accountID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAccountStorage)(nil).ResetPassword), accountID, passwordHash)
+}
+
+// RotateSession mocks base method.
+func (m *MockAccountStorage) RotateSession(refreshToken string, expired time.Time) (core.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", refreshToken, expired)
+	ret0, _ := ret[0].(core.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockAccountStorageMockRecorder) RotateSession(refreshToken, expired interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockAccountStorage)(nil).RotateSession), refreshToken, expired)
+}
+
+// SelectAccountByID mocks base method.
+func (m *MockAccountStorage) SelectAccountByID(accountID string) (core.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAccountByID", accountID)
+	ret0, _ := ret[0].(core.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAccountByID indicates an expected call of SelectAccountByID.
+func (mr *MockAccountStorageMockRecorder) SelectAccountByID(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountByID", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountByID), accountID)
+}
+
+// SelectAccountByIdentity mocks base method.
+func (m *MockAccountStorage) SelectAccountByIdentity(issuer, subject string) (core.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAccountByIdentity", issuer, subject)
+	ret0, _ := ret[0].(core.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAccountByIdentity indicates an expected call of SelectAccountByIdentity.
+func (mr *MockAccountStorageMockRecorder) SelectAccountByIdentity(issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountByIdentity", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountByIdentity), issuer, subject)
+}
+
+// SelectAccountByPhone mocks base method.
+func (m *MockAccountStorage) SelectAccountByPhone(phone string) (core.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAccountByPhone", phone)
+	ret0, _ := ret[0].(core.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAccountByPhone indicates an expected call of SelectAccountByPhone.
+func (mr *MockAccountStorageMockRecorder) SelectAccountByPhone(phone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountByPhone", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountByPhone), phone)
+}
+
+// SelectAccountExport mocks base method.
+func (m *MockAccountStorage) SelectAccountExport(accountID string) (core.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAccountExport", accountID)
+	ret0, _ := ret[0].(core.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAccountExport indicates an expected call of SelectAccountExport.
+func (mr *MockAccountStorageMockRecorder) SelectAccountExport(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountExport", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountExport), accountID)
+}
+
+// SelectAccountInfo mocks base method.
+func (m *MockAccountStorage) SelectAccountInfo(accountID string) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAccountInfo", accountID)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAccountInfo indicates an expected call of SelectAccountInfo.
+func (mr *MockAccountStorageMockRecorder) SelectAccountInfo(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountInfo", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountInfo), accountID)
+}
+
+// SelectAccountSessions mocks base method.
+func (m *MockAccountStorage) SelectAccountSessions(accountID string) ([]core.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAccountSessions", accountID)
+	ret0, _ := ret[0].([]core.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAccountSessions indicates an expected call of SelectAccountSessions.
+func (mr *MockAccountStorageMockRecorder) SelectAccountSessions(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountSessions", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountSessions), accountID)
+}
+
+// SelectAccounts mocks base method.
+func (m *MockAccountStorage) SelectAccounts(qp core.ConditionParams) ([]core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAccounts", qp)
+	ret0, _ := ret[0].([]core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAccounts indicates an expected call of SelectAccounts.
+func (mr *MockAccountStorageMockRecorder) SelectAccounts(qp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccounts", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccounts), qp)
+}
+
+// SelectOneTimeCode mocks base method.
+func (m *MockAccountStorage) SelectOneTimeCode(accountID string, purpose core.CodePurpose) (core.OneTimeCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOneTimeCode", accountID, purpose)
+	ret0, _ := ret[0].(core.OneTimeCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOneTimeCode indicates an expected call of SelectOneTimeCode.
+func (mr *MockAccountStorageMockRecorder) SelectOneTimeCode(accountID, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOneTimeCode", reflect.TypeOf((*MockAccountStorage)(nil).SelectOneTimeCode), accountID, purpose)
+}
+
+// SelectRetiredRefreshToken mocks base method.
+func (m *MockAccountStorage) SelectRetiredRefreshToken(refreshToken string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRetiredRefreshToken", refreshToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectRetiredRefreshToken indicates an expected call of SelectRetiredRefreshToken.
+func (mr *MockAccountStorageMockRecorder) SelectRetiredRefreshToken(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRetiredRefreshToken", reflect.TypeOf((*MockAccountStorage)(nil).SelectRetiredRefreshToken), refreshToken)
+}
+
+// SelectSession mocks base method.
+func (m *MockAccountStorage) SelectSession(session core.Session) (core.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSession", session)
+	ret0, _ := ret[0].(core.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSession indicates an expected call of SelectSession.
+func (mr *MockAccountStorageMockRecorder) SelectSession(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSession", reflect.TypeOf((*MockAccountStorage)(nil).SelectSession), session)
+}
+
+// TakeOIDCLogin mocks base method.
+func (m *MockAccountStorage) TakeOIDCLogin(state string) (core.OIDCLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeOIDCLogin", state)
+	ret0, _ := ret[0].(core.OIDCLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeOIDCLogin indicates an expected call of TakeOIDCLogin.
+func (mr *MockAccountStorageMockRecorder) TakeOIDCLogin(state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeOIDCLogin", reflect.TypeOf((*MockAccountStorage)(nil).TakeOIDCLogin), state)
+}
+
+// UpdateAccountAge mocks base method.
+func (m *MockAccountStorage) UpdateAccountAge(accountID string, age int) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountAge", accountID, age)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountAge indicates an expected call of UpdateAccountAge.
+func (mr *MockAccountStorageMockRecorder) UpdateAccountAge(accountID, age interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountAge", reflect.TypeOf((*MockAccountStorage)(nil).UpdateAccountAge), accountID, age)
+}
+
+// UpdateAccountDisabled mocks base method.
+func (m *MockAccountStorage) UpdateAccountDisabled(accountID string, disabled bool) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountDisabled", accountID, disabled)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountDisabled indicates an expected call of UpdateAccountDisabled.
+func (mr *MockAccountStorageMockRecorder) UpdateAccountDisabled(accountID, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountDisabled", reflect.TypeOf((*MockAccountStorage)(nil).UpdateAccountDisabled), accountID, disabled)
+}
+
+// UpdateAccountPassword mocks base method.
+func (m *MockAccountStorage) UpdateAccountPassword(accountID, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountPassword", accountID, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountPassword indicates an expected call of UpdateAccountPassword.
+func (mr *MockAccountStorageMockRecorder) UpdateAccountPassword(accountID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountPassword", reflect.TypeOf((*MockAccountStorage)(nil).UpdateAccountPassword), accountID, passwordHash)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccountStorage) UpdateAccountRole(accountID, role string) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", accountID, role)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccountStorageMockRecorder) UpdateAccountRole(accountID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccountStorage)(nil).UpdateAccountRole), accountID, role)
+}
+
+// UpsertOneTimeCode mocks base method.
+func (m *MockAccountStorage) UpsertOneTimeCode(code core.OneTimeCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOneTimeCode", code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertOneTimeCode indicates an expected call of UpsertOneTimeCode.
+func (mr *MockAccountStorageMockRecorder) UpsertOneTimeCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOneTimeCode", reflect.TypeOf((*MockAccountStorage)(nil).UpsertOneTimeCode), code)
+}
+
+// MockOIDCProvider is a mock of OIDCProvider interface.
+type MockOIDCProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCProviderMockRecorder
+}
+
+// MockOIDCProviderMockRecorder is the mock recorder for MockOIDCProvider.
+type MockOIDCProviderMockRecorder struct {
+	mock *MockOIDCProvider
+}
+
+// NewMockOIDCProvider creates a new mock instance.
+func NewMockOIDCProvider(ctrl *gomock.Controller) *MockOIDCProvider {
+	mock := &MockOIDCProvider{ctrl: ctrl}
+	mock.recorder = &MockOIDCProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCProvider) EXPECT() *MockOIDCProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockOIDCProvider) AuthCodeURL(state, nonce, codeVerifier string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", state, nonce, codeVerifier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockOIDCProviderMockRecorder) AuthCodeURL(state, nonce, codeVerifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockOIDCProvider)(nil).AuthCodeURL), state, nonce, codeVerifier)
+}
+
+// Exchange mocks base method.
+func (m *MockOIDCProvider) Exchange(code, codeVerifier, nonce string) (core.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", code, codeVerifier, nonce)
+	ret0, _ := ret[0].(core.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockOIDCProviderMockRecorder) Exchange(code, codeVerifier, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockOIDCProvider)(nil).Exchange), code, codeVerifier, nonce)
+}
+
+// MockLoginAttemptStorage is a mock of LoginAttemptStorage interface.
+type MockLoginAttemptStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptStorageMockRecorder
+}
+
+// MockLoginAttemptStorageMockRecorder is the mock recorder for MockLoginAttemptStorage.
+type MockLoginAttemptStorageMockRecorder struct {
+	mock *MockLoginAttemptStorage
+}
+
+// NewMockLoginAttemptStorage creates a new mock instance.
+func NewMockLoginAttemptStorage(ctrl *gomock.Controller) *MockLoginAttemptStorage {
+	mock := &MockLoginAttemptStorage{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptStorage) EXPECT() *MockLoginAttemptStorageMockRecorder {
+	return m.recorder
+}
+
+// AddLoginFailure mocks base method.
+func (m *MockLoginAttemptStorage) AddLoginFailure(key string, window time.Duration) (core.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginFailure", key, window)
+	ret0, _ := ret[0].(core.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginFailure indicates an expected call of AddLoginFailure.
+func (mr *MockLoginAttemptStorageMockRecorder) AddLoginFailure(key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginFailure", reflect.TypeOf((*MockLoginAttemptStorage)(nil).AddLoginFailure), key, window)
+}
+
+// DeleteLoginAttempts mocks base method.
+func (m *MockLoginAttemptStorage) DeleteLoginAttempts(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginAttempts", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginAttempts indicates an expected call of DeleteLoginAttempts.
+func (mr *MockLoginAttemptStorageMockRecorder) DeleteLoginAttempts(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginAttempts", reflect.TypeOf((*MockLoginAttemptStorage)(nil).DeleteLoginAttempts), key)
+}
+
+// LockLogin mocks base method.
+func (m *MockLoginAttemptStorage) LockLogin(key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockLoginAttemptStorageMockRecorder) LockLogin(key, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockLoginAttemptStorage)(nil).LockLogin), key, until)
+}
+
+// SelectLoginAttempts mocks base method.
+func (m *MockLoginAttemptStorage) SelectLoginAttempts(key string) (core.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLoginAttempts", key)
+	ret0, _ := ret[0].(core.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLoginAttempts indicates an expected call of SelectLoginAttempts.
+func (mr *MockLoginAttemptStorageMockRecorder) SelectLoginAttempts(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLoginAttempts", reflect.TypeOf((*MockLoginAttemptStorage)(nil).SelectLoginAttempts), key)
+}
+
+// MockAPIKeyStorage is a mock of APIKeyStorage interface.
+type MockAPIKeyStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyStorageMockRecorder
+}
+
+// MockAPIKeyStorageMockRecorder is the mock recorder for MockAPIKeyStorage.
+type MockAPIKeyStorageMockRecorder struct {
+	mock *MockAPIKeyStorage
+}
+
+// NewMockAPIKeyStorage creates a new mock instance.
+func NewMockAPIKeyStorage(ctrl *gomock.Controller) *MockAPIKeyStorage {
+	mock := &MockAPIKeyStorage{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyStorage) EXPECT() *MockAPIKeyStorageMockRecorder {
+	return m.recorder
+}
+
+// InsertAPIKey mocks base method.
+func (m *MockAPIKeyStorage) InsertAPIKey(key core.APIKey, keyHash string) (core.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAPIKey", key, keyHash)
+	ret0, _ := ret[0].(core.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAPIKey indicates an expected call of InsertAPIKey.
+func (mr *MockAPIKeyStorageMockRecorder) InsertAPIKey(key, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAPIKey", reflect.TypeOf((*MockAPIKeyStorage)(nil).InsertAPIKey), key, keyHash)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyStorage) RevokeAPIKey(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyStorageMockRecorder) RevokeAPIKey(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyStorage)(nil).RevokeAPIKey), id)
+}
+
+// SelectAPIKeyByHash mocks base method.
+func (m *MockAPIKeyStorage) SelectAPIKeyByHash(keyHash string) (core.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAPIKeyByHash", keyHash)
+	ret0, _ := ret[0].(core.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAPIKeyByHash indicates an expected call of SelectAPIKeyByHash.
+func (mr *MockAPIKeyStorageMockRecorder) SelectAPIKeyByHash(keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAPIKeyByHash", reflect.TypeOf((*MockAPIKeyStorage)(nil).SelectAPIKeyByHash), keyHash)
+}
+
+// SelectAPIKeys mocks base method.
+func (m *MockAPIKeyStorage) SelectAPIKeys() ([]core.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAPIKeys")
+	ret0, _ := ret[0].([]core.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAPIKeys indicates an expected call of SelectAPIKeys.
+func (mr *MockAPIKeyStorageMockRecorder) SelectAPIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAPIKeys", reflect.TypeOf((*MockAPIKeyStorage)(nil).SelectAPIKeys))
+}
+
+// UpdateAPIKeyLastUsed mocks base method.
+func (m *MockAPIKeyStorage) UpdateAPIKeyLastUsed(id string, used time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsed", id, used)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeyLastUsed indicates an expected call of UpdateAPIKeyLastUsed.
+func (mr *MockAPIKeyStorageMockRecorder) UpdateAPIKeyLastUsed(id, used interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsed", reflect.TypeOf((*MockAPIKeyStorage)(nil).UpdateAPIKeyLastUsed), id, used)
+}
+
+// MockDirectorStorage is a mock of DirectorStorage interface.
+type MockDirectorStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockDirectorStorageMockRecorder
+}
+
+// MockDirectorStorageMockRecorder is the mock recorder for MockDirectorStorage.
+type MockDirectorStorageMockRecorder struct {
+	mock *MockDirectorStorage
+}
+
+// NewMockDirectorStorage creates a new mock instance.
+func NewMockDirectorStorage(ctrl *gomock.Controller) *MockDirectorStorage {
+	mock := &MockDirectorStorage{ctrl: ctrl}
+	mock.recorder = &MockDirectorStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDirectorStorage) EXPECT() *MockDirectorStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteDirector mocks base method.
+func (m *MockDirectorStorage) DeleteDirector(directorID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDirector", directorID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDirector indicates an expected call of DeleteDirector.
+func (mr *MockDirectorStorageMockRecorder) DeleteDirector(directorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDirector", reflect.TypeOf((*MockDirectorStorage)(nil).DeleteDirector), directorID)
+}
+
+// InsertDirector mocks base method.
+func (m *MockDirectorStorage) InsertDirector(director core.Director) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDirector", director)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertDirector indicates an expected call of InsertDirector.
+func (mr *MockDirectorStorageMockRecorder) InsertDirector(director interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDirector", reflect.TypeOf((*MockDirectorStorage)(nil).InsertDirector), director)
+}
+
+// SelectDirectorByID mocks base method.
+func (m *MockDirectorStorage) SelectDirectorByID(directorID string) (core.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDirectorByID", directorID)
+	ret0, _ := ret[0].(core.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteSesions indicates an expected call of DeleteSesions.
-func (mr *MockAccountStorageMockRecorder) DeleteSesions(accountID interface{}) *gomock.Call {
+// SelectDirectorByID indicates an expected call of SelectDirectorByID.
+func (mr *MockDirectorStorageMockRecorder) SelectDirectorByID(directorID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSesions", reflect.TypeOf((*MockAccountStorage)(nil).DeleteSesions), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDirectorByID", reflect.TypeOf((*MockDirectorStorage)(nil).SelectDirectorByID), directorID)
 }
 
-// InsertAccount mocks base method.
-func (m *MockAccountStorage) InsertAccount(account core.Account) (string, error) {
+// SelectDirectorList mocks base method.
+func (m *MockDirectorStorage) SelectDirectorList(qp core.ConditionParams) ([]core.Director, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertAccount", account)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "SelectDirectorList", qp)
+	ret0, _ := ret[0].([]core.Director)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InsertAccount indicates an expected call of InsertAccount.
-func (mr *MockAccountStorageMockRecorder) InsertAccount(account interface{}) *gomock.Call {
+// SelectDirectorList indicates an expected call of SelectDirectorList.
+func (mr *MockDirectorStorageMockRecorder) SelectDirectorList(qp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccount", reflect.TypeOf((*MockAccountStorage)(nil).InsertAccount), account)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDirectorList", reflect.TypeOf((*MockDirectorStorage)(nil).SelectDirectorList), qp)
 }
 
-// InsertSession mocks base method.
-func (m *MockAccountStorage) InsertSession(session core.Session) (core.Session, error) {
+// SelectDirectorMovies mocks base method.
+func (m *MockDirectorStorage) SelectDirectorMovies(directorID string, qp core.ConditionParams) ([]core.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertSession", session)
-	ret0, _ := ret[0].(core.Session)
+	ret := m.ctrl.Call(m, "SelectDirectorMovies", directorID, qp)
+	ret0, _ := ret[0].([]core.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InsertSession indicates an expected call of InsertSession.
-func (mr *MockAccountStorageMockRecorder) InsertSession(session interface{}) *gomock.Call {
+// SelectDirectorMovies indicates an expected call of SelectDirectorMovies.
+func (mr *MockDirectorStorageMockRecorder) SelectDirectorMovies(directorID, qp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockAccountStorage)(nil).InsertSession), session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDirectorMovies", reflect.TypeOf((*MockDirectorStorage)(nil).SelectDirectorMovies), directorID, qp)
 }
 
-// RefreshSession mocks base method.
-func (m *MockAccountStorage) RefreshSession(session core.Session) error {
+// UpdateDirector mocks base method.
+func (m *MockDirectorStorage) UpdateDirector(director core.Director) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshSession", session)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateDirector", director)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RefreshSession indicates an expected call of RefreshSession.
-func (mr *MockAccountStorageMockRecorder) RefreshSession(session interface{}) *gomock.Call {
+// UpdateDirector indicates an expected call of UpdateDirector.
+func (mr *MockDirectorStorageMockRecorder) UpdateDirector(director interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockAccountStorage)(nil).RefreshSession), session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDirector", reflect.TypeOf((*MockDirectorStorage)(nil).UpdateDirector), director)
 }
 
-// SelectAccountByID mocks base method.
-func (m *MockAccountStorage) SelectAccountByID(accountID string) (core.Account, error) {
+// UpdateDirectorPhoto mocks base method.
+func (m *MockDirectorStorage) UpdateDirectorPhoto(directorID, photoURL string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectAccountByID", accountID)
-	ret0, _ := ret[0].(core.Account)
+	ret := m.ctrl.Call(m, "UpdateDirectorPhoto", directorID, photoURL)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectAccountByID indicates an expected call of SelectAccountByID.
-func (mr *MockAccountStorageMockRecorder) SelectAccountByID(accountID interface{}) *gomock.Call {
+// UpdateDirectorPhoto indicates an expected call of UpdateDirectorPhoto.
+func (mr *MockDirectorStorageMockRecorder) UpdateDirectorPhoto(directorID, photoURL interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountByID", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountByID), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDirectorPhoto", reflect.TypeOf((*MockDirectorStorage)(nil).UpdateDirectorPhoto), directorID, photoURL)
 }
 
-// SelectAccountByPhone mocks base method.
-func (m *MockAccountStorage) SelectAccountByPhone(phone string) (core.Account, error) {
+// MockPasswordHasher is a mock of PasswordHasher interface.
+type MockPasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHasherMockRecorder
+}
+
+// MockPasswordHasherMockRecorder is the mock recorder for MockPasswordHasher.
+type MockPasswordHasherMockRecorder struct {
+	mock *MockPasswordHasher
+}
+
+// NewMockPasswordHasher creates a new mock instance.
+func NewMockPasswordHasher(ctrl *gomock.Controller) *MockPasswordHasher {
+	mock := &MockPasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockPasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHasher) EXPECT() *MockPasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectAccountByPhone", phone)
-	ret0, _ := ret[0].(core.Account)
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectAccountByPhone indicates an expected call of SelectAccountByPhone.
-func (mr *MockAccountStorageMockRecorder) SelectAccountByPhone(phone interface{}) *gomock.Call {
+// Hash indicates an expected call of Hash.
+func (mr *MockPasswordHasherMockRecorder) Hash(password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAccountByPhone", reflect.TypeOf((*MockAccountStorage)(nil).SelectAccountByPhone), phone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordHasher)(nil).Hash), password)
 }
 
-// SelectSession mocks base method.
-func (m *MockAccountStorage) SelectSession(session core.Session) (core.Session, error) {
+// Verify mocks base method.
+func (m *MockPasswordHasher) Verify(password, encoded string) (bool, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectSession", session)
-	ret0, _ := ret[0].(core.Session)
+	ret := m.ctrl.Call(m, "Verify", password, encoded)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockPasswordHasherMockRecorder) Verify(password, encoded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockPasswordHasher)(nil).Verify), password, encoded)
+}
+
+// MockTokenKeys is a mock of TokenKeys interface.
+type MockTokenKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenKeysMockRecorder
+}
+
+// MockTokenKeysMockRecorder is the mock recorder for MockTokenKeys.
+type MockTokenKeysMockRecorder struct {
+	mock *MockTokenKeys
+}
+
+// NewMockTokenKeys creates a new mock instance.
+func NewMockTokenKeys(ctrl *gomock.Controller) *MockTokenKeys {
+	mock := &MockTokenKeys{ctrl: ctrl}
+	mock.recorder = &MockTokenKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenKeys) EXPECT() *MockTokenKeysMockRecorder {
+	return m.recorder
+}
+
+// JWKS mocks base method.
+func (m *MockTokenKeys) JWKS() core.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(core.JSONWebKeySet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockTokenKeysMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockTokenKeys)(nil).JWKS))
+}
+
+// Sign mocks base method.
+func (m *MockTokenKeys) Sign(claims jwt.Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectSession indicates an expected call of SelectSession.
-func (mr *MockAccountStorageMockRecorder) SelectSession(session interface{}) *gomock.Call {
+// Sign indicates an expected call of Sign.
+func (mr *MockTokenKeysMockRecorder) Sign(claims interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSession", reflect.TypeOf((*MockAccountStorage)(nil).SelectSession), session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockTokenKeys)(nil).Sign), claims)
 }
 
-// MockDirectorStorage is a mock of DirectorStorage interface.
-type MockDirectorStorage struct {
+// Verify mocks base method.
+func (m *MockTokenKeys) Verify(token *jwt.Token) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTokenKeysMockRecorder) Verify(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTokenKeys)(nil).Verify), token)
+}
+
+// MockSMSSender is a mock of SMSSender interface.
+type MockSMSSender struct {
 	ctrl     *gomock.Controller
-	recorder *MockDirectorStorageMockRecorder
+	recorder *MockSMSSenderMockRecorder
 }
 
-// MockDirectorStorageMockRecorder is the mock recorder for MockDirectorStorage.
-type MockDirectorStorageMockRecorder struct {
-	mock *MockDirectorStorage
+// MockSMSSenderMockRecorder is the mock recorder for MockSMSSender.
+type MockSMSSenderMockRecorder struct {
+	mock *MockSMSSender
 }
 
-// NewMockDirectorStorage creates a new mock instance.
-func NewMockDirectorStorage(ctrl *gomock.Controller) *MockDirectorStorage {
-	mock := &MockDirectorStorage{ctrl: ctrl}
-	mock.recorder = &MockDirectorStorageMockRecorder{mock}
+// NewMockSMSSender creates a new mock instance.
+func NewMockSMSSender(ctrl *gomock.Controller) *MockSMSSender {
+	mock := &MockSMSSender{ctrl: ctrl}
+	mock.recorder = &MockSMSSenderMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockDirectorStorage) EXPECT() *MockDirectorStorageMockRecorder {
+func (m *MockSMSSender) EXPECT() *MockSMSSenderMockRecorder {
 	return m.recorder
 }
 
-// InsertDirector mocks base method.
-func (m *MockDirectorStorage) InsertDirector(director core.Director) error {
+// Send mocks base method.
+func (m *MockSMSSender) Send(phone, text string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertDirector", director)
+	ret := m.ctrl.Call(m, "Send", phone, text)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertDirector indicates an expected call of InsertDirector.
-func (mr *MockDirectorStorageMockRecorder) InsertDirector(director interface{}) *gomock.Call {
+// Send indicates an expected call of Send.
+func (mr *MockSMSSenderMockRecorder) Send(phone, text interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDirector", reflect.TypeOf((*MockDirectorStorage)(nil).InsertDirector), director)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSMSSender)(nil).Send), phone, text)
 }
 
-// SelectDirectorByID mocks base method.
-func (m *MockDirectorStorage) SelectDirectorByID(directorID string) (core.Director, error) {
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(url string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectDirectorByID", directorID)
-	ret0, _ := ret[0].(core.Director)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Delete", url)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SelectDirectorByID indicates an expected call of SelectDirectorByID.
-func (mr *MockDirectorStorageMockRecorder) SelectDirectorByID(directorID interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(url interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDirectorByID", reflect.TypeOf((*MockDirectorStorage)(nil).SelectDirectorByID), directorID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), url)
 }
 
-// SelectDirectorList mocks base method.
-func (m *MockDirectorStorage) SelectDirectorList() ([]core.Director, error) {
+// Put mocks base method.
+func (m *MockBlobStore) Put(key, contentType string, body io.Reader, size int64) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectDirectorList")
-	ret0, _ := ret[0].([]core.Director)
+	ret := m.ctrl.Call(m, "Put", key, contentType, body, size)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectDirectorList indicates an expected call of SelectDirectorList.
-func (mr *MockDirectorStorageMockRecorder) SelectDirectorList() *gomock.Call {
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(key, contentType, body, size interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDirectorList", reflect.TypeOf((*MockDirectorStorage)(nil).SelectDirectorList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), key, contentType, body, size)
 }
 
 // MockMovieStorage is a mock of MovieStorage interface.
@@ -227,6 +1112,21 @@ func (m *MockMovieStorage) EXPECT() *MockMovieStorageMockRecorder {
 	return m.recorder
 }
 
+// DeleteMovie mocks base method.
+func (m *MockMovieStorage) DeleteMovie(movieID string, cascade bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovie", movieID, cascade)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMovie indicates an expected call of DeleteMovie.
+func (mr *MockMovieStorageMockRecorder) DeleteMovie(movieID, cascade interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieStorage)(nil).DeleteMovie), movieID, cascade)
+}
+
 // InsertMovie mocks base method.
 func (m *MockMovieStorage) InsertMovie(movie core.Movie) error {
 	m.ctrl.T.Helper()
@@ -286,6 +1186,21 @@ func (mr *MockMovieStorageMockRecorder) SelectMoviesCSV(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectMoviesCSV", reflect.TypeOf((*MockMovieStorage)(nil).SelectMoviesCSV), arg0)
 }
 
+// UpdateMovie mocks base method.
+func (m *MockMovieStorage) UpdateMovie(movie core.Movie) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovie", movie)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMovie indicates an expected call of UpdateMovie.
+func (mr *MockMovieStorageMockRecorder) UpdateMovie(movie interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieStorage)(nil).UpdateMovie), movie)
+}
+
 // MockListSorage is a mock of ListSorage interface.
 type MockListSorage struct {
 	ctrl     *gomock.Controller
@@ -309,6 +1224,48 @@ func (m *MockListSorage) EXPECT() *MockListSorageMockRecorder {
 	return m.recorder
 }
 
+// DeleteList mocks base method.
+func (m *MockListSorage) DeleteList(listID, accountID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", listID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockListSorageMockRecorder) DeleteList(listID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockListSorage)(nil).DeleteList), listID, accountID)
+}
+
+// DeleteMovieFromList mocks base method.
+func (m *MockListSorage) DeleteMovieFromList(listID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovieFromList", listID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovieFromList indicates an expected call of DeleteMovieFromList.
+func (mr *MockListSorageMockRecorder) DeleteMovieFromList(listID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovieFromList", reflect.TypeOf((*MockListSorage)(nil).DeleteMovieFromList), listID, movieID)
+}
+
+// DeleteMovieFromSystemList mocks base method.
+func (m *MockListSorage) DeleteMovieFromSystemList(accountID string, listType core.ListType, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovieFromSystemList", accountID, listType, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovieFromSystemList indicates an expected call of DeleteMovieFromSystemList.
+func (mr *MockListSorageMockRecorder) DeleteMovieFromSystemList(accountID, listType, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovieFromSystemList", reflect.TypeOf((*MockListSorage)(nil).DeleteMovieFromSystemList), accountID, listType, movieID)
+}
+
 // Insert mocks base method.
 func (m *MockListSorage) Insert(arg0 core.MovieList) (string, error) {
 	m.ctrl.T.Helper()
@@ -338,6 +1295,20 @@ func (mr *MockListSorageMockRecorder) InsertMovieToList(moviID, listID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMovieToList", reflect.TypeOf((*MockListSorage)(nil).InsertMovieToList), moviID, listID)
 }
 
+// InsertMovieToSystemList mocks base method.
+func (m *MockListSorage) InsertMovieToSystemList(accountID string, listType core.ListType, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMovieToSystemList", accountID, listType, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertMovieToSystemList indicates an expected call of InsertMovieToSystemList.
+func (mr *MockListSorageMockRecorder) InsertMovieToSystemList(accountID, listType, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMovieToSystemList", reflect.TypeOf((*MockListSorage)(nil).InsertMovieToSystemList), accountID, listType, movieID)
+}
+
 // SelectAllUsersLists mocks base method.
 func (m *MockListSorage) SelectAllUsersLists(arg0 []core.QuerySliceElement) ([]core.MovieList, error) {
 	m.ctrl.T.Helper()
@@ -352,3 +1323,115 @@ func (mr *MockListSorageMockRecorder) SelectAllUsersLists(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllUsersLists", reflect.TypeOf((*MockListSorage)(nil).SelectAllUsersLists), arg0)
 }
+
+// SelectListByID mocks base method.
+func (m *MockListSorage) SelectListByID(listID, accountID string) (core.MovieList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectListByID", listID, accountID)
+	ret0, _ := ret[0].(core.MovieList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectListByID indicates an expected call of SelectListByID.
+func (mr *MockListSorageMockRecorder) SelectListByID(listID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectListByID", reflect.TypeOf((*MockListSorage)(nil).SelectListByID), listID, accountID)
+}
+
+// SelectListMovies mocks base method.
+func (m *MockListSorage) SelectListMovies(listID string, qp core.ConditionParams) ([]core.ListMovie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectListMovies", listID, qp)
+	ret0, _ := ret[0].([]core.ListMovie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectListMovies indicates an expected call of SelectListMovies.
+func (mr *MockListSorageMockRecorder) SelectListMovies(listID, qp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectListMovies", reflect.TypeOf((*MockListSorage)(nil).SelectListMovies), listID, qp)
+}
+
+// UpdateListType mocks base method.
+func (m *MockListSorage) UpdateListType(listID, accountID, listType string) (core.MovieList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateListType", listID, accountID, listType)
+	ret0, _ := ret[0].(core.MovieList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateListType indicates an expected call of UpdateListType.
+func (mr *MockListSorageMockRecorder) UpdateListType(listID, accountID, listType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateListType", reflect.TypeOf((*MockListSorage)(nil).UpdateListType), listID, accountID, listType)
+}
+
+// MockRatingStorage is a mock of RatingStorage interface.
+type MockRatingStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingStorageMockRecorder
+}
+
+// MockRatingStorageMockRecorder is the mock recorder for MockRatingStorage.
+type MockRatingStorageMockRecorder struct {
+	mock *MockRatingStorage
+}
+
+// NewMockRatingStorage creates a new mock instance.
+func NewMockRatingStorage(ctrl *gomock.Controller) *MockRatingStorage {
+	mock := &MockRatingStorage{ctrl: ctrl}
+	mock.recorder = &MockRatingStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingStorage) EXPECT() *MockRatingStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteRating mocks base method.
+func (m *MockRatingStorage) DeleteRating(accountID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRating", accountID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRating indicates an expected call of DeleteRating.
+func (mr *MockRatingStorageMockRecorder) DeleteRating(accountID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRating", reflect.TypeOf((*MockRatingStorage)(nil).DeleteRating), accountID, movieID)
+}
+
+// SelectRating mocks base method.
+func (m *MockRatingStorage) SelectRating(accountID, movieID string) (core.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRating", accountID, movieID)
+	ret0, _ := ret[0].(core.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRating indicates an expected call of SelectRating.
+func (mr *MockRatingStorageMockRecorder) SelectRating(accountID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRating", reflect.TypeOf((*MockRatingStorage)(nil).SelectRating), accountID, movieID)
+}
+
+// UpsertRating mocks base method.
+func (m *MockRatingStorage) UpsertRating(rating core.Rating) (core.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRating", rating)
+	ret0, _ := ret[0].(core.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRating indicates an expected call of UpsertRating.
+func (mr *MockRatingStorageMockRecorder) UpsertRating(rating interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRating", reflect.TypeOf((*MockRatingStorage)(nil).UpsertRating), rating)
+}
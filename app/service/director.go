@@ -4,14 +4,16 @@ import (
 	"fmt"
 
 	"github.com/Brigant/PetPorject/app/core"
+	"github.com/google/uuid"
 )
 
 type DirectorService struct {
 	storage DirectorStorage
+	blobs   BlobStore
 }
 
-func NewDirectorService(storage DirectorStorage) DirectorService {
-	return DirectorService{storage: storage}
+func NewDirectorService(storage DirectorStorage, blobs BlobStore) DirectorService {
+	return DirectorService{storage: storage, blobs: blobs}
 }
 
 // The sirvice with logic of creatinf of the director.
@@ -33,12 +35,85 @@ func (d DirectorService) GetDirectorWithID(directorID string) (core.Director, er
 	return director, nil
 }
 
-// The service returns the slice of the directors.
-func (d DirectorService) GetDirectorList() ([]core.Director, error) {
-	directorsList, err := d.storage.SelectDirectorList()
+// The service returns the slice of the directors which satisfy the conditions.
+func (d DirectorService) GetDirectorList(qp core.ConditionParams) ([]core.Director, error) {
+	directorsList, err := d.storage.SelectDirectorList(qp)
 	if err != nil {
 		return nil, fmt.Errorf("SelectDirectorList returned the error: %w", err)
 	}
 
 	return directorsList, nil
 }
+
+// The service returns the page of the director movies which satisfy the conditions.
+func (d DirectorService) GetDirectorMovies(directorID string, qp core.ConditionParams) ([]core.Movie, error) {
+	movieList, err := d.storage.SelectDirectorMovies(directorID, qp)
+	if err != nil {
+		return nil, fmt.Errorf("service get an error while SelectDirectorMovies: %w", err)
+	}
+
+	return movieList, nil
+}
+
+// The service with logic of the director changing. Returns the new modified timestamp.
+// The non-empty Modified field of the director makes the update conditional.
+func (d DirectorService) UpdateDirector(director core.Director) (string, error) {
+	modified, err := d.storage.UpdateDirector(director)
+	if err != nil {
+		return "", fmt.Errorf("service get an error while UpdateDirector: %w", err)
+	}
+
+	return modified, nil
+}
+
+// The service with logic of the director deletion. The photo of the deleted director is removed too.
+func (d DirectorService) DeleteDirector(directorID string) error {
+	photoURL, err := d.storage.DeleteDirector(directorID)
+	if err != nil {
+		return fmt.Errorf("service get an error while DeleteDirector: %w", err)
+	}
+
+	d.removePhoto(photoURL)
+
+	return nil
+}
+
+// The service uploads the new photo of the director to the blob store, links it to the director
+// and removes the replaced one. Returns the url of the new photo.
+func (d DirectorService) UploadDirectorPhoto(directorID string, photo core.Photo) (string, error) {
+	if err := photo.Validate(); err != nil {
+		return "", fmt.Errorf("service get an error while validating the photo: %w", err)
+	}
+
+	if _, err := d.storage.SelectDirectorByID(directorID); err != nil {
+		return "", fmt.Errorf("service get an error while SelectDirectorByID: %w", err)
+	}
+
+	key := "director/" + directorID + "/" + uuid.New().String() + core.PhotoExtensions[photo.ContentType]
+
+	photoURL, err := d.blobs.Put(key, photo.ContentType, photo.Body, photo.Size)
+	if err != nil {
+		return "", fmt.Errorf("service get an error while uploading the photo: %w", err)
+	}
+
+	previousURL, err := d.storage.UpdateDirectorPhoto(directorID, photoURL)
+	if err != nil {
+		d.removePhoto(photoURL)
+
+		return "", fmt.Errorf("service get an error while UpdateDirectorPhoto: %w", err)
+	}
+
+	d.removePhoto(previousURL)
+
+	return photoURL, nil
+}
+
+// Removes the photo from the blob store. The failure is not reported,
+// because the orphaned object only takes the space and the main operation has already succeeded.
+func (d DirectorService) removePhoto(photoURL string) {
+	if photoURL == "" {
+		return
+	}
+
+	d.blobs.Delete(photoURL) //nolint:errcheck
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -146,7 +148,7 @@ func TestGetDirectorList(t *testing.T) {
 	}{
 		"Successful": {
 			mockBehavior: func(s *MockDirectorStorage) {
-				s.EXPECT().SelectDirectorList().Return([]core.Director{
+				s.EXPECT().SelectDirectorList(gomock.Any()).Return([]core.Director{
 					{ID: "1"},
 					{ID: "2"},
 				}, nil)
@@ -159,7 +161,7 @@ func TestGetDirectorList(t *testing.T) {
 		},
 		"Error": {
 			mockBehavior: func(s *MockDirectorStorage) {
-				s.EXPECT().SelectDirectorList().Return(nil,
+				s.EXPECT().SelectDirectorList(gomock.Any()).Return(nil,
 					errors.New("some error"))
 			},
 			expectedList:         nil,
@@ -181,7 +183,7 @@ func TestGetDirectorList(t *testing.T) {
 				storage: DirectorStorage,
 			}
 
-			actualList, err := ds.GetDirectorList()
+			actualList, err := ds.GetDirectorList(core.ConditionParams{Limit: "20", Offset: "0"})
 			if testCase.wantError {
 				assert.Equal(t, testCase.expectedList, actualList, "The director list should be nil")
 				assert.EqualError(t, err, testCase.expectedErrorMessage,
@@ -193,3 +195,276 @@ func TestGetDirectorList(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDirectorMovies(t *testing.T) {
+	type mockBehavior func(s *MockDirectorStorage, directorID string, qp core.ConditionParams)
+
+	qp := core.ConditionParams{
+		Limit:  "20",
+		Offset: "0",
+		Sort:   []core.QuerySliceElement{{Key: "release_date", Val: "desc"}},
+	}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedList         []core.Movie
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			mockBehavior: func(s *MockDirectorStorage, directorID string, qp core.ConditionParams) {
+				s.EXPECT().SelectDirectorMovies(directorID, qp).Return([]core.Movie{
+					{ID: "1", DirectorID: directorID},
+				}, nil)
+			},
+			expectedList: []core.Movie{{ID: "1", DirectorID: "director-id"}},
+			wantError:    false,
+		},
+		"Director not found": {
+			mockBehavior: func(s *MockDirectorStorage, directorID string, qp core.ConditionParams) {
+				s.EXPECT().SelectDirectorMovies(directorID, qp).Return(nil, core.ErrNowDirectorFound)
+			},
+			expectedErrorMessage: "service get an error while SelectDirectorMovies: no director found",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			DirectorStorage := NewMockDirectorStorage(ctrl)
+			testCase.mockBehavior(DirectorStorage, "director-id", qp)
+
+			ds := DirectorService{
+				storage: DirectorStorage,
+			}
+
+			actualList, err := ds.GetDirectorMovies("director-id", qp)
+			if testCase.wantError {
+				assert.Nil(t, actualList)
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.Equal(t, testCase.expectedList, actualList)
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
+
+func TestUpdateDirector(t *testing.T) {
+	type mockBehavior func(s *MockDirectorStorage, director core.Director)
+
+	testCasesTable := map[string]struct {
+		director             core.Director
+		mockBehavior         mockBehavior
+		expectedModified     string
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			director: core.Director{ID: "1", Name: "James Cameron"},
+			mockBehavior: func(s *MockDirectorStorage, director core.Director) {
+				s.EXPECT().UpdateDirector(director).Return("2023-04-01T10:00:00Z", nil)
+			},
+			expectedModified: "2023-04-01T10:00:00Z",
+			wantError:        false,
+		},
+		"Duplicate": {
+			director: core.Director{ID: "1", Name: "James Cameron"},
+			mockBehavior: func(s *MockDirectorStorage, director core.Director) {
+				s.EXPECT().UpdateDirector(director).Return("", core.ErrDublicatDirector)
+			},
+			expectedErrorMessage: "service get an error while UpdateDirector: there is the director with such data",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			// Init Deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			DirectorStorage := NewMockDirectorStorage(ctrl)
+			testCase.mockBehavior(DirectorStorage, testCase.director)
+
+			ds := DirectorService{
+				storage: DirectorStorage,
+			}
+
+			modified, err := ds.UpdateDirector(testCase.director)
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.Equal(t, testCase.expectedModified, modified)
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
+
+func TestDeleteDirector(t *testing.T) {
+	type mockBehavior func(s *MockDirectorStorage, b *MockBlobStore, directorID string)
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().DeleteDirector(directorID).Return("", nil)
+			},
+			wantError: false,
+		},
+		"The photo is removed": {
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().DeleteDirector(directorID).Return("/photos/director/old.jpg", nil)
+				b.EXPECT().Delete("/photos/director/old.jpg").Return(nil)
+			},
+			wantError: false,
+		},
+		"The failed photo removing is not reported": {
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().DeleteDirector(directorID).Return("/photos/director/old.jpg", nil)
+				b.EXPECT().Delete("/photos/director/old.jpg").Return(errors.New("storage is unavailable"))
+			},
+			wantError: false,
+		},
+		"Director has movies": {
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().DeleteDirector(directorID).Return("", core.ErrDirectorHasMovie)
+			},
+			expectedErrorMessage: "service get an error while DeleteDirector: the director still has movies",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			// Init Deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			DirectorStorage := NewMockDirectorStorage(ctrl)
+			BlobStore := NewMockBlobStore(ctrl)
+			testCase.mockBehavior(DirectorStorage, BlobStore, "director-id")
+
+			ds := NewDirectorService(DirectorStorage, BlobStore)
+
+			err := ds.DeleteDirector("director-id")
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
+
+func TestUploadDirectorPhoto(t *testing.T) {
+	type mockBehavior func(s *MockDirectorStorage, b *MockBlobStore, directorID string)
+
+	const newURL = "/photos/director/director-id/new.png"
+
+	keyMatcher := gomock.AssignableToTypeOf("")
+
+	testCasesTable := map[string]struct {
+		photo                core.Photo
+		mockBehavior         mockBehavior
+		expectedURL          string
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"The first photo": {
+			photo: core.Photo{ContentType: "image/png", Size: 8, Body: strings.NewReader("png-data")},
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().SelectDirectorByID(directorID).Return(core.Director{ID: directorID}, nil)
+				b.EXPECT().Put(keyMatcher, "image/png", gomock.Any(), int64(8)).DoAndReturn(
+					func(key, contentType string, body io.Reader, size int64) (string, error) {
+						assert.True(t, strings.HasPrefix(key, "director/director-id/"))
+						assert.True(t, strings.HasSuffix(key, ".png"))
+
+						return newURL, nil
+					})
+				s.EXPECT().UpdateDirectorPhoto(directorID, newURL).Return("", nil)
+			},
+			expectedURL: newURL,
+			wantError:   false,
+		},
+		"The replaced photo is removed": {
+			photo: core.Photo{ContentType: "image/png", Size: 8, Body: strings.NewReader("png-data")},
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().SelectDirectorByID(directorID).Return(core.Director{ID: directorID}, nil)
+				b.EXPECT().Put(keyMatcher, "image/png", gomock.Any(), int64(8)).Return(newURL, nil)
+				s.EXPECT().UpdateDirectorPhoto(directorID, newURL).Return("/photos/director/old.jpg", nil)
+				b.EXPECT().Delete("/photos/director/old.jpg").Return(nil)
+			},
+			expectedURL: newURL,
+			wantError:   false,
+		},
+		"Unsupported content type": {
+			photo:                core.Photo{ContentType: "image/gif", Size: 8, Body: strings.NewReader("gif-data")},
+			mockBehavior:         func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {},
+			expectedErrorMessage: "service get an error while validating the photo: the photo should be jpeg, png or webp image",
+			wantError:            true,
+		},
+		"Too large photo": {
+			photo:                core.Photo{ContentType: "image/jpeg", Size: core.MaxPhotoSize + 1},
+			mockBehavior:         func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {},
+			expectedErrorMessage: "service get an error while validating the photo: the photo should not be larger than 5 MB",
+			wantError:            true,
+		},
+		"Director not found": {
+			photo: core.Photo{ContentType: "image/png", Size: 8, Body: strings.NewReader("png-data")},
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().SelectDirectorByID(directorID).Return(core.Director{}, core.ErrNowDirectorFound)
+			},
+			expectedErrorMessage: "service get an error while SelectDirectorByID: no director found",
+			wantError:            true,
+		},
+		"The uploaded photo is removed if the director is not updated": {
+			photo: core.Photo{ContentType: "image/png", Size: 8, Body: strings.NewReader("png-data")},
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().SelectDirectorByID(directorID).Return(core.Director{ID: directorID}, nil)
+				b.EXPECT().Put(keyMatcher, "image/png", gomock.Any(), int64(8)).Return(newURL, nil)
+				s.EXPECT().UpdateDirectorPhoto(directorID, newURL).Return("", core.ErrNowDirectorFound)
+				b.EXPECT().Delete(newURL).Return(nil)
+			},
+			expectedErrorMessage: "service get an error while UpdateDirectorPhoto: no director found",
+			wantError:            true,
+		},
+		"Blob store error": {
+			photo: core.Photo{ContentType: "image/png", Size: 8, Body: strings.NewReader("png-data")},
+			mockBehavior: func(s *MockDirectorStorage, b *MockBlobStore, directorID string) {
+				s.EXPECT().SelectDirectorByID(directorID).Return(core.Director{ID: directorID}, nil)
+				b.EXPECT().Put(keyMatcher, "image/png", gomock.Any(), int64(8)).Return("", errors.New("storage is unavailable"))
+			},
+			expectedErrorMessage: "service get an error while uploading the photo: storage is unavailable",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			DirectorStorage := NewMockDirectorStorage(ctrl)
+			BlobStore := NewMockBlobStore(ctrl)
+			testCase.mockBehavior(DirectorStorage, BlobStore, "director-id")
+
+			ds := NewDirectorService(DirectorStorage, BlobStore)
+
+			photoURL, err := ds.UploadDirectorPhoto("director-id", testCase.photo)
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.Equal(t, testCase.expectedURL, photoURL)
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
@@ -32,10 +32,114 @@ func (s ListService) GetAllAccountLists(condtitions []core.QuerySliceElement) ([
 	return movieLists, nil
 }
 
-func (s ListService) AddMovieToList(listID, movieID string) error {
+// Adds the movie to the list, the list should belong to the account.
+func (s ListService) AddMovieToList(accountID, listID, movieID string) error {
+	if err := s.authorize(accountID, listID); err != nil {
+		return err
+	}
+
 	if err := s.storage.InsertMovieToList(listID, movieID); err != nil {
 		return fmt.Errorf("service add movie to list got error: %w", err)
 	}
 
 	return nil
 }
+
+// Returns the list of the account with the page of its movies.
+func (s ListService) GetListWithMovies(listID, accountID string, qp core.ConditionParams) (core.MovieListDetails, error) {
+	list, err := s.storage.SelectListByID(listID, accountID)
+	if err != nil {
+		return core.MovieListDetails{}, fmt.Errorf("service get list got the error: %w", err)
+	}
+
+	// The list is selected by the account, so the movies are read only after the ownership is proved.
+	movies, err := s.storage.SelectListMovies(listID, qp)
+	if err != nil {
+		return core.MovieListDetails{}, fmt.Errorf("service get list movies got the error: %w", err)
+	}
+
+	return core.MovieListDetails{MovieList: list, Movies: movies}, nil
+}
+
+// Removes the movie from the list, the list should belong to the account.
+func (s ListService) RemoveMovieFromList(accountID, listID, movieID string) error {
+	if err := s.authorize(accountID, listID); err != nil {
+		return err
+	}
+
+	if err := s.storage.DeleteMovieFromList(listID, movieID); err != nil {
+		return fmt.Errorf("service remove movie from list got error: %w", err)
+	}
+
+	return nil
+}
+
+// Deletes the list of the account with all its movies. The system list can't be deleted.
+func (s ListService) Delete(accountID, listID string) error {
+	if err := s.checkCustomList(accountID, listID); err != nil {
+		return err
+	}
+
+	if err := s.storage.DeleteList(listID, accountID); err != nil {
+		return fmt.Errorf("service delete list got error: %w", err)
+	}
+
+	return nil
+}
+
+// Changes the type of the list of the account and returns the updated list.
+// The system list can't be renamed.
+func (s ListService) Rename(accountID, listID, listType string) (core.MovieList, error) {
+	if err := s.checkCustomList(accountID, listID); err != nil {
+		return core.MovieList{}, err
+	}
+
+	list, err := s.storage.UpdateListType(listID, accountID, listType)
+	if err != nil {
+		return core.MovieList{}, fmt.Errorf("service rename list got error: %w", err)
+	}
+
+	return list, nil
+}
+
+// Adds the movie to the system list of the account, e.g. to the favorites.
+func (s ListService) AddMovieToSystemList(accountID string, listType core.ListType, movieID string) error {
+	if err := s.storage.InsertMovieToSystemList(accountID, listType, movieID); err != nil {
+		return fmt.Errorf("service add movie to %s list got error: %w", listType, err)
+	}
+
+	return nil
+}
+
+// Removes the movie from the system list of the account.
+func (s ListService) RemoveMovieFromSystemList(accountID string, listType core.ListType, movieID string) error {
+	if err := s.storage.DeleteMovieFromSystemList(accountID, listType, movieID); err != nil {
+		return fmt.Errorf("service remove movie from %s list got error: %w", listType, err)
+	}
+
+	return nil
+}
+
+// Checks that the list belongs to the account. The list of another account is reported
+// as not found, so the caller can't learn whether the list exists.
+func (s ListService) authorize(accountID, listID string) error {
+	if _, err := s.storage.SelectListByID(listID, accountID); err != nil {
+		return fmt.Errorf("service authorize list got the error: %w", err)
+	}
+
+	return nil
+}
+
+// Checks that the list belongs to the account and it is not the system one.
+func (s ListService) checkCustomList(accountID, listID string) error {
+	list, err := s.storage.SelectListByID(listID, accountID)
+	if err != nil {
+		return fmt.Errorf("service authorize list got the error: %w", err)
+	}
+
+	if core.IsSystemListType(list.Type) {
+		return core.ErrSystemList
+	}
+
+	return nil
+}
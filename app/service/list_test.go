@@ -119,28 +119,45 @@ func TestListService_GetAllAccountLists(t *testing.T) {
 }
 
 func TestListService_AddMovieToList(t *testing.T) {
-	type mockBehavior func(s *MockListSorage, listID, movieID string)
+	type mockBehavior func(s *MockListSorage, accountID, listID, movieID string)
 
 	testCasesTable := map[string]struct {
+		accountID            string
 		listID               string
 		movieID              string
 		mockBehavior         mockBehavior
 		expectedErrorMessage string
 		wantError            bool
+		wantErr              error
 	}{
 		"Successful": {
-			listID:  "listID-111",
-			movieID: "movieID-222",
-			mockBehavior: func(s *MockListSorage, listID, movieID string) {
+			accountID: "accountID-000",
+			listID:    "listID-111",
+			movieID:   "movieID-222",
+			mockBehavior: func(s *MockListSorage, accountID, listID, movieID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, nil).Times(1)
 				s.EXPECT().InsertMovieToList(listID, movieID).Return(nil).Times(1)
 			},
 			expectedErrorMessage: "",
 			wantError:            false,
 		},
+		"The list of another account": {
+			accountID: "accountID-333",
+			listID:    "listID-111",
+			movieID:   "movieID-222",
+			mockBehavior: func(s *MockListSorage, accountID, listID, movieID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, core.ErrListNotFound).Times(1)
+			},
+			expectedErrorMessage: "service authorize list got the error: no list found",
+			wantError:            true,
+			wantErr:              core.ErrListNotFound,
+		},
 		"Want error": {
-			listID:  "listID-111",
-			movieID: "movieID-222",
-			mockBehavior: func(s *MockListSorage, listID, movieID string) {
+			accountID: "accountID-000",
+			listID:    "listID-111",
+			movieID:   "movieID-222",
+			mockBehavior: func(s *MockListSorage, accountID, listID, movieID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, nil).Times(1)
 				s.EXPECT().InsertMovieToList(listID, movieID).Return(
 					errors.New("some error")).Times(1)
 			},
@@ -156,17 +173,334 @@ func TestListService_AddMovieToList(t *testing.T) {
 			defer ctrl.Finish()
 
 			listStorage := NewMockListSorage(ctrl)
-			testCase.mockBehavior(listStorage, testCase.listID, testCase.movieID)
+			testCase.mockBehavior(listStorage, testCase.accountID, testCase.listID, testCase.movieID)
 
 			ls := ListService{
 				storage: listStorage,
 			}
 
-			err := ls.AddMovieToList(testCase.listID, testCase.movieID)
+			err := ls.AddMovieToList(testCase.accountID, testCase.listID, testCase.movieID)
 
 			if testCase.wantError {
 				assert.EqualError(t, err, testCase.expectedErrorMessage,
 					"We want get an error beceause the storage returned the error")
+
+				if testCase.wantErr != nil {
+					assert.ErrorIs(t, err, testCase.wantErr)
+				}
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
+
+func TestListService_GetListWithMovies(t *testing.T) {
+	type mockBehavior func(s *MockListSorage, listID, accountID string, qp core.ConditionParams)
+
+	qp := core.ConditionParams{Limit: "20", Offset: "0"}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedList         core.MovieListDetails
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			mockBehavior: func(s *MockListSorage, listID, accountID string, qp core.ConditionParams) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{Type: "favorite"}, nil).Times(1)
+				s.EXPECT().SelectListMovies(listID, qp).Return([]core.ListMovie{
+					{Movie: core.Movie{ID: "movie-id"}, DirectorName: "James Cameron"},
+				}, nil).Times(1)
+			},
+			expectedList: core.MovieListDetails{
+				MovieList: core.MovieList{Type: "favorite"},
+				Movies:    []core.ListMovie{{Movie: core.Movie{ID: "movie-id"}, DirectorName: "James Cameron"}},
+			},
+			wantError: false,
+		},
+		"The list of another account": {
+			mockBehavior: func(s *MockListSorage, listID, accountID string, qp core.ConditionParams) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, core.ErrListNotFound).Times(1)
+			},
+			expectedErrorMessage: "service get list got the error: no list found",
+			wantError:            true,
+		},
+		"Movies error": {
+			mockBehavior: func(s *MockListSorage, listID, accountID string, qp core.ConditionParams) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, nil).Times(1)
+				s.EXPECT().SelectListMovies(listID, qp).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedErrorMessage: "service get list movies got the error: some error",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			listStorage := NewMockListSorage(ctrl)
+			testCase.mockBehavior(listStorage, "list-id", "account-id", qp)
+
+			ls := ListService{
+				storage: listStorage,
+			}
+
+			list, err := ls.GetListWithMovies("list-id", "account-id", qp)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+				assert.Equal(t, testCase.expectedList, list)
+			}
+		})
+	}
+}
+
+func TestListService_RemoveMovieFromList(t *testing.T) {
+	type mockBehavior func(s *MockListSorage, accountID, listID, movieID string)
+
+	testCasesTable := map[string]struct {
+		accountID            string
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			accountID: "accountID-000",
+			mockBehavior: func(s *MockListSorage, accountID, listID, movieID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, nil).Times(1)
+				s.EXPECT().DeleteMovieFromList(listID, movieID).Return(nil).Times(1)
+			},
+			wantError: false,
+		},
+		"The list of another account": {
+			accountID: "accountID-333",
+			mockBehavior: func(s *MockListSorage, accountID, listID, movieID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, core.ErrListNotFound).Times(1)
+			},
+			expectedErrorMessage: "service authorize list got the error: no list found",
+			wantError:            true,
+		},
+		"The movie is not in the list": {
+			accountID: "accountID-000",
+			mockBehavior: func(s *MockListSorage, accountID, listID, movieID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, nil).Times(1)
+				s.EXPECT().DeleteMovieFromList(listID, movieID).Return(core.ErrNotFound).Times(1)
+			},
+			expectedErrorMessage: "service remove movie from list got error: nothing was found",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			listStorage := NewMockListSorage(ctrl)
+			testCase.mockBehavior(listStorage, testCase.accountID, "listID-111", "movieID-222")
+
+			ls := ListService{
+				storage: listStorage,
+			}
+
+			err := ls.RemoveMovieFromList(testCase.accountID, "listID-111", "movieID-222")
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
+
+func TestListService_Delete(t *testing.T) {
+	type mockBehavior func(s *MockListSorage, accountID, listID string)
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			mockBehavior: func(s *MockListSorage, accountID, listID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{Type: "classics"}, nil).Times(1)
+				s.EXPECT().DeleteList(listID, accountID).Return(nil).Times(1)
+			},
+			wantError: false,
+		},
+		"The list of another account": {
+			mockBehavior: func(s *MockListSorage, accountID, listID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, core.ErrListNotFound).Times(1)
+			},
+			expectedErrorMessage: "service authorize list got the error: no list found",
+			wantError:            true,
+		},
+		"The system list": {
+			mockBehavior: func(s *MockListSorage, accountID, listID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{Type: "favorite"}, nil).Times(1)
+			},
+			expectedErrorMessage: "the system list can't be renamed or deleted",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			listStorage := NewMockListSorage(ctrl)
+			testCase.mockBehavior(listStorage, "accountID-000", "listID-111")
+
+			ls := ListService{
+				storage: listStorage,
+			}
+
+			err := ls.Delete("accountID-000", "listID-111")
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
+
+func TestListService_Rename(t *testing.T) {
+	type mockBehavior func(s *MockListSorage, accountID, listID string)
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedList         core.MovieList
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			mockBehavior: func(s *MockListSorage, accountID, listID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{Type: "old"}, nil).Times(1)
+				s.EXPECT().UpdateListType(listID, accountID, "classics").Return(core.MovieList{Type: "classics"}, nil).Times(1)
+			},
+			expectedList: core.MovieList{Type: "classics"},
+			wantError:    false,
+		},
+		"The account has the list of such type": {
+			mockBehavior: func(s *MockListSorage, accountID, listID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{Type: "old"}, nil).Times(1)
+				s.EXPECT().UpdateListType(listID, accountID, "classics").Return(core.MovieList{}, core.ErrDuplicateRow).Times(1)
+			},
+			expectedErrorMessage: "service rename list got error: such record already exists",
+			wantError:            true,
+		},
+		"The list of another account": {
+			mockBehavior: func(s *MockListSorage, accountID, listID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{}, core.ErrListNotFound).Times(1)
+			},
+			expectedErrorMessage: "service authorize list got the error: no list found",
+			wantError:            true,
+		},
+		"The system list": {
+			mockBehavior: func(s *MockListSorage, accountID, listID string) {
+				s.EXPECT().SelectListByID(listID, accountID).Return(core.MovieList{Type: "wishlist"}, nil).Times(1)
+			},
+			expectedErrorMessage: "the system list can't be renamed or deleted",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			listStorage := NewMockListSorage(ctrl)
+			testCase.mockBehavior(listStorage, "accountID-000", "listID-111")
+
+			ls := ListService{
+				storage: listStorage,
+			}
+
+			list, err := ls.Rename("accountID-000", "listID-111", "classics")
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+				assert.Equal(t, testCase.expectedList, list)
+			}
+		})
+	}
+}
+
+func TestListService_SystemList(t *testing.T) {
+	type mockBehavior func(s *MockListSorage)
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		action               func(ls ListService) error
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Add to favorites": {
+			mockBehavior: func(s *MockListSorage) {
+				s.EXPECT().InsertMovieToSystemList("accountID-000", core.FavoriteList, "movieID-222").Return(nil).Times(1)
+			},
+			action: func(ls ListService) error {
+				return ls.AddMovieToSystemList("accountID-000", core.FavoriteList, "movieID-222")
+			},
+			wantError: false,
+		},
+		"Add the unknown movie to wishlist": {
+			mockBehavior: func(s *MockListSorage) {
+				s.EXPECT().InsertMovieToSystemList("accountID-000", core.WishList, "movieID-222").Return(
+					core.ErrForeignKeyViolation).Times(1)
+			},
+			action: func(ls ListService) error {
+				return ls.AddMovieToSystemList("accountID-000", core.WishList, "movieID-222")
+			},
+			expectedErrorMessage: "service add movie to wishlist list got error: some value has no reference to the list or to the movie",
+			wantError:            true,
+		},
+		"Remove from favorites": {
+			mockBehavior: func(s *MockListSorage) {
+				s.EXPECT().DeleteMovieFromSystemList("accountID-000", core.FavoriteList, "movieID-222").Return(nil).Times(1)
+			},
+			action: func(ls ListService) error {
+				return ls.RemoveMovieFromSystemList("accountID-000", core.FavoriteList, "movieID-222")
+			},
+			wantError: false,
+		},
+		"Remove the movie which is not in wishlist": {
+			mockBehavior: func(s *MockListSorage) {
+				s.EXPECT().DeleteMovieFromSystemList("accountID-000", core.WishList, "movieID-222").Return(
+					core.ErrNotFound).Times(1)
+			},
+			action: func(ls ListService) error {
+				return ls.RemoveMovieFromSystemList("accountID-000", core.WishList, "movieID-222")
+			},
+			expectedErrorMessage: "service remove movie from wishlist list got error: nothing was found",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			listStorage := NewMockListSorage(ctrl)
+			testCase.mockBehavior(listStorage)
+
+			err := testCase.action(ListService{storage: listStorage})
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
 			} else {
 				assert.NoError(t, err, "The error should be nil")
 			}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/config"
+)
+
+// The prefixes of the keys the failed logins are counted by.
+const (
+	phoneThrottleKey = "phone:"
+	ipThrottleKey    = "ip:"
+)
+
+// The key the failed logins are counted by and the number of the failures allowed for it.
+type throttleKey struct {
+	key          string
+	freeAttempts int
+}
+
+// Locks the login of the phone or of the client IP after too many failures.
+// The lock grows exponentially while the failures continue. The nil storage disables the throttling.
+type loginThrottle struct {
+	storage LoginAttemptStorage
+	cfg     config.LoginThrottleConfig
+	now     func() time.Time
+}
+
+func newLoginThrottle(storage LoginAttemptStorage, cfg config.LoginThrottleConfig) loginThrottle {
+	return loginThrottle{storage: storage, cfg: cfg, now: time.Now}
+}
+
+func (t loginThrottle) keys(phone, clientIP string) []throttleKey {
+	return []throttleKey{
+		{key: phoneThrottleKey + phone, freeAttempts: t.cfg.AccountAttempts},
+		{key: ipThrottleKey + clientIP, freeAttempts: t.cfg.IPAttempts},
+	}
+}
+
+// The key of the phone alone, for the password checks of the account which is already logged in.
+func (t loginThrottle) phoneKeys(phone string) []throttleKey {
+	return []throttleKey{{key: phoneThrottleKey + phone, freeAttempts: t.cfg.AccountAttempts}}
+}
+
+// Returns core.LoginLockedError with the longest remaining lock if any of the keys is locked.
+func (t loginThrottle) check(keys []throttleKey) error {
+	if t.storage == nil {
+		return nil
+	}
+
+	var retryAfter time.Duration
+
+	for _, k := range keys {
+		attempts, err := t.storage.SelectLoginAttempts(k.key)
+		if err != nil {
+			return fmt.Errorf("can't get the login attempts: %w", err)
+		}
+
+		if wait := attempts.LockedUntil.Sub(t.now()); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return core.LoginLockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// Counts the failed login for all the keys and locks the ones which have run out of the attempts.
+func (t loginThrottle) fail(keys []throttleKey) error {
+	if t.storage == nil {
+		return nil
+	}
+
+	for _, k := range keys {
+		attempts, err := t.storage.AddLoginFailure(k.key, t.cfg.Window)
+		if err != nil {
+			return fmt.Errorf("can't count the failed login: %w", err)
+		}
+
+		if attempts.Failures <= k.freeAttempts {
+			continue
+		}
+
+		if err := t.storage.LockLogin(k.key, t.now().Add(t.lockout(attempts.Failures-k.freeAttempts))); err != nil {
+			return fmt.Errorf("can't lock the login: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Forgets the failures of the key.
+func (t loginThrottle) reset(key string) error {
+	if t.storage == nil {
+		return nil
+	}
+
+	if err := t.storage.DeleteLoginAttempts(key); err != nil {
+		return fmt.Errorf("can't reset the login attempts: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the lock for the failure over the allowed attempts: the base lock doubled for every next one.
+func (t loginThrottle) lockout(overLimit int) time.Duration {
+	lockout := t.cfg.BaseLockout
+
+	for i := 1; i < overLimit && lockout < t.cfg.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	if lockout > t.cfg.MaxLockout {
+		return t.cfg.MaxLockout
+	}
+
+	return lockout
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/app/keyring"
+	"github.com/Brigant/PetPorject/config"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottle_lockout(t *testing.T) {
+	throttle := newLoginThrottle(nil, config.LoginThrottleConfig{
+		BaseLockout: 30 * time.Second,
+		MaxLockout:  3 * time.Minute,
+	})
+
+	testCasesTable := map[string]struct {
+		overLimit int
+		expected  time.Duration
+	}{
+		"First failure over the limit": {overLimit: 1, expected: 30 * time.Second},
+		"Second failure doubles":       {overLimit: 2, expected: time.Minute},
+		"Third failure doubles again":  {overLimit: 3, expected: 2 * time.Minute},
+		"Capped by the max lockout":    {overLimit: 4, expected: 3 * time.Minute},
+		"Far over the limit":           {overLimit: 100, expected: 3 * time.Minute},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, throttle.lockout(testCase.overLimit))
+		})
+	}
+}
+
+func TestService_LoginThrottle(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage)
+
+	const (
+		phone    = "+380999999999"
+		password = "qwerty123456"
+		clientIP = "10.0.0.1"
+		phoneKey = "phone:" + phone
+		ipKey    = "ip:" + clientIP
+	)
+
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	account := core.Account{ID: "id-111", Phone: phone, Password: "stored-hash", Role: core.RoleUser}
+
+	notLocked := func(a *MockLoginAttemptStorage) {
+		a.EXPECT().SelectLoginAttempts(phoneKey).Return(core.LoginAttempts{Key: phoneKey}, nil)
+		a.EXPECT().SelectLoginAttempts(ipKey).Return(core.LoginAttempts{Key: ipKey}, nil)
+	}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		expectedRetryAfter   time.Duration
+		wantError            bool
+	}{
+		"Locked phone is refused without checking the password": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				a.EXPECT().SelectLoginAttempts(phoneKey).
+					Return(core.LoginAttempts{Key: phoneKey, Failures: 6, LockedUntil: now.Add(90 * time.Second)}, nil)
+				a.EXPECT().SelectLoginAttempts(ipKey).
+					Return(core.LoginAttempts{Key: ipKey, Failures: 21, LockedUntil: now.Add(30 * time.Second)}, nil)
+			},
+			expectedErrorMessage: "service Login got the error: too many failed login attempts, retry after 1m30s",
+			expectedRetryAfter:   90 * time.Second,
+			wantError:            true,
+		},
+		"Expired lock doesn't refuse the login": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				a.EXPECT().SelectLoginAttempts(phoneKey).
+					Return(core.LoginAttempts{Key: phoneKey, Failures: 6, LockedUntil: now.Add(-time.Second)}, nil)
+				a.EXPECT().SelectLoginAttempts(ipKey).Return(core.LoginAttempts{Key: ipKey}, nil)
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(true, false, nil)
+				a.EXPECT().DeleteLoginAttempts(phoneKey).Return(nil)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{RefreshToken: "refresh-111"}, nil)
+			},
+		},
+		"Wrong password locks the phone which has run out of the attempts": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				notLocked(a)
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(false, false, nil)
+				a.EXPECT().AddLoginFailure(phoneKey, 15*time.Minute).Return(core.LoginAttempts{Key: phoneKey, Failures: 7}, nil)
+				a.EXPECT().LockLogin(phoneKey, now.Add(time.Minute)).Return(nil)
+				a.EXPECT().AddLoginFailure(ipKey, 15*time.Minute).Return(core.LoginAttempts{Key: ipKey, Failures: 7}, nil)
+			},
+			expectedErrorMessage: "wrong passord",
+			wantError:            true,
+		},
+		"Unknown phone counts the failure": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				notLocked(a)
+				s.EXPECT().SelectAccountByPhone(phone).Return(core.Account{}, core.ErrUserNotFound)
+				a.EXPECT().AddLoginFailure(phoneKey, 15*time.Minute).Return(core.LoginAttempts{Key: phoneKey, Failures: 1}, nil)
+				a.EXPECT().AddLoginFailure(ipKey, 15*time.Minute).Return(core.LoginAttempts{Key: ipKey, Failures: 21}, nil)
+				a.EXPECT().LockLogin(ipKey, now.Add(30*time.Second)).Return(nil)
+			},
+			expectedErrorMessage: "service Login got the error: user is not found with such credentials",
+			wantError:            true,
+		},
+		"Success resets only the phone": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				notLocked(a)
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				h.EXPECT().Verify(password, "stored-hash").Return(true, false, nil)
+				a.EXPECT().DeleteLoginAttempts(phoneKey).Return(nil)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{RefreshToken: "refresh-111"}, nil)
+			},
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			passwordHasher := NewMockPasswordHasher(ctrl)
+			loginAttempts := NewMockLoginAttemptStorage(ctrl)
+			testCase.mockBehavior(accountStorage, passwordHasher, loginAttempts)
+
+			accountService := NewAccountService(accountStorage, passwordHasher, keyring.NewHMAC("key"), nil, loginAttempts, nil,
+				config.Config{
+					AccessTokenTTL:  time.Minute,
+					RefreshTokenTTL: time.Hour,
+					LoginThrottle: config.LoginThrottleConfig{
+						AccountAttempts: 5,
+						IPAttempts:      20,
+						BaseLockout:     30 * time.Second,
+						MaxLockout:      15 * time.Minute,
+						Window:          15 * time.Minute,
+					},
+				})
+			accountService.throttle.now = func() time.Time { return now }
+
+			_, err := accountService.Login(phone, password, core.Session{ClientIP: clientIP})
+
+			if !testCase.wantError {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedErrorMessage)
+
+			if testCase.expectedRetryAfter != 0 {
+				var lockedErr core.LoginLockedError
+
+				assert.ErrorAs(t, err, &lockedErr)
+				assert.Equal(t, testCase.expectedRetryAfter, lockedErr.RetryAfter)
+			}
+		})
+	}
+}
+
+func TestService_PasswordCheckThrottle(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage)
+
+	const (
+		phone    = "+380999999999"
+		phoneKey = "phone:" + phone
+	)
+
+	now := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	account := core.Account{ID: "id-111", Phone: phone, Password: "stored-hash", Role: core.RoleUser}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Locked phone is refused without checking the password": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				a.EXPECT().SelectLoginAttempts(phoneKey).
+					Return(core.LoginAttempts{Key: phoneKey, Failures: 6, LockedUntil: now.Add(30 * time.Second)}, nil)
+			},
+			expectedErrorMessage: "service DeleteAccount got the error: too many failed login attempts, retry after 30s",
+			wantError:            true,
+		},
+		"Wrong password locks the phone which has run out of the attempts": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				a.EXPECT().SelectLoginAttempts(phoneKey).Return(core.LoginAttempts{Key: phoneKey}, nil)
+				h.EXPECT().Verify("password", "stored-hash").Return(false, false, nil)
+				a.EXPECT().AddLoginFailure(phoneKey, 15*time.Minute).Return(core.LoginAttempts{Key: phoneKey, Failures: 6}, nil)
+				a.EXPECT().LockLogin(phoneKey, now.Add(30*time.Second)).Return(nil)
+			},
+			expectedErrorMessage: "service DeleteAccount got the error: wrong passord",
+			wantError:            true,
+		},
+		"Success resets the phone": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, a *MockLoginAttemptStorage) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				a.EXPECT().SelectLoginAttempts(phoneKey).Return(core.LoginAttempts{Key: phoneKey, Failures: 2}, nil)
+				h.EXPECT().Verify("password", "stored-hash").Return(true, false, nil)
+				a.EXPECT().DeleteLoginAttempts(phoneKey).Return(nil)
+				s.EXPECT().DeleteAccount("id-111").Return(core.ErasureReport{AccountID: "id-111"}, nil)
+			},
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			passwordHasher := NewMockPasswordHasher(ctrl)
+			loginAttempts := NewMockLoginAttemptStorage(ctrl)
+			testCase.mockBehavior(accountStorage, passwordHasher, loginAttempts)
+
+			accountService := NewAccountService(accountStorage, passwordHasher, keyring.NewHMAC("key"), nil, loginAttempts, nil,
+				config.Config{
+					LoginThrottle: config.LoginThrottleConfig{
+						AccountAttempts: 5,
+						BaseLockout:     30 * time.Second,
+						MaxLockout:      15 * time.Minute,
+						Window:          15 * time.Minute,
+					},
+				})
+			accountService.throttle.now = func() time.Time { return now }
+
+			_, err := accountService.DeleteAccount("id-111", "password")
+
+			if !testCase.wantError {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedErrorMessage)
+		})
+	}
+}
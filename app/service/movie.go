@@ -61,3 +61,25 @@ func (m MovieService) GetCSV(qp core.ConditionParams) ([]core.MovieCSV, error) {
 
 	return movieList, nil
 }
+
+// Replace the editable fields of the movie in the storage and return the new modified timestamp.
+// The non-empty Modified field of the movie makes the update conditional.
+func (m MovieService) UpdateMovie(movie core.Movie) (string, error) {
+	modified, err := m.movieStorage.UpdateMovie(movie)
+	if err != nil {
+		return "", fmt.Errorf("error happens while updating movie: %w", err)
+	}
+
+	return modified, nil
+}
+
+// Delete the movie from the storage. Returns the number of the lists which referenced the movie.
+// If cascade is false and there are such lists, the movie is kept and core.ErrMovieInLists is returned.
+func (m MovieService) DeleteMovie(movieID string, cascade bool) (int, error) {
+	listCount, err := m.movieStorage.DeleteMovie(movieID, cascade)
+	if err != nil {
+		return listCount, fmt.Errorf("error happens while deleting movie: %w", err)
+	}
+
+	return listCount, nil
+}
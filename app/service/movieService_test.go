@@ -252,3 +252,57 @@ func TestMovieService_GetCSV(t *testing.T) {
 		})
 	}
 }
+
+func TestMovieService_DeleteMovie(t *testing.T) {
+	type mockBehavior func(s *MockMovieStorage, movieID string)
+
+	testCasesTable := map[string]struct {
+		cascade              bool
+		mockBehavior         mockBehavior
+		expectedListCount    int
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful case": {
+			cascade: true,
+			mockBehavior: func(s *MockMovieStorage, movieID string) {
+				s.EXPECT().DeleteMovie(movieID, true).Return(2, nil)
+			},
+			expectedListCount: 2,
+			wantError:         false,
+		},
+		"Movie is in lists": {
+			cascade: false,
+			mockBehavior: func(s *MockMovieStorage, movieID string) {
+				s.EXPECT().DeleteMovie(movieID, false).Return(2, core.ErrMovieInLists)
+			},
+			expectedListCount:    2,
+			expectedErrorMessage: "error happens while deleting movie: the movie is still referenced by the lists",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mStorage := NewMockMovieStorage(ctrl)
+			testCase.mockBehavior(mStorage, "some-movie-id")
+
+			ms := MovieService{
+				movieStorage: mStorage,
+			}
+
+			listCount, err := ms.DeleteMovie("some-movie-id", testCase.cascade)
+
+			assert.Equal(t, testCase.expectedListCount, listCount)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+const (
+	// The random bytes of the state, the nonce and the PKCE code verifier.
+	oidcRandomBytes = 32
+	// The random bytes of the password of the account created by the single sign-on.
+	oidcPasswordBytes = 32
+)
+
+var e164Phone = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// Starts the login at the identity provider and returns the URL the user is redirected to
+// together with the login, whose state must be bound to the browser which started it.
+func (a AccountService) StartOIDCLogin() (string, core.OIDCLogin, error) {
+	if a.oidc == nil {
+		return "", core.OIDCLogin{}, core.ErrOIDCDisabled
+	}
+
+	login := core.OIDCLogin{Expired: time.Now().Add(a.cfg.OIDC.LoginTTL)}
+
+	for _, value := range []*string{&login.State, &login.Nonce, &login.CodeVerifier} {
+		random, err := randomString(oidcRandomBytes)
+		if err != nil {
+			return "", core.OIDCLogin{}, fmt.Errorf("service StartOIDCLogin got the error: %w", err)
+		}
+
+		*value = random
+	}
+
+	if err := a.storage.InsertOIDCLogin(login); err != nil {
+		return "", core.OIDCLogin{}, fmt.Errorf("service StartOIDCLogin got the error: %w", err)
+	}
+
+	authURL, err := a.oidc.AuthCodeURL(login.State, login.Nonce, login.CodeVerifier)
+	if err != nil {
+		return "", core.OIDCLogin{}, fmt.Errorf("service StartOIDCLogin got the error: %w", err)
+	}
+
+	return authURL, login, nil
+}
+
+// Finishes the login with the code the identity provider redirected back with
+// and returns the token pair of the account linked to the identity. The state is accepted once.
+func (a AccountService) FinishOIDCLogin(code, state string, session core.Session) (core.TokenPair, error) {
+	if a.oidc == nil {
+		return core.TokenPair{}, core.ErrOIDCDisabled
+	}
+
+	login, err := a.storage.TakeOIDCLogin(state)
+	if err != nil {
+		return core.TokenPair{}, fmt.Errorf("service FinishOIDCLogin got the error: %w", err)
+	}
+
+	if !time.Now().Before(login.Expired) {
+		return core.TokenPair{}, core.ErrOIDCLoginNotFound
+	}
+
+	identity, err := a.oidc.Exchange(code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return core.TokenPair{}, fmt.Errorf("service FinishOIDCLogin got the error: %w", err)
+	}
+
+	account, err := a.identityAccount(identity)
+	if err != nil {
+		return core.TokenPair{}, fmt.Errorf("service FinishOIDCLogin got the error: %w", err)
+	}
+
+	if account.Disabled {
+		return core.TokenPair{}, core.ErrAccountDisabled
+	}
+
+	return a.startSession(account, session)
+}
+
+// Returns the account linked to the identity. The new identity is linked to the account
+// with the same phone if both the provider and the account verified it,
+// otherwise the new user is created.
+func (a AccountService) identityAccount(identity core.ExternalIdentity) (core.Account, error) {
+	account, err := a.storage.SelectAccountByIdentity(identity.Issuer, identity.Subject)
+	if err == nil {
+		return account, nil
+	}
+
+	if !errors.Is(err, core.ErrIdentityNotFound) {
+		return core.Account{}, fmt.Errorf("can't get the account of the identity: %w", err)
+	}
+
+	// The unverified phone could be anyone's, so it is neither linked nor given to the new account.
+	phone := normalizePhone(identity.Phone)
+	if !identity.PhoneVerified || !e164Phone.MatchString(phone) {
+		return core.Account{}, core.ErrOIDCPhoneRequired
+	}
+
+	account, err = a.storage.SelectAccountByPhone(phone)
+	if errors.Is(err, core.ErrUserNotFound) {
+		return a.createIdentityAccount(phone, identity)
+	}
+
+	if err != nil {
+		return core.Account{}, fmt.Errorf("can't get the account by the phone: %w", err)
+	}
+
+	// Anyone could sign up with the phone they don't own, so only the verified account is linked.
+	if !account.PhoneVerified {
+		return core.Account{}, core.ErrOIDCPhoneNotLinked
+	}
+
+	err = a.storage.InsertIdentity(account.ID, identity)
+	if errors.Is(err, core.ErrDuplicateIdentity) {
+		return a.concurrentIdentityAccount(identity, err)
+	}
+
+	if err != nil {
+		return core.Account{}, fmt.Errorf("can't link the identity: %w", err)
+	}
+
+	return account, nil
+}
+
+// The concurrent callback of the same identity may link or create the account first,
+// then the account it linked is used.
+func (a AccountService) concurrentIdentityAccount(identity core.ExternalIdentity, insertErr error) (core.Account, error) {
+	account, err := a.storage.SelectAccountByIdentity(identity.Issuer, identity.Subject)
+	if errors.Is(err, core.ErrIdentityNotFound) {
+		return core.Account{}, fmt.Errorf("can't link the identity: %w", insertErr)
+	}
+
+	if err != nil {
+		return core.Account{}, fmt.Errorf("can't get the account of the identity: %w", err)
+	}
+
+	return account, nil
+}
+
+// Creates the user with the verified phone. The password is random,
+// the user can set the own one by the password reset.
+// The provider doesn't share the age, so it is 0 which means unknown until the user sets it by PATCH /me.
+func (a AccountService) createIdentityAccount(phone string, identity core.ExternalIdentity) (core.Account, error) {
+	password, err := randomString(oidcPasswordBytes)
+	if err != nil {
+		return core.Account{}, err
+	}
+
+	passwordHash, err := a.hasher.Hash(password)
+	if err != nil {
+		return core.Account{}, fmt.Errorf("can't hash the password: %w", err)
+	}
+
+	account := core.Account{
+		Phone:         phone,
+		Password:      passwordHash,
+		Role:          core.RoleUser,
+		PhoneVerified: true,
+	}
+
+	account.ID, err = a.storage.InsertAccountWithIdentity(account, identity, core.SystemListTypes)
+	if errors.Is(err, core.ErrDuplicatePhone) || errors.Is(err, core.ErrDuplicateIdentity) {
+		return a.concurrentIdentityAccount(identity, err)
+	}
+
+	if err != nil {
+		return core.Account{}, fmt.Errorf("can't create the account of the identity: %w", err)
+	}
+
+	return account, nil
+}
+
+// The provider may format the phone for reading, for ex.: "+1 (425) 555-1212".
+func normalizePhone(phone string) string {
+	return strings.NewReplacer(" ", "", "(", "", ")", "", "-", "", ".", "").Replace(phone)
+}
+
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("can't generate the random string: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
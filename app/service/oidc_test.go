@@ -0,0 +1,310 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/app/keyring"
+	"github.com/Brigant/PetPorject/app/oidc"
+	"github.com/Brigant/PetPorject/app/oidc/oidctest"
+	"github.com/Brigant/PetPorject/config"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Starts the login at the service, lets the provider log the user in
+// and returns the code and the state the provider redirects back with.
+func startOIDCLogin(t *testing.T, accountService AccountService) (code, state string) {
+	t.Helper()
+
+	authURL, login, err := accountService.StartOIDCLogin()
+	if err != nil {
+		t.Fatalf("StartOIDCLogin: %v", err)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	response, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	defer response.Body.Close()
+
+	callback, err := url.Parse(response.Header.Get("Location"))
+	if err != nil || response.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: unexpected response %d %q", response.StatusCode, response.Header.Get("Location"))
+	}
+
+	// The handler binds this state to the browser, it must be the one the provider returns.
+	assert.Equal(t, login.State, callback.Query().Get("state"))
+
+	return callback.Query().Get("code"), callback.Query().Get("state")
+}
+
+func TestService_OIDCLogin(t *testing.T) {
+	const (
+		clientID    = "movies-api"
+		redirectURL = "http://localhost:8080/auth/oidc/callback"
+		phone       = "+380999999999"
+	)
+
+	type mockBehavior func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity)
+
+	account := core.Account{ID: "id-111", Phone: phone, Role: core.RoleUser, PhoneVerified: true}
+
+	testCasesTable := map[string]struct {
+		user          oidctest.User
+		mockBehavior  mockBehavior
+		expectedError error
+	}{
+		"Linked identity": {
+			user: oidctest.User{Subject: "subject-111", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-111").Return(account, nil)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{RefreshToken: "refresh-111"}, nil)
+			},
+		},
+		"Linked by the verified phone": {
+			user: oidctest.User{Subject: "subject-111", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-111").
+					Return(core.Account{}, core.ErrIdentityNotFound)
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().InsertIdentity("id-111", identity).Return(nil)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{RefreshToken: "refresh-111"}, nil)
+			},
+		},
+		"Formatted phone is linked": {
+			user: oidctest.User{Subject: "subject-111", Phone: "+380 (99) 999-99-99", PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-111").
+					Return(core.Account{}, core.ErrIdentityNotFound)
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().InsertIdentity("id-111", identity).Return(nil)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{RefreshToken: "refresh-111"}, nil)
+			},
+		},
+		"New user is created": {
+			user: oidctest.User{Subject: "subject-222", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-222").
+					Return(core.Account{}, core.ErrIdentityNotFound)
+				s.EXPECT().SelectAccountByPhone(phone).Return(core.Account{}, core.ErrUserNotFound)
+				h.EXPECT().Hash(gomock.Any()).Return("random-password-hash", nil)
+				s.EXPECT().InsertAccountWithIdentity(core.Account{
+					Phone: phone, Password: "random-password-hash", Role: core.RoleUser, PhoneVerified: true,
+				}, identity, core.SystemListTypes).Return("id-222", nil)
+				s.EXPECT().InsertSession(gomock.Any()).DoAndReturn(func(session core.Session) (core.Session, error) {
+					assert.Equal(t, "id-222", session.AccountID)
+					assert.Equal(t, core.RoleUser, session.Role)
+
+					session.RefreshToken = "refresh-222"
+
+					return session, nil
+				})
+			},
+		},
+		"Identity linked by the concurrent callback": {
+			user: oidctest.User{Subject: "subject-111", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				gomock.InOrder(
+					s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-111").
+						Return(core.Account{}, core.ErrIdentityNotFound),
+					s.EXPECT().SelectAccountByPhone(phone).Return(account, nil),
+					s.EXPECT().InsertIdentity("id-111", identity).Return(core.ErrDuplicateIdentity),
+					s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-111").Return(account, nil),
+				)
+				s.EXPECT().InsertSession(gomock.Any()).Return(core.Session{RefreshToken: "refresh-111"}, nil)
+			},
+		},
+		"Account created by the concurrent callback": {
+			user: oidctest.User{Subject: "subject-222", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				created := core.Account{ID: "id-222", Phone: phone, Role: core.RoleUser, PhoneVerified: true}
+
+				gomock.InOrder(
+					s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-222").
+						Return(core.Account{}, core.ErrIdentityNotFound),
+					s.EXPECT().SelectAccountByPhone(phone).Return(core.Account{}, core.ErrUserNotFound),
+					s.EXPECT().InsertAccountWithIdentity(gomock.Any(), identity, core.SystemListTypes).
+						Return("", core.ErrDuplicatePhone),
+					s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-222").Return(created, nil),
+				)
+				h.EXPECT().Hash(gomock.Any()).Return("random-password-hash", nil)
+				s.EXPECT().InsertSession(gomock.Any()).DoAndReturn(func(session core.Session) (core.Session, error) {
+					assert.Equal(t, "id-222", session.AccountID)
+
+					session.RefreshToken = "refresh-222"
+
+					return session, nil
+				})
+			},
+		},
+		"Phone taken by the concurrent sign up": {
+			user: oidctest.User{Subject: "subject-222", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-222").
+					Return(core.Account{}, core.ErrIdentityNotFound).Times(2)
+				s.EXPECT().SelectAccountByPhone(phone).Return(core.Account{}, core.ErrUserNotFound)
+				h.EXPECT().Hash(gomock.Any()).Return("random-password-hash", nil)
+				s.EXPECT().InsertAccountWithIdentity(gomock.Any(), identity, core.SystemListTypes).
+					Return("", core.ErrDuplicatePhone)
+			},
+			expectedError: core.ErrDuplicatePhone,
+		},
+		"Unverified phone at the provider": {
+			user: oidctest.User{Subject: "subject-222", Phone: phone, PhoneVerified: false},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-222").
+					Return(core.Account{}, core.ErrIdentityNotFound)
+			},
+			expectedError: core.ErrOIDCPhoneRequired,
+		},
+		"No phone at the provider": {
+			user: oidctest.User{Subject: "subject-222"},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-222").
+					Return(core.Account{}, core.ErrIdentityNotFound)
+			},
+			expectedError: core.ErrOIDCPhoneRequired,
+		},
+		"Account with the unverified phone is not linked": {
+			user: oidctest.User{Subject: "subject-111", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				unverified := account
+				unverified.PhoneVerified = false
+
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-111").
+					Return(core.Account{}, core.ErrIdentityNotFound)
+				s.EXPECT().SelectAccountByPhone(phone).Return(unverified, nil)
+			},
+			expectedError: core.ErrOIDCPhoneNotLinked,
+		},
+		"Disabled account": {
+			user: oidctest.User{Subject: "subject-111", Phone: phone, PhoneVerified: true},
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher, identity core.ExternalIdentity) {
+				disabled := account
+				disabled.Disabled = true
+
+				s.EXPECT().SelectAccountByIdentity(identity.Issuer, "subject-111").Return(disabled, nil)
+			},
+			expectedError: core.ErrAccountDisabled,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			idp, err := oidctest.NewIdP(clientID, "client-secret")
+			if err != nil {
+				t.FailNow()
+			}
+			defer idp.Close()
+
+			idp.User = testCase.user
+
+			provider := oidc.NewProvider(oidc.Config{
+				Issuer:       idp.Issuer(),
+				ClientID:     clientID,
+				ClientSecret: "client-secret",
+				RedirectURL:  redirectURL,
+				Scopes:       []string{"openid", "phone"},
+			}, nil)
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			passwordHasher := NewMockPasswordHasher(ctrl)
+
+			accountService := NewAccountService(accountStorage, passwordHasher, keyring.NewHMAC("key"), nil, nil, provider,
+				config.Config{
+					AccessTokenTTL:  time.Minute,
+					RefreshTokenTTL: time.Hour,
+					OIDC:            config.OIDCConfig{LoginTTL: 10 * time.Minute},
+				})
+
+			var login core.OIDCLogin
+
+			accountStorage.EXPECT().InsertOIDCLogin(gomock.Any()).DoAndReturn(func(l core.OIDCLogin) error {
+				login = l
+
+				return nil
+			})
+
+			code, state := startOIDCLogin(t, accountService)
+			assert.Equal(t, login.State, state)
+
+			accountStorage.EXPECT().TakeOIDCLogin(state).Return(login, nil)
+			testCase.mockBehavior(accountStorage, passwordHasher, core.ExternalIdentity{
+				Issuer:        idp.Issuer(),
+				Subject:       testCase.user.Subject,
+				Phone:         testCase.user.Phone,
+				PhoneVerified: testCase.user.PhoneVerified,
+			})
+
+			tokenPair, err := accountService.FinishOIDCLogin(code, state, core.Session{ClientIP: "10.0.0.1"})
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, tokenPair.AccessToken)
+			assert.NotEmpty(t, tokenPair.RefreshToken)
+		})
+	}
+}
+
+func TestService_FinishOIDCLoginRejects(t *testing.T) {
+	provider := NewMockOIDCProvider(gomock.NewController(t))
+
+	testCasesTable := map[string]struct {
+		provider      OIDCProvider
+		mockBehavior  func(s *MockAccountStorage)
+		expectedError error
+	}{
+		"Single sign-on is disabled": {
+			mockBehavior:  func(s *MockAccountStorage) {},
+			expectedError: core.ErrOIDCDisabled,
+		},
+		"Unknown state": {
+			provider: provider,
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().TakeOIDCLogin("state-111").Return(core.OIDCLogin{}, core.ErrOIDCLoginNotFound)
+			},
+			expectedError: core.ErrOIDCLoginNotFound,
+		},
+		"Expired login": {
+			provider: provider,
+			mockBehavior: func(s *MockAccountStorage) {
+				s.EXPECT().TakeOIDCLogin("state-111").Return(core.OIDCLogin{
+					State: "state-111", Expired: time.Now().Add(-time.Second),
+				}, nil)
+			},
+			expectedError: core.ErrOIDCLoginNotFound,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			testCase.mockBehavior(accountStorage)
+
+			accountService := NewAccountService(accountStorage, nil, nil, nil, nil, testCase.provider, config.Config{})
+
+			_, err := accountService.FinishOIDCLogin("code-111", "state-111", core.Session{})
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+// Returns the account of the caller without the password hash.
+func (a AccountService) Profile(accountID string) (core.AccountInfo, error) {
+	account, err := a.storage.SelectAccountInfo(accountID)
+	if err != nil {
+		return core.AccountInfo{}, fmt.Errorf("service Profile got the error: %w", err)
+	}
+
+	return account, nil
+}
+
+func (a AccountService) UpdateAge(accountID string, age int) (core.AccountInfo, error) {
+	account, err := a.storage.UpdateAccountAge(accountID, age)
+	if err != nil {
+		return core.AccountInfo{}, fmt.Errorf("service UpdateAge got the error: %w", err)
+	}
+
+	return account, nil
+}
+
+// Replaces the password if the current one is correct. The other sessions of the account are revoked,
+// the session of the current refresh token stays alive.
+func (a AccountService) ChangePassword(accountID, currentRefreshToken, currentPassword, newPassword string) error {
+	if err := a.checkPassword(accountID, currentPassword); err != nil {
+		return fmt.Errorf("service ChangePassword got the error: %w", err)
+	}
+
+	passwordHash, err := a.hasher.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("service ChangePassword got the error: %w", err)
+	}
+
+	if err := a.storage.ChangePassword(accountID, passwordHash, currentRefreshToken); err != nil {
+		return fmt.Errorf("service ChangePassword got the error: %w", err)
+	}
+
+	a.sessions.reset()
+
+	return nil
+}
+
+// Deletes the account with all its data if the password is correct
+// and reports how much data was removed.
+func (a AccountService) DeleteAccount(accountID, password string) (core.ErasureReport, error) {
+	if err := a.checkPassword(accountID, password); err != nil {
+		return core.ErasureReport{}, fmt.Errorf("service DeleteAccount got the error: %w", err)
+	}
+
+	report, err := a.storage.DeleteAccount(accountID)
+	if err != nil {
+		return core.ErasureReport{}, fmt.Errorf("service DeleteAccount got the error: %w", err)
+	}
+
+	a.sessions.reset()
+
+	return report, nil
+}
+
+// Returns everything stored about the account.
+func (a AccountService) ExportAccount(accountID string) (core.AccountExport, error) {
+	export, err := a.storage.SelectAccountExport(accountID)
+	if err != nil {
+		return core.AccountExport{}, fmt.Errorf("service ExportAccount got the error: %w", err)
+	}
+
+	export.Exported = time.Now().UTC()
+
+	return export, nil
+}
+
+// Returns core.ErrWrongPassword if the password doesn't match the one of the account.
+// The failures are throttled by the phone like the login, so the stolen access token
+// doesn't let guess the password either.
+func (a AccountService) checkPassword(accountID, password string) error {
+	account, err := a.storage.SelectAccountByID(accountID)
+	if err != nil {
+		return fmt.Errorf("can't get the account: %w", err)
+	}
+
+	throttleKeys := a.throttle.phoneKeys(account.Phone)
+
+	if err := a.throttle.check(throttleKeys); err != nil {
+		return err
+	}
+
+	match, _, err := a.hasher.Verify(password, account.Password)
+	if err != nil {
+		return fmt.Errorf("can't verify the password: %w", err)
+	}
+
+	if !match {
+		if err := a.throttle.fail(throttleKeys); err != nil {
+			return err
+		}
+
+		return core.ErrWrongPassword
+	}
+
+	return a.throttle.reset(throttleKeys[0].key)
+}
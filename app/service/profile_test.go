@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ChangePassword(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage, h *MockPasswordHasher)
+
+	account := core.Account{ID: "id-111", Password: "stored-hash"}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				h.EXPECT().Verify("current-pass", "stored-hash").Return(true, false, nil)
+				h.EXPECT().Hash("new-password").Return("new-hash", nil)
+				s.EXPECT().ChangePassword("id-111", "new-hash", "refresh-111").Return(nil)
+			},
+		},
+		"Wrong current password": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				h.EXPECT().Verify("current-pass", "stored-hash").Return(false, false, nil)
+			},
+			expectedErrorMessage: "service ChangePassword got the error: wrong passord",
+			wantError:            true,
+		},
+		"Password is not changed": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				h.EXPECT().Verify("current-pass", "stored-hash").Return(true, false, nil)
+				h.EXPECT().Hash("new-password").Return("new-hash", nil)
+				s.EXPECT().ChangePassword("id-111", "new-hash", "refresh-111").Return(errors.New("db is down"))
+			},
+			expectedErrorMessage: "service ChangePassword got the error: db is down",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			passwordHasher := NewMockPasswordHasher(ctrl)
+			testCase.mockBehavior(accountStorage, passwordHasher)
+
+			accountService := AccountService{storage: accountStorage, hasher: passwordHasher}
+
+			err := accountService.ChangePassword("id-111", "refresh-111", "current-pass", "new-password")
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_DeleteAccount(t *testing.T) {
+	type mockBehavior func(s *MockAccountStorage, h *MockPasswordHasher)
+
+	account := core.Account{ID: "id-111", Password: "stored-hash"}
+	report := core.ErasureReport{AccountID: "id-111", Sessions: 2, Lists: 3, ListMovies: 5, Ratings: 1}
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedReport       core.ErasureReport
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				h.EXPECT().Verify("password", "stored-hash").Return(true, false, nil)
+				s.EXPECT().DeleteAccount("id-111").Return(report, nil)
+			},
+			expectedReport: report,
+		},
+		"Wrong password": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByID("id-111").Return(account, nil)
+				h.EXPECT().Verify("password", "stored-hash").Return(false, false, nil)
+			},
+			expectedErrorMessage: "service DeleteAccount got the error: wrong passord",
+			wantError:            true,
+		},
+		"No account": {
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByID("id-111").Return(core.Account{}, core.ErrUserNotFound)
+			},
+			expectedErrorMessage: "service DeleteAccount got the error: " +
+				"can't get the account: user is not found with such credentials",
+			wantError: true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			passwordHasher := NewMockPasswordHasher(ctrl)
+			testCase.mockBehavior(accountStorage, passwordHasher)
+
+			accountService := AccountService{storage: accountStorage, hasher: passwordHasher}
+
+			report, err := accountService.DeleteAccount("id-111", "password")
+
+			assert.Equal(t, testCase.expectedReport, report)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_ExportAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	export := core.AccountExport{
+		Account: core.AccountInfo{ID: "id-111"},
+		Ratings: []core.Rating{{AccountID: "id-111", MovieID: "movie-1", Score: 7.5}},
+	}
+
+	accountStorage := NewMockAccountStorage(ctrl)
+	accountStorage.EXPECT().SelectAccountExport("id-111").Return(export, nil)
+
+	before := time.Now()
+
+	result, err := AccountService{storage: accountStorage}.ExportAccount("id-111")
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, export.Account, result.Account)
+	assert.Equal(t, export.Ratings, result.Ratings)
+	assert.False(t, result.Exported.Before(before.Truncate(time.Second)))
+}
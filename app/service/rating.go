@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+type RatingService struct {
+	storage RatingStorage
+}
+
+func NewRatingService(storage RatingStorage) RatingService {
+	return RatingService{storage: storage}
+}
+
+// Returns the score which the account gave to the movie.
+func (r RatingService) Get(accountID, movieID string) (core.Rating, error) {
+	rating, err := r.storage.SelectRating(accountID, movieID)
+	if err != nil {
+		return core.Rating{}, fmt.Errorf("service Get rating got the error: %w", err)
+	}
+
+	return rating, nil
+}
+
+// Puts the new score of the account to the movie or changes the existing one.
+func (r RatingService) Rate(rating core.Rating) (core.Rating, error) {
+	if err := rating.Validate(); err != nil {
+		return core.Rating{}, fmt.Errorf("rating validation failed: %w", err)
+	}
+
+	rating, err := r.storage.UpsertRating(rating)
+	if err != nil {
+		return core.Rating{}, fmt.Errorf("service Rate got the error: %w", err)
+	}
+
+	return rating, nil
+}
+
+// Removes the score of the account from the movie.
+func (r RatingService) Delete(accountID, movieID string) error {
+	if err := r.storage.DeleteRating(accountID, movieID); err != nil {
+		return fmt.Errorf("service Delete rating got the error: %w", err)
+	}
+
+	return nil
+}
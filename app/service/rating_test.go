@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Brigant/PetPorject/app/core"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingService_Rate(t *testing.T) {
+	type mockBehavior func(s *MockRatingStorage, rating core.Rating)
+
+	testCasesTable := map[string]struct {
+		rating               core.Rating
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			rating: core.Rating{AccountID: "account-111", MovieID: "movie-111", Score: 7.9},
+			mockBehavior: func(s *MockRatingStorage, rating core.Rating) {
+				s.EXPECT().UpsertRating(rating).Return(rating, nil).Times(1)
+			},
+			wantError: false,
+		},
+		"Score with two decimals": {
+			rating:               core.Rating{AccountID: "account-111", MovieID: "movie-111", Score: 7.95},
+			mockBehavior:         func(s *MockRatingStorage, rating core.Rating) {},
+			expectedErrorMessage: "rating validation failed: the score should be in range from 0 to 10 with one decimal place",
+			wantError:            true,
+		},
+		"Score out of range": {
+			rating:               core.Rating{AccountID: "account-111", MovieID: "movie-111", Score: 10.1},
+			mockBehavior:         func(s *MockRatingStorage, rating core.Rating) {},
+			expectedErrorMessage: "rating validation failed: the score should be in range from 0 to 10 with one decimal place",
+			wantError:            true,
+		},
+		"Storage error": {
+			rating: core.Rating{AccountID: "account-111", MovieID: "movie-111", Score: 0},
+			mockBehavior: func(s *MockRatingStorage, rating core.Rating) {
+				s.EXPECT().UpsertRating(rating).Return(core.Rating{}, errors.New("some error")).Times(1)
+			},
+			expectedErrorMessage: "service Rate got the error: some error",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			// Init Deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ratingStorage := NewMockRatingStorage(ctrl)
+			testCase.mockBehavior(ratingStorage, testCase.rating)
+
+			rs := RatingService{
+				storage: ratingStorage,
+			}
+
+			rating, err := rs.Rate(testCase.rating)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+				assert.Equal(t, testCase.rating.Score, rating.Score)
+			}
+		})
+	}
+}
+
+func TestRatingService_Delete(t *testing.T) {
+	type mockBehavior func(s *MockRatingStorage, accountID, movieID string)
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Successful": {
+			mockBehavior: func(s *MockRatingStorage, accountID, movieID string) {
+				s.EXPECT().DeleteRating(accountID, movieID).Return(nil).Times(1)
+			},
+			wantError: false,
+		},
+		"Not found": {
+			mockBehavior: func(s *MockRatingStorage, accountID, movieID string) {
+				s.EXPECT().DeleteRating(accountID, movieID).Return(core.ErrRatingNotFound).Times(1)
+			},
+			expectedErrorMessage: "service Delete rating got the error: no rating found",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			// Init Deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ratingStorage := NewMockRatingStorage(ctrl)
+			testCase.mockBehavior(ratingStorage, "account-111", "movie-111")
+
+			rs := RatingService{
+				storage: ratingStorage,
+			}
+
+			err := rs.Delete("account-111", "movie-111")
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err, "The error should be nil")
+			}
+		})
+	}
+}
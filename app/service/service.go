@@ -9,6 +9,15 @@ type Deps struct {
 	DirectorStorage DirectorStorage
 	MovieStorage    MovieStorage
 	ListSorage      ListSorage
+	RatingStorage   RatingStorage
+	BlobStore       BlobStore
+	PasswordHasher  PasswordHasher
+	TokenKeys       TokenKeys
+	SMSSender       SMSSender
+	LoginAttempts   LoginAttemptStorage
+	APIKeyStorage   APIKeyStorage
+	// The provider of the single sign-on, it is disabled if the provider is nil.
+	OIDCProvider OIDCProvider
 }
 
 type Services struct {
@@ -16,13 +25,18 @@ type Services struct {
 	Director DirectorService
 	Movie    MovieService
 	List     ListService
+	Rating   RatingService
+	APIKey   APIKeyService
 }
 
 func New(deps Deps, cfg config.Config) Services {
 	return Services{
-		Account:  NewAccountService(deps.AccountStorage, cfg),
-		Director: NewDirectorService(deps.DirectorStorage),
+		Account: NewAccountService(deps.AccountStorage, deps.PasswordHasher, deps.TokenKeys, deps.SMSSender, deps.LoginAttempts,
+			deps.OIDCProvider, cfg),
+		Director: NewDirectorService(deps.DirectorStorage, deps.BlobStore),
 		Movie:    NewMovieService(deps.MovieStorage),
 		List:     NewListService(deps.ListSorage),
+		Rating:   NewRatingService(deps.RatingStorage),
+		APIKey:   NewAPIKeyService(deps.APIKeyStorage),
 	}
 }
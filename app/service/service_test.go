@@ -19,6 +19,11 @@ func TestNew(t *testing.T) {
 		DirectorStorage: NewMockDirectorStorage(ctrl),
 		MovieStorage:    NewMockMovieStorage(ctrl),
 		ListSorage:      NewMockListSorage(ctrl),
+		RatingStorage:   NewMockRatingStorage(ctrl),
+		BlobStore:       NewMockBlobStore(ctrl),
+		PasswordHasher:  NewMockPasswordHasher(ctrl),
+		TokenKeys:       NewMockTokenKeys(ctrl),
+		APIKeyStorage:   NewMockAPIKeyStorage(ctrl),
 	}
 
 	service := New(deps, config.Config{})
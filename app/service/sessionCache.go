@@ -0,0 +1,85 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// The number of the cached sessions after which the expired entries are pruned.
+const sessionCacheMaxEntries = 10000
+
+// The short-lived in-process cache of the session liveness, keyed by the refresh token.
+// It saves the database query on every authenticated request.
+type sessionCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]sessionCacheEntry
+	now     func() time.Time
+}
+
+type sessionCacheEntry struct {
+	live    bool
+	expires time.Time
+}
+
+func newSessionCache(ttl time.Duration) *sessionCache {
+	return &sessionCache{
+		ttl:     ttl,
+		entries: make(map[string]sessionCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Returns the cached liveness of the session and whether it was found.
+func (c *sessionCache) get(refreshToken string) (live, ok bool) {
+	if c == nil || c.ttl <= 0 {
+		return false, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[refreshToken]
+	if !ok || !c.now().Before(entry.expires) {
+		return false, false
+	}
+
+	return entry.live, true
+}
+
+func (c *sessionCache) set(refreshToken string, live bool) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if len(c.entries) >= sessionCacheMaxEntries {
+		for token, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, token)
+			}
+		}
+	}
+
+	if len(c.entries) >= sessionCacheMaxEntries {
+		c.entries = make(map[string]sessionCacheEntry)
+	}
+
+	c.entries[refreshToken] = sessionCacheEntry{live: live, expires: now.Add(c.ttl)}
+}
+
+// Forgets all the sessions, because the revoked session may be cached under any of its refresh tokens.
+func (c *sessionCache) reset() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]sessionCacheEntry)
+}
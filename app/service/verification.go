@@ -0,0 +1,183 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+)
+
+// The number of the digits in the one-time code.
+const codeDigits = 6
+
+// Sends the code which confirms the account owns the phone. The unknown phone is not reported,
+// so the endpoint doesn't reveal which phones are registered.
+func (a AccountService) RequestPhoneVerification(phone string) error {
+	account, err := a.storage.SelectAccountByPhone(phone)
+	if err != nil {
+		if errors.Is(err, core.ErrUserNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("service RequestPhoneVerification got the error: %w", err)
+	}
+
+	if account.PhoneVerified {
+		return nil
+	}
+
+	if err := a.sendCode(account, core.PhoneVerification, "Your phone verification code: %s"); err != nil {
+		return fmt.Errorf("service RequestPhoneVerification got the error: %w", err)
+	}
+
+	return nil
+}
+
+// Marks the phone as verified if the code is correct.
+func (a AccountService) ConfirmPhone(phone, code string) error {
+	accountID, err := a.checkCode(phone, core.PhoneVerification, code)
+	if err != nil {
+		return fmt.Errorf("service ConfirmPhone got the error: %w", err)
+	}
+
+	if err := a.storage.ConfirmPhone(accountID); err != nil {
+		return fmt.Errorf("service ConfirmPhone got the error: %w", err)
+	}
+
+	return nil
+}
+
+// Sends the code which allows to set the new password. The unknown phone is not reported.
+func (a AccountService) RequestPasswordReset(phone string) error {
+	account, err := a.storage.SelectAccountByPhone(phone)
+	if err != nil {
+		if errors.Is(err, core.ErrUserNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("service RequestPasswordReset got the error: %w", err)
+	}
+
+	if err := a.sendCode(account, core.PasswordReset, "Your password reset code: %s"); err != nil {
+		return fmt.Errorf("service RequestPasswordReset got the error: %w", err)
+	}
+
+	return nil
+}
+
+// Sets the new password if the code is correct. All the sessions of the account are revoked.
+func (a AccountService) ResetPassword(phone, code, newPassword string) error {
+	accountID, err := a.checkCode(phone, core.PasswordReset, code)
+	if err != nil {
+		return fmt.Errorf("service ResetPassword got the error: %w", err)
+	}
+
+	passwordHash, err := a.hasher.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("service ResetPassword got the error: %w", err)
+	}
+
+	if err := a.storage.ResetPassword(accountID, passwordHash); err != nil {
+		return fmt.Errorf("service ResetPassword got the error: %w", err)
+	}
+
+	a.sessions.reset()
+
+	return nil
+}
+
+// Generates the new code, stores its hash and sends the code by SMS. The code is not sent again
+// until the resend interval has passed, the previously sent code stays valid meanwhile.
+func (a AccountService) sendCode(account core.Account, purpose core.CodePurpose, text string) error {
+	previous, err := a.storage.SelectOneTimeCode(account.ID, purpose)
+	if err != nil && !errors.Is(err, core.ErrCodeNotFound) {
+		return fmt.Errorf("can't get the previous code: %w", err)
+	}
+
+	if err == nil && time.Since(previous.Created) < a.cfg.OneTimeCode.ResendInterval {
+		return nil
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+
+	err = a.storage.UpsertOneTimeCode(core.OneTimeCode{
+		AccountID: account.ID,
+		Purpose:   purpose,
+		CodeHash:  hashCode(account.ID, purpose, code),
+		Expired:   time.Now().Add(a.cfg.OneTimeCode.TTL),
+	})
+	if err != nil {
+		return fmt.Errorf("can't store the code: %w", err)
+	}
+
+	if err := a.sms.Send(account.Phone, fmt.Sprintf(text, code)); err != nil {
+		return fmt.Errorf("can't send the code: %w", err)
+	}
+
+	return nil
+}
+
+// Checks the code sent to the phone and returns the account the code belongs to.
+// The unknown phone is reported in the same way as the wrong code.
+func (a AccountService) checkCode(phone string, purpose core.CodePurpose, code string) (string, error) {
+	account, err := a.storage.SelectAccountByPhone(phone)
+	if err != nil {
+		if errors.Is(err, core.ErrUserNotFound) {
+			return "", core.ErrInvalidCode
+		}
+
+		return "", fmt.Errorf("can't get the account: %w", err)
+	}
+
+	stored, err := a.storage.AddCodeAttempt(account.ID, purpose)
+	if err != nil {
+		if errors.Is(err, core.ErrCodeNotFound) {
+			return "", core.ErrInvalidCode
+		}
+
+		return "", fmt.Errorf("can't count the attempt: %w", err)
+	}
+
+	if stored.Attempts > a.cfg.OneTimeCode.MaxAttempts {
+		return "", core.ErrTooManyAttempts
+	}
+
+	if !time.Now().Before(stored.Expired) {
+		return "", core.ErrCodeExpired
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashCode(account.ID, purpose, code)), []byte(stored.CodeHash)) != 1 {
+		return "", core.ErrInvalidCode
+	}
+
+	return account.ID, nil
+}
+
+// Returns the random code of the decimal digits.
+func generateCode() (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(codeDigits), nil)
+
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", fmt.Errorf("can't generate the code: %w", err)
+	}
+
+	return fmt.Sprintf("%0*d", codeDigits, n), nil
+}
+
+// The hash is bound to the account and the purpose, so the same code of the other account
+// or of the other purpose has the different hash.
+func hashCode(accountID string, purpose core.CodePurpose, code string) string {
+	sum := sha256.Sum256([]byte(accountID + ":" + string(purpose) + ":" + code))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/config"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RequestPasswordReset(t *testing.T) {
+	const phone = "+380999999999"
+
+	account := core.Account{ID: "id-111", Phone: phone}
+
+	type mockBehavior func(s *MockAccountStorage, sms *MockSMSSender, sentCode *string)
+
+	testCasesTable := map[string]struct {
+		mockBehavior mockBehavior
+		wantSMS      bool
+	}{
+		"The code is sent": {
+			mockBehavior: func(s *MockAccountStorage, sms *MockSMSSender, sentCode *string) {
+				var storedHash string
+
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().SelectOneTimeCode("id-111", core.PasswordReset).Return(core.OneTimeCode{}, core.ErrCodeNotFound)
+				s.EXPECT().UpsertOneTimeCode(gomock.Any()).DoAndReturn(func(code core.OneTimeCode) error {
+					storedHash = code.CodeHash
+
+					return nil
+				})
+				sms.EXPECT().Send(phone, gomock.Any()).DoAndReturn(func(_, text string) error {
+					*sentCode = text[strings.LastIndex(text, " ")+1:]
+
+					assert.Equal(t, hashCode("id-111", core.PasswordReset, *sentCode), storedHash)
+
+					return nil
+				})
+			},
+			wantSMS: true,
+		},
+		"The code was sent recently": {
+			mockBehavior: func(s *MockAccountStorage, sms *MockSMSSender, sentCode *string) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().SelectOneTimeCode("id-111", core.PasswordReset).
+					Return(core.OneTimeCode{Created: time.Now().Add(-10 * time.Second)}, nil)
+			},
+		},
+		"Unknown phone is not reported": {
+			mockBehavior: func(s *MockAccountStorage, sms *MockSMSSender, sentCode *string) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(core.Account{}, core.ErrUserNotFound)
+			},
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var sentCode string
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			smsSender := NewMockSMSSender(ctrl)
+			testCase.mockBehavior(accountStorage, smsSender, &sentCode)
+
+			accountService := NewAccountService(accountStorage, nil, nil, smsSender, nil, nil, config.Config{
+				OneTimeCode: config.OneTimeCodeConfig{TTL: time.Minute, MaxAttempts: 3, ResendInterval: time.Minute},
+			})
+
+			err := accountService.RequestPasswordReset(phone)
+
+			assert.NoError(t, err)
+
+			if testCase.wantSMS {
+				assert.Len(t, sentCode, codeDigits)
+			}
+		})
+	}
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	const (
+		phone = "+380999999999"
+		code  = "123456"
+	)
+
+	account := core.Account{ID: "id-111", Phone: phone}
+	stored := core.OneTimeCode{
+		AccountID: "id-111",
+		Purpose:   core.PasswordReset,
+		CodeHash:  hashCode("id-111", core.PasswordReset, code),
+		Attempts:  1,
+		Expired:   time.Now().Add(time.Minute),
+	}
+
+	type mockBehavior func(s *MockAccountStorage, h *MockPasswordHasher)
+
+	testCasesTable := map[string]struct {
+		code                 string
+		mockBehavior         mockBehavior
+		expectedErrorMessage string
+		wantError            bool
+	}{
+		"Success": {
+			code: code,
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().AddCodeAttempt("id-111", core.PasswordReset).Return(stored, nil)
+				h.EXPECT().Hash("new-password").Return("new-hash", nil)
+				s.EXPECT().ResetPassword("id-111", "new-hash").Return(nil)
+			},
+		},
+		"Wrong code": {
+			code: "654321",
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().AddCodeAttempt("id-111", core.PasswordReset).Return(stored, nil)
+			},
+			expectedErrorMessage: "service ResetPassword got the error: the code is invalid",
+			wantError:            true,
+		},
+		"The code of another purpose": {
+			code: code,
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				verification := stored
+				verification.CodeHash = hashCode("id-111", core.PhoneVerification, code)
+
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().AddCodeAttempt("id-111", core.PasswordReset).Return(verification, nil)
+			},
+			expectedErrorMessage: "service ResetPassword got the error: the code is invalid",
+			wantError:            true,
+		},
+		"Too many attempts": {
+			code: code,
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				exhausted := stored
+				exhausted.Attempts = 4
+
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().AddCodeAttempt("id-111", core.PasswordReset).Return(exhausted, nil)
+			},
+			expectedErrorMessage: "service ResetPassword got the error: too many attempts, request a new code",
+			wantError:            true,
+		},
+		"Expired code": {
+			code: code,
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				expired := stored
+				expired.Expired = time.Now().Add(-time.Second)
+
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().AddCodeAttempt("id-111", core.PasswordReset).Return(expired, nil)
+			},
+			expectedErrorMessage: "service ResetPassword got the error: the code is expired",
+			wantError:            true,
+		},
+		"No code was requested": {
+			code: code,
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(account, nil)
+				s.EXPECT().AddCodeAttempt("id-111", core.PasswordReset).Return(core.OneTimeCode{}, core.ErrCodeNotFound)
+			},
+			expectedErrorMessage: "service ResetPassword got the error: the code is invalid",
+			wantError:            true,
+		},
+		"Unknown phone": {
+			code: code,
+			mockBehavior: func(s *MockAccountStorage, h *MockPasswordHasher) {
+				s.EXPECT().SelectAccountByPhone(phone).Return(core.Account{}, core.ErrUserNotFound)
+			},
+			expectedErrorMessage: "service ResetPassword got the error: the code is invalid",
+			wantError:            true,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountStorage := NewMockAccountStorage(ctrl)
+			passwordHasher := NewMockPasswordHasher(ctrl)
+			testCase.mockBehavior(accountStorage, passwordHasher)
+
+			accountService := NewAccountService(accountStorage, passwordHasher, nil, nil, nil, nil, config.Config{
+				OneTimeCode: config.OneTimeCodeConfig{TTL: time.Minute, MaxAttempts: 3, ResendInterval: time.Minute},
+			})
+
+			err := accountService.ResetPassword(phone, testCase.code, "new-password")
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedErrorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_LoginRequiresVerifiedPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountStorage := NewMockAccountStorage(ctrl)
+	passwordHasher := NewMockPasswordHasher(ctrl)
+
+	accountStorage.EXPECT().SelectAccountByPhone("+380999999999").
+		Return(core.Account{ID: "id-111", Password: "stored-hash", Role: core.RoleUser}, nil)
+	passwordHasher.EXPECT().Verify("qwerty123456", "stored-hash").Return(true, false, nil)
+
+	accountService := NewAccountService(accountStorage, passwordHasher, nil, nil, nil, nil, config.Config{
+		RequirePhoneVerification: true,
+	})
+
+	_, err := accountService.Login("+380999999999", "qwerty123456", core.Session{})
+
+	assert.ErrorIs(t, err, core.ErrPhoneNotVerified)
+}
@@ -0,0 +1,52 @@
+package sms
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSender_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sms.jsonl")
+	sent := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	sender := NewFileSender(path)
+	sender.now = func() time.Time { return sent }
+
+	if err := sender.Send("+380999999999", "code 123456"); err != nil {
+		t.FailNow()
+	}
+
+	if err := sender.Send("+380888888888", "code 654321"); err != nil {
+		t.FailNow()
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.FailNow()
+	}
+	defer file.Close()
+
+	var messages []Message
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message Message
+
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.FailNow()
+		}
+
+		messages = append(messages, message)
+	}
+
+	assert.Equal(t, []Message{
+		{Phone: "+380999999999", Text: "code 123456", Sent: sent},
+		{Phone: "+380888888888", Text: "code 654321", Sent: sent},
+	}, messages)
+}
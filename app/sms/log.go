@@ -0,0 +1,19 @@
+package sms
+
+import "github.com/Brigant/PetPorject/logger"
+
+// LogSender writes the messages to the log instead of sending them.
+// It is meant for the local development only, the codes are visible to anyone who reads the log.
+type LogSender struct {
+	log *logger.Logger
+}
+
+func NewLogSender(log *logger.Logger) LogSender {
+	return LogSender{log: log}
+}
+
+func (s LogSender) Send(phone, text string) error {
+	s.log.Infow("sms", "phone", phone, "text", text)
+
+	return nil
+}
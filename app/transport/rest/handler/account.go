@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/Brigant/PetPorject/app/core"
 	"github.com/Brigant/PetPorject/logger"
@@ -51,7 +53,7 @@ func (h AccountHandler) singUp(c *gin.Context) {
 
 	userID, err := h.service.CreateUser(account)
 	if err != nil {
-		if errors.Is(err, core.ErrDuplicatePhone) {
+		if errors.Is(err, core.ErrDuplicatePhone) || errors.Is(err, core.ErrPasswordTooLong) {
 			h.logger.Debugw("CreateUser", "error", err.Error())
 
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -90,6 +92,25 @@ func (h AccountHandler) login(c *gin.Context) {
 
 	tokenPair, err := h.service.Login(accInputData.Phone, accInputData.Password, session)
 	if err != nil {
+		var lockedErr core.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			h.logger.Warnw("security event: login is locked",
+				"phone", accInputData.Phone,
+				"clientIP", session.ClientIP,
+				"retryAfter", lockedErr.RetryAfter.String())
+			c.Header("Retry-After", retryAfterSeconds(lockedErr))
+			c.JSON(http.StatusTooManyRequests, core.ErrLoginLocked.Error())
+
+			return
+		}
+
+		if errors.Is(err, core.ErrWrongPassword) {
+			h.logger.Debugw("Login", "alert", err.Error())
+			c.JSON(http.StatusUnauthorized, err.Error())
+
+			return
+		}
+
 		if errors.Is(err, core.ErrUserNotFound) {
 			h.logger.Debugw("Login", "alert", err.Error())
 			c.JSON(http.StatusNotFound, err.Error())
@@ -97,6 +118,13 @@ func (h AccountHandler) login(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, core.ErrAccountDisabled) || errors.Is(err, core.ErrPhoneNotVerified) {
+			h.logger.Debugw("Login", "alert", err.Error())
+			c.JSON(http.StatusForbidden, err.Error())
+
+			return
+		}
+
 		h.logger.Errorw("Login", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, err.Error())
 
@@ -136,6 +164,16 @@ func (h AccountHandler) refreshToken(c *gin.Context) {
 
 	tokenPair, err := h.service.RefreshTokenpair(session)
 	if err != nil {
+		if errors.Is(err, core.ErrRefreshTokenReused) {
+			h.logger.Warnw("security event: refresh token reuse",
+				"error", err.Error(),
+				"clientIP", session.ClientIP,
+				"userAgent", session.UserAgent)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": core.ErrRefreshTokenReused.Error()})
+
+			return
+		}
+
 		h.logger.Errorw("error happened while RefreshTokenpair", "error", err.Error())
 
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -146,7 +184,7 @@ func (h AccountHandler) refreshToken(c *gin.Context) {
 	c.JSON(http.StatusOK, tokenPair)
 }
 
-// Delete all accounts sessions.
+// Deletes the session of the access token.
 func (h AccountHandler) logout(c *gin.Context) {
 	userID, ok := c.Get("userID")
 	if !ok {
@@ -163,7 +201,7 @@ func (h AccountHandler) logout(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Logout(accountID); err != nil {
+	if err := h.service.Logout(accountID, c.GetString(refreshTokenCtx)); err != nil {
 		h.logger.Errorw("logout", "error", err.Error())
 
 		if errors.Is(err, core.ErrNoRowsEffected) {
@@ -179,3 +217,75 @@ func (h AccountHandler) logout(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"action": "successful"})
 }
+
+// Returns the active sessions of the account.
+func (h AccountHandler) sessions(c *gin.Context) {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("sessions", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	sessions, err := h.service.Sessions(accountID, c.GetString(refreshTokenCtx))
+	if err != nil {
+		h.logger.Errorw("sessions", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// Revokes the session of the account by its id.
+func (h AccountHandler) revokeSession(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if _, err := uuid.Parse(sessionID); err != nil {
+		h.logger.Debugw("revokeSession", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("revokeSession", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.RevokeSession(accountID, sessionID); err != nil {
+		if errors.Is(err, core.ErrSesseionNotFound) {
+			h.logger.Debugw("revokeSession", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("revokeSession", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Publishes the public keys the access tokens can be verified with.
+func (h AccountHandler) jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.service.JWKS())
+}
+
+// Returns the value of the Retry-After header for the locked login: the seconds rounded up.
+func retryAfterSeconds(err error) string {
+	var lockedErr core.LoginLockedError
+
+	errors.As(err, &lockedErr)
+
+	return strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds())))
+}
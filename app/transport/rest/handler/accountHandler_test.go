@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -41,7 +42,6 @@ func TestAccountHandler_singUp(t *testing.T) {
 				Phone:    "+399999999",
 				Password: "password1234",
 				Age:      15,
-				Role:     "admin",
 			},
 			mockBehavior: func(s *MockAccountService, account core.Account) {
 				s.EXPECT().CreateUser(account).Return("bla-bla-bla", nil)
@@ -114,21 +114,19 @@ func TestAccountHandler_singUp(t *testing.T) {
 			expectedStatusCode:  400,
 			expectedRequestBody: `{"error":"Key: 'Account.Age' Error:Field validation for 'Age' failed on the 'lte' tag"}`,
 		},
-		"Role not in lowercase": {
-			logger:              log,
-			inputBody:           `{"phone":"+399999999","password":"123456789","age":30,"role":"Admin"}`,
-			account:             core.Account{},
-			mockBehavior:        func(s *MockAccountService, account core.Account) {},
-			expectedStatusCode:  400,
-			expectedRequestBody: `{"error":"Key: 'Account.Role' Error:Field validation for 'Role' failed on the 'lowercase' tag"}`,
-		},
-		"Not available role": {
-			logger:              log,
-			inputBody:           `{"phone":"+399999999","password":"123456789","age":30,"role":"superuser"}`,
-			account:             core.Account{},
-			mockBehavior:        func(s *MockAccountService, account core.Account) {},
-			expectedStatusCode:  400,
-			expectedRequestBody: `{"error":"Key: 'Account.Role' Error:Field validation for 'Role' failed on the 'checkRole' tag"}`,
+		"Role is ignored": {
+			logger:    log,
+			inputBody: `{"phone":"+399999999","password":"123456789","age":30,"role":"superuser"}`,
+			account: core.Account{
+				Phone:    "+399999999",
+				Password: "123456789",
+				Age:      30,
+			},
+			mockBehavior: func(s *MockAccountService, account core.Account) {
+				s.EXPECT().CreateUser(account).Return("bla-bla-bla", nil)
+			},
+			expectedStatusCode:  201,
+			expectedRequestBody: `{"userID":"bla-bla-bla"}`,
 		},
 		"service Failure": {
 			logger:    log,
@@ -137,7 +135,6 @@ func TestAccountHandler_singUp(t *testing.T) {
 				Phone:    "+399999999",
 				Password: "password1234",
 				Age:      15,
-				Role:     "admin",
 			},
 			mockBehavior: func(s *MockAccountService, account core.Account) {
 				s.EXPECT().CreateUser(account).Return("", errors.New("service failure"))
@@ -166,7 +163,7 @@ func TestAccountHandler_singUp(t *testing.T) {
 			r := gin.New()
 
 			if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-				if err := v.RegisterValidation("checkRole", checkRoleFunc); err != nil {
+				if err := v.RegisterValidation("checkRole", checkRoleFunc(core.DefaultRolePermissions())); err != nil {
 					ah.logger.Errorw("bind validator", "err", errValidatorBind.Error())
 				}
 			}
@@ -206,6 +203,7 @@ func TestAccountHendler_login(t *testing.T) {
 		session             core.Session
 		mockBehavior        mockBehavior
 		expectedStatusCode  int
+		expectedRetryAfter  string
 		expectedRequestBody string
 	}{
 		"Success": {
@@ -223,10 +221,11 @@ func TestAccountHendler_login(t *testing.T) {
 				s.EXPECT().Login(phone, password, session).Return(core.TokenPair{
 					AccessToken:  "SomeAccesToken",
 					RefreshToken: "SomeRefreshToken",
+					ExpiresIn:    600,
 				}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"AccessToken":"SomeAccesToken","RefreshToken":"SomeRefreshToken"}`,
+			expectedRequestBody: `{"AccessToken":"SomeAccesToken","RefreshToken":"SomeRefreshToken","expires_in":600}`,
 		},
 		"Wrong request body": {
 			logger:    log,
@@ -294,6 +293,32 @@ func TestAccountHendler_login(t *testing.T) {
 			expectedStatusCode:  400,
 			expectedRequestBody: `{"error":"Key: 'inputAccountData.Password' Error:Field validation for 'Password' failed on the 'ascii' tag"}`,
 		},
+		"Wrong password": {
+			logger:    log,
+			inputBody: `{"phone":"+399999999","password":"password1234"}`,
+			phone:     "+399999999",
+			password:  "password1234",
+			session:   core.Session{},
+			mockBehavior: func(s *MockAccountService, phone, password string, c *gin.Context) {
+				s.EXPECT().Login(phone, password, gomock.Any()).Return(core.TokenPair{}, core.ErrWrongPassword)
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `"wrong passord"`,
+		},
+		"Locked login": {
+			logger:    log,
+			inputBody: `{"phone":"+399999999","password":"password1234"}`,
+			phone:     "+399999999",
+			password:  "password1234",
+			session:   core.Session{},
+			mockBehavior: func(s *MockAccountService, phone, password string, c *gin.Context) {
+				s.EXPECT().Login(phone, password, gomock.Any()).Return(core.TokenPair{},
+					fmt.Errorf("service Login got the error: %w", core.LoginLockedError{RetryAfter: 89500 * time.Millisecond}))
+			},
+			expectedStatusCode:  429,
+			expectedRetryAfter:  "90",
+			expectedRequestBody: `"too many failed login attempts"`,
+		},
 	}
 
 	for name, testCase := range testCasesTable {
@@ -331,6 +356,65 @@ func TestAccountHendler_login(t *testing.T) {
 			// Assert
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedRequestBody, w.Body.String())
+			assert.Equal(t, testCase.expectedRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestAccountHandler_loginClientIP(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	testCasesTable := map[string]struct {
+		trustedProxies   []string
+		forwardedFor     string
+		expectedClientIP string
+	}{
+		"Spoofed header without trusted proxies": {
+			forwardedFor:     "203.0.113.7",
+			expectedClientIP: "10.0.0.1",
+		},
+		"Spoofed header from the untrusted proxy": {
+			trustedProxies:   []string{"192.168.0.0/16"},
+			forwardedFor:     "203.0.113.7",
+			expectedClientIP: "10.0.0.1",
+		},
+		"Header from the trusted proxy": {
+			trustedProxies:   []string{"10.0.0.0/8"},
+			forwardedFor:     "203.0.113.7",
+			expectedClientIP: "203.0.113.7",
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountService := NewMockAccountService(ctrl)
+			accountService.EXPECT().Login("+399999999", "password1234", gomock.Any()).
+				DoAndReturn(func(phone, password string, session core.Session) (core.TokenPair, error) {
+					// The login throttle counts the failures by this IP.
+					assert.Equal(t, testCase.expectedClientIP, session.ClientIP)
+
+					return core.TokenPair{}, nil
+				})
+
+			handler := NewHandler(Deps{AccountService: accountService, TrustedProxies: testCase.trustedProxies}, log)
+			router := handler.InitRouter(gin.TestMode)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/auth/login",
+				bytes.NewBufferString(`{"phone":"+399999999","password":"password1234"}`))
+			req.RemoteAddr = "10.0.0.1:51000"
+			req.Header.Set("X-Forwarded-For", testCase.forwardedFor)
+			req.Header.Set("X-Real-IP", testCase.forwardedFor)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
 		})
 	}
 }
@@ -360,10 +444,22 @@ func TestAccountHendler_refreshToken(t *testing.T) {
 					core.TokenPair{
 						AccessToken:  "SomeAccesToken",
 						RefreshToken: "SomeRefreshToken",
+						ExpiresIn:    600,
 					}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"AccessToken":"SomeAccesToken","RefreshToken":"SomeRefreshToken"}`,
+			expectedRequestBody: `{"AccessToken":"SomeAccesToken","RefreshToken":"SomeRefreshToken","expires_in":600}`,
+		},
+		"Reused refresh token": {
+			logger:       log,
+			inputBody:    `{"RefreshToken": "fc182364-7122-4d4b-bd95-552b716224e2"}`,
+			refreshToken: "fc182364-7122-4d4b-bd95-552b716224e2",
+			mockBehavior: func(s *MockAccountService, session core.Session) {
+				s.EXPECT().RefreshTokenpair(session).Return(core.TokenPair{},
+					fmt.Errorf("%w: the token family family-1 of the account id-1 is revoked", core.ErrRefreshTokenReused))
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"error":"refresh token has already been used"}`,
 		},
 		"Bad refresh token": {
 			logger:              log,
@@ -453,7 +549,7 @@ func TestAccountHandler_logout(t *testing.T) {
 		"Successful logout": {
 			logger: log,
 			mockBehavior: func(s *MockAccountService) {
-				s.EXPECT().Logout(gomock.Any()).Return(nil)
+				s.EXPECT().Logout("accountID", "refresh-111").Return(nil)
 			},
 			ctxKey:               userCtx,
 			ctxVal:               "accountID",
@@ -480,7 +576,7 @@ func TestAccountHandler_logout(t *testing.T) {
 		"Already logouted": {
 			logger: log,
 			mockBehavior: func(s *MockAccountService) {
-				s.EXPECT().Logout(gomock.Any()).Return(core.ErrNoRowsEffected)
+				s.EXPECT().Logout("accountID", "refresh-111").Return(core.ErrNoRowsEffected)
 			},
 			ctxKey:               userCtx,
 			ctxVal:               "accountID",
@@ -490,7 +586,7 @@ func TestAccountHandler_logout(t *testing.T) {
 		"Some internal error": {
 			logger: log,
 			mockBehavior: func(s *MockAccountService) {
-				s.EXPECT().Logout(gomock.Any()).Return(errors.New("some internal error"))
+				s.EXPECT().Logout("accountID", "refresh-111").Return(errors.New("some internal error"))
 			},
 			ctxKey:               userCtx,
 			ctxVal:               "accountID",
@@ -519,6 +615,7 @@ func TestAccountHandler_logout(t *testing.T) {
 
 				c, _ := gin.CreateTestContext(w)
 				c.Set(testCase.ctxKey, testCase.ctxVal)
+				c.Set(refreshTokenCtx, "refresh-111")
 
 				accountHandler.logout(c)
 
@@ -528,3 +625,138 @@ func TestAccountHandler_logout(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountHandler_sessions(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		accountID = "2e6a2b3e-0000-4000-8000-000000000001"
+		sessionID = "2e6a2b3e-0000-4000-8000-000000000002"
+	)
+
+	created := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(s *MockAccountService)
+
+	testCasesTable := map[string]struct {
+		method               string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"List sessions": {
+			method: http.MethodGet,
+			path:   "/auth/sessions",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().Sessions(accountID, "refresh-111").Return([]core.SessionInfo{{
+					ID: sessionID, RequestHost: "example.com", UserAgent: "curl", ClientIP: "10.0.0.1",
+					Created: created, Expired: created.Add(time.Hour), Current: true,
+				}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `[{"id":"` + sessionID + `","request_host":"example.com","user_agent":"curl",` +
+				`"client_ip":"10.0.0.1","created":"2023-05-01T10:00:00Z","expired":"2023-05-01T11:00:00Z",` +
+				`"current":true}]`,
+		},
+		"List sessions failed": {
+			method: http.MethodGet,
+			path:   "/auth/sessions",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().Sessions(accountID, "refresh-111").Return(nil, errors.New("db is down"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"db is down"}`,
+		},
+		"Revoke session": {
+			method: http.MethodDelete,
+			path:   "/auth/sessions/" + sessionID,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().RevokeSession(accountID, sessionID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Revoke the session of another account": {
+			method: http.MethodDelete,
+			path:   "/auth/sessions/" + sessionID,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().RevokeSession(accountID, sessionID).Return(core.ErrSesseionNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"session is not found with such credentials"}`,
+		},
+		"Revoke session with wrong id": {
+			method:               http.MethodDelete,
+			path:                 "/auth/sessions/wrong-id",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountService := NewMockAccountService(ctrl)
+			testCase.mockBehavior(accountService)
+
+			accountHandler := AccountHandler{service: accountService, logger: log}
+
+			setIdentity := func(c *gin.Context) {
+				c.Set(userCtx, accountID)
+				c.Set(refreshTokenCtx, "refresh-111")
+			}
+
+			router := gin.New()
+			router.GET("/auth/sessions", setIdentity, accountHandler.sessions)
+			router.DELETE("/auth/sessions/:id", setIdentity, accountHandler.revokeSession)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestAccountHandler_jwks(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountService := NewMockAccountService(ctrl)
+	accountService.EXPECT().JWKS().Return(core.JSONWebKeySet{Keys: []core.JSONWebKey{{
+		KeyType: "OKP", KeyID: "ed-1", Algorithm: "EdDSA", Use: "sig", Curve: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg",
+	}}})
+
+	handler := NewHandler(Deps{AccountService: accountService}, log)
+	router := handler.InitRouter(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	assert.Equal(t,
+		`{"keys":[{"kty":"OKP","kid":"ed-1","alg":"EdDSA","use":"sig","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg"}]}`,
+		w.Body.String())
+}
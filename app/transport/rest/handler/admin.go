@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errInvalidIP = errors.New("invalid IP address")
+
+type inputRole struct {
+	Role string `json:"role" binding:"required,checkRole"`
+}
+
+// Returns the accounts filtered by the phone, role or disabled flag.
+func (h AccountHandler) listAccounts(c *gin.Context) {
+	var queryParameter core.ConditionParams
+
+	if err := queryParameter.Prepare(c); err != nil {
+		h.logger.Debugw("Prepare query params", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	accounts, err := h.service.ListAccounts(queryParameter)
+	if err != nil {
+		h.respondAdminError(c, "ListAccounts", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+func (h AccountHandler) getAccount(c *gin.Context) {
+	accountID, ok := h.parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.service.GetAccount(accountID)
+	if err != nil {
+		h.respondAdminError(c, "GetAccount", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// Promotes or demotes the account.
+func (h AccountHandler) changeRole(c *gin.Context) {
+	accountID, ok := h.parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var input inputRole
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	actorID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("changeRole", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	account, err := h.service.ChangeRole(actorID, accountID, input.Role)
+	if err != nil {
+		h.respondAdminError(c, "ChangeRole", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h AccountHandler) disableAccount(c *gin.Context) {
+	accountID, ok := h.parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	actorID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("disableAccount", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	account, err := h.service.DisableAccount(actorID, accountID)
+	if err != nil {
+		h.respondAdminError(c, "DisableAccount", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h AccountHandler) enableAccount(c *gin.Context) {
+	accountID, ok := h.parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.service.EnableAccount(accountID)
+	if err != nil {
+		h.respondAdminError(c, "EnableAccount", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// Revokes all the sessions of the account.
+func (h AccountHandler) forceLogout(c *gin.Context) {
+	accountID, ok := h.parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ForceLogout(accountID); err != nil {
+		h.respondAdminError(c, "ForceLogout", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Unlocks the login of the account locked after the failed attempts.
+func (h AccountHandler) clearLoginLockout(c *gin.Context) {
+	accountID, ok := h.parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ClearLoginLockout(accountID); err != nil {
+		h.respondAdminError(c, "ClearLoginLockout", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Unlocks the login from the client IP locked after the failed attempts.
+func (h AccountHandler) clearIPLockout(c *gin.Context) {
+	ip := net.ParseIP(c.Param("ip"))
+	if ip == nil {
+		h.logger.Debugw("clearIPLockout", "error", errInvalidIP.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidIP.Error()})
+
+		return
+	}
+
+	if err := h.service.ClearIPLockout(ip.String()); err != nil {
+		h.respondAdminError(c, "ClearIPLockout", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Takes the account ID from the path. Writes the response and returns false if it is not UUID.
+func (h AccountHandler) parseAccountID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.logger.Debugw("ID is not UUID", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", false
+	}
+
+	return id, true
+}
+
+// Writes the response with the status code which corresponds to the error of the account management.
+func (h AccountHandler) respondAdminError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, core.ErrUserNotFound):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrSelfManagement):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrUnkownConditionKey):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorw(operation, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHandler_admin(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		adminID   = "2e6a2b3e-0000-4000-8000-000000000001"
+		accountID = "2e6a2b3e-0000-4000-8000-000000000002"
+	)
+
+	created := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	account := core.AccountInfo{
+		ID: accountID, Phone: "+380999999999", Age: 30, Role: core.RoleUser, Created: created, Modified: created,
+	}
+	accountJSON := `{"id":"` + accountID + `","phone":"+380999999999","age":30,"role":"user","disabled":false,` +
+		`"phone_verified":false,"created":"2023-05-01T10:00:00Z","modified":"2023-05-01T10:00:00Z"}`
+
+	type mockBehavior func(s *MockAccountService)
+
+	testCasesTable := map[string]struct {
+		method               string
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"List accounts": {
+			method: http.MethodGet,
+			path:   "/admin/accounts?f=role:user&s=created:desc",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ListAccounts(gomock.Any()).Return([]core.AccountInfo{account}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `[` + accountJSON + `]`,
+		},
+		"List accounts with wrong filter": {
+			method:               http.MethodGet,
+			path:                 "/admin/accounts?f=disabled:maybe",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"query preparetion failed: the disabled value should be a boolean: unallowed filter value"}`,
+		},
+		"List accounts with the filter without the value": {
+			method:               http.MethodGet,
+			path:                 "/admin/accounts?f=role",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"query preparetion failed: \"role\": the filter and the sort should be in the key:value form"}`,
+		},
+		"List accounts with the sort without the order": {
+			method:               http.MethodGet,
+			path:                 "/admin/accounts?s=created",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"query preparetion failed: \"created\": the filter and the sort should be in the key:value form"}`,
+		},
+		"Get account": {
+			method: http.MethodGet,
+			path:   "/admin/accounts/" + accountID,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().GetAccount(accountID).Return(account, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: accountJSON,
+		},
+		"Get not existing account": {
+			method: http.MethodGet,
+			path:   "/admin/accounts/" + accountID,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().GetAccount(accountID).Return(core.AccountInfo{}, core.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"user is not found with such credentials"}`,
+		},
+		"Get account with wrong id": {
+			method:               http.MethodGet,
+			path:                 "/admin/accounts/wrong-id",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"Change role": {
+			method:    http.MethodPatch,
+			path:      "/admin/accounts/" + accountID + "/role",
+			inputBody: `{"role":"user"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ChangeRole(adminID, accountID, core.RoleUser).Return(account, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: accountJSON,
+		},
+		"Change role to editor": {
+			method:    http.MethodPatch,
+			path:      "/admin/accounts/" + accountID + "/role",
+			inputBody: `{"role":"editor"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ChangeRole(adminID, accountID, core.RoleEditor).Return(account, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: accountJSON,
+		},
+		"Change to not available role": {
+			method:               http.MethodPatch,
+			path:                 "/admin/accounts/" + accountID + "/role",
+			inputBody:            `{"role":"superuser"}`,
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputRole.Role' Error:Field validation for 'Role' failed on the 'checkRole' tag"}`,
+		},
+		"Change the own role": {
+			method:    http.MethodPatch,
+			path:      "/admin/accounts/" + adminID + "/role",
+			inputBody: `{"role":"user"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ChangeRole(adminID, adminID, core.RoleUser).Return(core.AccountInfo{}, core.ErrSelfManagement)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"the admin can't change the own role or disable the own account"}`,
+		},
+		"Disable account": {
+			method: http.MethodPost,
+			path:   "/admin/accounts/" + accountID + "/disable",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().DisableAccount(adminID, accountID).Return(account, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: accountJSON,
+		},
+		"Enable account failed": {
+			method: http.MethodPost,
+			path:   "/admin/accounts/" + accountID + "/enable",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().EnableAccount(accountID).Return(core.AccountInfo{}, errors.New("db is down"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"db is down"}`,
+		},
+		"Force logout": {
+			method: http.MethodDelete,
+			path:   "/admin/accounts/" + accountID + "/sessions",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ForceLogout(accountID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Clear login lockout": {
+			method: http.MethodDelete,
+			path:   "/admin/accounts/" + accountID + "/lockout",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ClearLoginLockout(accountID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Clear login lockout of not existing account": {
+			method: http.MethodDelete,
+			path:   "/admin/accounts/" + accountID + "/lockout",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ClearLoginLockout(accountID).Return(core.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"user is not found with such credentials"}`,
+		},
+		"Clear IP lockout": {
+			method: http.MethodDelete,
+			path:   "/admin/lockouts/ip/2001:db8:0:0:0:0:0:1",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ClearIPLockout("2001:db8::1").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Clear lockout of invalid IP": {
+			method:               http.MethodDelete,
+			path:                 "/admin/lockouts/ip/10.0.0",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"` + errInvalidIP.Error() + `"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountService := NewMockAccountService(ctrl)
+			testCase.mockBehavior(accountService)
+
+			accountHandler := AccountHandler{service: accountService, logger: log}
+
+			if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+				if err := v.RegisterValidation("checkRole", checkRoleFunc(core.DefaultRolePermissions())); err != nil {
+					t.FailNow()
+				}
+			}
+
+			setIdentity := func(c *gin.Context) {
+				c.Set(userCtx, adminID)
+			}
+
+			router := gin.New()
+			admin := router.Group("/admin", setIdentity)
+			admin.GET("/accounts", accountHandler.listAccounts)
+			admin.GET("/accounts/:id", accountHandler.getAccount)
+			admin.PATCH("/accounts/:id/role", accountHandler.changeRole)
+			admin.POST("/accounts/:id/disable", accountHandler.disableAccount)
+			admin.POST("/accounts/:id/enable", accountHandler.enableAccount)
+			admin.DELETE("/accounts/:id/sessions", accountHandler.forceLogout)
+			admin.DELETE("/accounts/:id/lockout", accountHandler.clearLoginLockout)
+			admin.DELETE("/lockouts/ip/:ip", accountHandler.clearIPLockout)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.inputBody))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type APIKeyHandler struct {
+	service APIKeyService
+	logger  *logger.Logger
+}
+
+func NewAPIKeyHandler(s APIKeyService, log *logger.Logger) APIKeyHandler {
+	return APIKeyHandler{
+		service: s,
+		logger:  log,
+	}
+}
+
+type inputAPIKey struct {
+	Name    string            `json:"name" binding:"required,max=100"`
+	Scopes  []core.Permission `json:"scopes"`
+	Expires *time.Time        `json:"expires"`
+}
+
+// Creates the API key. The body example: {"name": "recommendations", "scopes": ["catalogue:read"], "expires": "2024-01-01T00:00:00Z"}.
+// The key is in the response only, it can't be got again.
+func (h APIKeyHandler) create(c *gin.Context) {
+	var input inputAPIKey
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	actorID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("create api key", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	key, err := h.service.Create(actorID, core.APIKeyInput{
+		Name:    input.Name,
+		Scopes:  input.Scopes,
+		Expires: input.Expires,
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrUnknownPermission) || errors.Is(err, core.ErrScopeNotAllowed) ||
+			errors.Is(err, core.ErrExpiryInThePast) || errors.Is(err, core.ErrNoScopes) {
+			h.logger.Debugw("Create api key", "error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("Create api key", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	h.logger.Infow("api key is created", "id", key.ID, "name", key.Name, "createdBy", actorID)
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// Returns all the keys without their secrets.
+func (h APIKeyHandler) list(c *gin.Context) {
+	keys, err := h.service.List()
+	if err != nil {
+		h.logger.Errorw("List api keys", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+func (h APIKeyHandler) revoke(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.logger.Debugw("ID is not UUID", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.Revoke(id); err != nil {
+		if errors.Is(err, core.ErrAPIKeyNotFound) {
+			h.logger.Debugw("Revoke api key", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("Revoke api key", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	h.logger.Infow("api key is revoked", "id", id)
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
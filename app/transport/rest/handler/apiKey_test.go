@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyHandler(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		adminID = "2e6a2b3e-0000-4000-8000-000000000001"
+		keyID   = "2e6a2b3e-0000-4000-8000-0000000000a1"
+	)
+
+	created := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	expires := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	key := core.APIKey{
+		ID: keyID, Name: "recommendations", Prefix: "ppk_abcdefgh", Scopes: []core.Permission{core.PermMovieWrite},
+		CreatedBy: adminID, Expires: &expires, Created: created,
+	}
+	keyJSON := `"id":"` + keyID + `","name":"recommendations","prefix":"ppk_abcdefgh","scopes":["movie:write"],` +
+		`"created_by":"` + adminID + `","expires":"2024-05-01T10:00:00Z","last_used":null,"revoked":null,` +
+		`"created":"2023-05-01T10:00:00Z"`
+
+	type mockBehavior func(s *MockAPIKeyService)
+
+	testCasesTable := map[string]struct {
+		method               string
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Create": {
+			method:    http.MethodPost,
+			path:      "/admin/api-keys",
+			inputBody: `{"name":"recommendations","scopes":["movie:write"],"expires":"2024-05-01T10:00:00Z"}`,
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().Create(adminID, core.APIKeyInput{
+					Name: "recommendations", Scopes: []core.Permission{core.PermMovieWrite}, Expires: &expires,
+				}).Return(core.IssuedAPIKey{APIKey: key, Key: "ppk_abcdefgh-secret"}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{` + keyJSON + `,"key":"ppk_abcdefgh-secret"}`,
+		},
+		"Create without name": {
+			method:               http.MethodPost,
+			path:                 "/admin/api-keys",
+			inputBody:            `{"scopes":[]}`,
+			mockBehavior:         func(s *MockAPIKeyService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputAPIKey.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+		},
+		"Create without scopes": {
+			method:    http.MethodPost,
+			path:      "/admin/api-keys",
+			inputBody: `{"name":"dashboard"}`,
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().Create(adminID, core.APIKeyInput{Name: "dashboard"}).
+					Return(core.IssuedAPIKey{}, fmt.Errorf("api key validation failed: %w", core.ErrNoScopes))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"api key validation failed: the api key should be granted at least one scope"}`,
+		},
+		"Create with empty scopes": {
+			method:    http.MethodPost,
+			path:      "/admin/api-keys",
+			inputBody: `{"name":"dashboard","scopes":[]}`,
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().Create(adminID, core.APIKeyInput{Name: "dashboard", Scopes: []core.Permission{}}).
+					Return(core.IssuedAPIKey{}, fmt.Errorf("api key validation failed: %w", core.ErrNoScopes))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"api key validation failed: the api key should be granted at least one scope"}`,
+		},
+		"Create with not allowed scope": {
+			method:    http.MethodPost,
+			path:      "/admin/api-keys",
+			inputBody: `{"name":"dashboard","scopes":["account:manage"]}`,
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().Create(adminID, core.APIKeyInput{
+					Name: "dashboard", Scopes: []core.Permission{core.PermAccountManage},
+				}).Return(core.IssuedAPIKey{}, fmt.Errorf("api key validation failed: %w: %q",
+					core.ErrScopeNotAllowed, core.PermAccountManage))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponseBody: `{"error":"api key validation failed: ` +
+				`the permission can't be granted to the api key: \"account:manage\""}`,
+		},
+		"List": {
+			method: http.MethodGet,
+			path:   "/admin/api-keys",
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().List().Return([]core.APIKey{key}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `[{` + keyJSON + `}]`,
+		},
+		"List failed": {
+			method: http.MethodGet,
+			path:   "/admin/api-keys",
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().List().Return(nil, errors.New("db is down"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"db is down"}`,
+		},
+		"Revoke": {
+			method: http.MethodDelete,
+			path:   "/admin/api-keys/" + keyID,
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().Revoke(keyID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Revoke not existing key": {
+			method: http.MethodDelete,
+			path:   "/admin/api-keys/" + keyID,
+			mockBehavior: func(s *MockAPIKeyService) {
+				s.EXPECT().Revoke(keyID).Return(core.ErrAPIKeyNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"api key is not found"}`,
+		},
+		"Revoke with wrong id": {
+			method:               http.MethodDelete,
+			path:                 "/admin/api-keys/wrong-id",
+			mockBehavior:         func(s *MockAPIKeyService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyService := NewMockAPIKeyService(ctrl)
+			testCase.mockBehavior(apiKeyService)
+
+			apiKeyHandler := NewAPIKeyHandler(apiKeyService, log)
+
+			setIdentity := func(c *gin.Context) {
+				c.Set(userCtx, adminID)
+			}
+
+			router := gin.New()
+			admin := router.Group("/admin", setIdentity)
+			admin.POST("/api-keys", apiKeyHandler.create)
+			admin.GET("/api-keys", apiKeyHandler.list)
+			admin.DELETE("/api-keys/:id", apiKeyHandler.revoke)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.inputBody))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestAPIKey_catalogueRead(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const movieID = "2e6a2b3e-0000-4000-8000-000000000002"
+
+	type mockBehavior func(k *MockAPIKeyService, m *MockMovieService)
+
+	testCasesTable := map[string]struct {
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		"Key with the read scope": {
+			mockBehavior: func(k *MockAPIKeyService, m *MockMovieService) {
+				k.EXPECT().Authenticate("ppk_secret").
+					Return(core.APIKey{ID: "key-111", Scopes: []core.Permission{core.PermCatalogueRead}}, nil)
+				m.EXPECT().Get(movieID).Return(core.Movie{ID: movieID}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		"Key without scopes is refused": {
+			mockBehavior: func(k *MockAPIKeyService, m *MockMovieService) {
+				k.EXPECT().Authenticate("ppk_secret").Return(core.APIKey{ID: "key-111"}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"Key with only the write scope is refused": {
+			mockBehavior: func(k *MockAPIKeyService, m *MockMovieService) {
+				k.EXPECT().Authenticate("ppk_secret").
+					Return(core.APIKey{ID: "key-111", Scopes: []core.Permission{core.PermMovieWrite}}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyService := NewMockAPIKeyService(ctrl)
+			movieService := NewMockMovieService(ctrl)
+			testCase.mockBehavior(apiKeyService, movieService)
+
+			handler := NewHandler(Deps{
+				APIKeyService: apiKeyService,
+				MovieService:  movieService,
+				Roles:         core.DefaultRolePermissions(),
+			}, log)
+			router := handler.InitRouter(gin.TestMode)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/movie/"+movieID, nil)
+			req.Header.Set("Authorization", "ApiKey ppk_secret")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
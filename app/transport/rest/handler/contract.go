@@ -7,15 +7,41 @@ import "github.com/Brigant/PetPorject/app/core"
 type AccountService interface {
 	CreateUser(account core.Account) (id string, err error)
 	Login(login, password string, session core.Session) (core.TokenPair, error)
-	ParseToken(string) (string, string, error)
+	ParseToken(string) (core.Identity, error)
 	RefreshTokenpair(session core.Session) (core.TokenPair, error)
-	Logout(accountID string) error
+	Logout(accountID, refreshToken string) error
+	Sessions(accountID, currentRefreshToken string) ([]core.SessionInfo, error)
+	RevokeSession(accountID, sessionID string) error
+	JWKS() core.JSONWebKeySet
+	ListAccounts(qp core.ConditionParams) ([]core.AccountInfo, error)
+	GetAccount(accountID string) (core.AccountInfo, error)
+	ChangeRole(actorID, accountID, role string) (core.AccountInfo, error)
+	DisableAccount(actorID, accountID string) (core.AccountInfo, error)
+	EnableAccount(accountID string) (core.AccountInfo, error)
+	ForceLogout(accountID string) error
+	Profile(accountID string) (core.AccountInfo, error)
+	UpdateAge(accountID string, age int) (core.AccountInfo, error)
+	ChangePassword(accountID, currentRefreshToken, currentPassword, newPassword string) error
+	DeleteAccount(accountID, password string) (core.ErasureReport, error)
+	ExportAccount(accountID string) (core.AccountExport, error)
+	RequestPhoneVerification(phone string) error
+	ConfirmPhone(phone, code string) error
+	RequestPasswordReset(phone string) error
+	ResetPassword(phone, code, newPassword string) error
+	ClearLoginLockout(accountID string) error
+	ClearIPLockout(clientIP string) error
+	StartOIDCLogin() (string, core.OIDCLogin, error)
+	FinishOIDCLogin(code, state string, session core.Session) (core.TokenPair, error)
 }
 
 type DirectorService interface {
 	CreateDirector(director core.Director) error
 	GetDirectorWithID(directorID string) (core.Director, error)
-	GetDirectorList() ([]core.Director, error)
+	GetDirectorList(qp core.ConditionParams) ([]core.Director, error)
+	UpdateDirector(director core.Director) (string, error)
+	DeleteDirector(directorID string) error
+	UploadDirectorPhoto(directorID string, photo core.Photo) (string, error)
+	GetDirectorMovies(directorID string, qp core.ConditionParams) ([]core.Movie, error)
 }
 
 type MovieService interface {
@@ -23,10 +49,31 @@ type MovieService interface {
 	Get(movieID string) (core.Movie, error)
 	GetList(core.ConditionParams) ([]core.Movie, error)
 	GetCSV(core.ConditionParams) ([]core.MovieCSV, error)
+	UpdateMovie(movie core.Movie) (string, error)
+	DeleteMovie(movieID string, cascade bool) (int, error)
 }
 
 type ListsService interface {
 	Create(list core.MovieList) (string, error)
 	GetAllAccountLists([]core.QuerySliceElement) ([]core.MovieList, error)
-	AddMovieToList(movieID, listID string) error
+	AddMovieToList(accountID, listID, movieID string) error
+	GetListWithMovies(listID, accountID string, qp core.ConditionParams) (core.MovieListDetails, error)
+	RemoveMovieFromList(accountID, listID, movieID string) error
+	Delete(accountID, listID string) error
+	Rename(accountID, listID, listType string) (core.MovieList, error)
+	AddMovieToSystemList(accountID string, listType core.ListType, movieID string) error
+	RemoveMovieFromSystemList(accountID string, listType core.ListType, movieID string) error
+}
+
+type RatingService interface {
+	Get(accountID, movieID string) (core.Rating, error)
+	Rate(rating core.Rating) (core.Rating, error)
+	Delete(accountID, movieID string) error
+}
+
+type APIKeyService interface {
+	Create(actorID string, input core.APIKeyInput) (core.IssuedAPIKey, error)
+	List() ([]core.APIKey, error)
+	Revoke(id string) error
+	Authenticate(secret string) (core.APIKey, error)
 }
@@ -34,6 +34,77 @@ func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockAccountService) ChangePassword(accountID, currentRefreshToken, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", accountID, currentRefreshToken, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAccountServiceMockRecorder) ChangePassword(accountID, currentRefreshToken, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAccountService)(nil).ChangePassword), accountID, currentRefreshToken, currentPassword, newPassword)
+}
+
+// ChangeRole mocks base method.
+func (m *MockAccountService) ChangeRole(actorID, accountID, role string) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRole", actorID, accountID, role)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeRole indicates an expected call of ChangeRole.
+func (mr *MockAccountServiceMockRecorder) ChangeRole(actorID, accountID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRole", reflect.TypeOf((*MockAccountService)(nil).ChangeRole), actorID, accountID, role)
+}
+
+// ClearIPLockout mocks base method.
+func (m *MockAccountService) ClearIPLockout(clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearIPLockout", clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearIPLockout indicates an expected call of ClearIPLockout.
+func (mr *MockAccountServiceMockRecorder) ClearIPLockout(clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearIPLockout", reflect.TypeOf((*MockAccountService)(nil).ClearIPLockout), clientIP)
+}
+
+// ClearLoginLockout mocks base method.
+func (m *MockAccountService) ClearLoginLockout(accountID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearLoginLockout", accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearLoginLockout indicates an expected call of ClearLoginLockout.
+func (mr *MockAccountServiceMockRecorder) ClearLoginLockout(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLoginLockout", reflect.TypeOf((*MockAccountService)(nil).ClearLoginLockout), accountID)
+}
+
+// ConfirmPhone mocks base method.
+func (m *MockAccountService) ConfirmPhone(phone, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPhone", phone, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmPhone indicates an expected call of ConfirmPhone.
+func (mr *MockAccountServiceMockRecorder) ConfirmPhone(phone, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPhone", reflect.TypeOf((*MockAccountService)(nil).ConfirmPhone), phone, code)
+}
+
 // CreateUser mocks base method.
 func (m *MockAccountService) CreateUser(account core.Account) (string, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +120,139 @@ func (mr *MockAccountServiceMockRecorder) CreateUser(account interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAccountService)(nil).CreateUser), account)
 }
 
+// DeleteAccount mocks base method.
+func (m *MockAccountService) DeleteAccount(accountID, password string) (core.ErasureReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", accountID, password)
+	ret0, _ := ret[0].(core.ErasureReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountServiceMockRecorder) DeleteAccount(accountID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountService)(nil).DeleteAccount), accountID, password)
+}
+
+// DisableAccount mocks base method.
+func (m *MockAccountService) DisableAccount(actorID, accountID string) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableAccount", actorID, accountID)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableAccount indicates an expected call of DisableAccount.
+func (mr *MockAccountServiceMockRecorder) DisableAccount(actorID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAccount", reflect.TypeOf((*MockAccountService)(nil).DisableAccount), actorID, accountID)
+}
+
+// EnableAccount mocks base method.
+func (m *MockAccountService) EnableAccount(accountID string) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableAccount", accountID)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableAccount indicates an expected call of EnableAccount.
+func (mr *MockAccountServiceMockRecorder) EnableAccount(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAccount", reflect.TypeOf((*MockAccountService)(nil).EnableAccount), accountID)
+}
+
+// ExportAccount mocks base method.
+func (m *MockAccountService) ExportAccount(accountID string) (core.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccount", accountID)
+	ret0, _ := ret[0].(core.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccount indicates an expected call of ExportAccount.
+func (mr *MockAccountServiceMockRecorder) ExportAccount(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccount", reflect.TypeOf((*MockAccountService)(nil).ExportAccount), accountID)
+}
+
+// FinishOIDCLogin mocks base method.
+func (m *MockAccountService) FinishOIDCLogin(code, state string, session core.Session) (core.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishOIDCLogin", code, state, session)
+	ret0, _ := ret[0].(core.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishOIDCLogin indicates an expected call of FinishOIDCLogin.
+func (mr *MockAccountServiceMockRecorder) FinishOIDCLogin(code, state, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishOIDCLogin", reflect.TypeOf((*MockAccountService)(nil).FinishOIDCLogin), code, state, session)
+}
+
+// ForceLogout mocks base method.
+func (m *MockAccountService) ForceLogout(accountID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceLogout", accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockAccountServiceMockRecorder) ForceLogout(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockAccountService)(nil).ForceLogout), accountID)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccountService) GetAccount(accountID string) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", accountID)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountServiceMockRecorder) GetAccount(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountService)(nil).GetAccount), accountID)
+}
+
+// JWKS mocks base method.
+func (m *MockAccountService) JWKS() core.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(core.JSONWebKeySet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockAccountServiceMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockAccountService)(nil).JWKS))
+}
+
+// ListAccounts mocks base method.
+func (m *MockAccountService) ListAccounts(qp core.ConditionParams) ([]core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", qp)
+	ret0, _ := ret[0].([]core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockAccountServiceMockRecorder) ListAccounts(qp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountService)(nil).ListAccounts), qp)
+}
+
 // Login mocks base method.
 func (m *MockAccountService) Login(login, password string, session core.Session) (core.TokenPair, error) {
 	m.ctrl.T.Helper()
@@ -65,27 +269,26 @@ func (mr *MockAccountServiceMockRecorder) Login(login, password, session interfa
 }
 
 // Logout mocks base method.
-func (m *MockAccountService) Logout(accountID string) error {
+func (m *MockAccountService) Logout(accountID, refreshToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Logout", accountID)
+	ret := m.ctrl.Call(m, "Logout", accountID, refreshToken)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Logout indicates an expected call of Logout.
-func (mr *MockAccountServiceMockRecorder) Logout(accountID interface{}) *gomock.Call {
+func (mr *MockAccountServiceMockRecorder) Logout(accountID, refreshToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAccountService)(nil).Logout), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAccountService)(nil).Logout), accountID, refreshToken)
 }
 
 // ParseToken mocks base method.
-func (m *MockAccountService) ParseToken(arg0 string) (string, string, error) {
+func (m *MockAccountService) ParseToken(arg0 string) (core.Identity, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", arg0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(core.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ParseToken indicates an expected call of ParseToken.
@@ -94,6 +297,21 @@ func (mr *MockAccountServiceMockRecorder) ParseToken(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAccountService)(nil).ParseToken), arg0)
 }
 
+// Profile mocks base method.
+func (m *MockAccountService) Profile(accountID string) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", accountID)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockAccountServiceMockRecorder) Profile(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockAccountService)(nil).Profile), accountID)
+}
+
 // RefreshTokenpair mocks base method.
 func (m *MockAccountService) RefreshTokenpair(session core.Session) (core.TokenPair, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +327,108 @@ func (mr *MockAccountServiceMockRecorder) RefreshTokenpair(session interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokenpair", reflect.TypeOf((*MockAccountService)(nil).RefreshTokenpair), session)
 }
 
+// RequestPasswordReset mocks base method.
+func (m *MockAccountService) RequestPasswordReset(phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAccountServiceMockRecorder) RequestPasswordReset(phone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAccountService)(nil).RequestPasswordReset), phone)
+}
+
+// RequestPhoneVerification mocks base method.
+func (m *MockAccountService) RequestPhoneVerification(phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPhoneVerification", phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPhoneVerification indicates an expected call of RequestPhoneVerification.
+func (mr *MockAccountServiceMockRecorder) RequestPhoneVerification(phone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPhoneVerification", reflect.TypeOf((*MockAccountService)(nil).RequestPhoneVerification), phone)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAccountService) ResetPassword(phone, code, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", phone, code, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAccountServiceMockRecorder) ResetPassword(phone, code, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAccountService)(nil).ResetPassword), phone, code, newPassword)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAccountService) RevokeSession(accountID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", accountID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAccountServiceMockRecorder) RevokeSession(accountID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAccountService)(nil).RevokeSession), accountID, sessionID)
+}
+
+// Sessions mocks base method.
+func (m *MockAccountService) Sessions(accountID, currentRefreshToken string) ([]core.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions", accountID, currentRefreshToken)
+	ret0, _ := ret[0].([]core.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockAccountServiceMockRecorder) Sessions(accountID, currentRefreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockAccountService)(nil).Sessions), accountID, currentRefreshToken)
+}
+
+// StartOIDCLogin mocks base method.
+func (m *MockAccountService) StartOIDCLogin() (string, core.OIDCLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLogin")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(core.OIDCLogin)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StartOIDCLogin indicates an expected call of StartOIDCLogin.
+func (mr *MockAccountServiceMockRecorder) StartOIDCLogin() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLogin", reflect.TypeOf((*MockAccountService)(nil).StartOIDCLogin))
+}
+
+// UpdateAge mocks base method.
+func (m *MockAccountService) UpdateAge(accountID string, age int) (core.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAge", accountID, age)
+	ret0, _ := ret[0].(core.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAge indicates an expected call of UpdateAge.
+func (mr *MockAccountServiceMockRecorder) UpdateAge(accountID, age interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAge", reflect.TypeOf((*MockAccountService)(nil).UpdateAge), accountID, age)
+}
+
 // MockDirectorService is a mock of DirectorService interface.
 type MockDirectorService struct {
 	ctrl     *gomock.Controller
@@ -146,19 +466,48 @@ func (mr *MockDirectorServiceMockRecorder) CreateDirector(director interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirector", reflect.TypeOf((*MockDirectorService)(nil).CreateDirector), director)
 }
 
+// DeleteDirector mocks base method.
+func (m *MockDirectorService) DeleteDirector(directorID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDirector", directorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDirector indicates an expected call of DeleteDirector.
+func (mr *MockDirectorServiceMockRecorder) DeleteDirector(directorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDirector", reflect.TypeOf((*MockDirectorService)(nil).DeleteDirector), directorID)
+}
+
 // GetDirectorList mocks base method.
-func (m *MockDirectorService) GetDirectorList() ([]core.Director, error) {
+func (m *MockDirectorService) GetDirectorList(qp core.ConditionParams) ([]core.Director, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDirectorList")
+	ret := m.ctrl.Call(m, "GetDirectorList", qp)
 	ret0, _ := ret[0].([]core.Director)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDirectorList indicates an expected call of GetDirectorList.
-func (mr *MockDirectorServiceMockRecorder) GetDirectorList() *gomock.Call {
+func (mr *MockDirectorServiceMockRecorder) GetDirectorList(qp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectorList", reflect.TypeOf((*MockDirectorService)(nil).GetDirectorList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectorList", reflect.TypeOf((*MockDirectorService)(nil).GetDirectorList), qp)
+}
+
+// GetDirectorMovies mocks base method.
+func (m *MockDirectorService) GetDirectorMovies(directorID string, qp core.ConditionParams) ([]core.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectorMovies", directorID, qp)
+	ret0, _ := ret[0].([]core.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectorMovies indicates an expected call of GetDirectorMovies.
+func (mr *MockDirectorServiceMockRecorder) GetDirectorMovies(directorID, qp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectorMovies", reflect.TypeOf((*MockDirectorService)(nil).GetDirectorMovies), directorID, qp)
 }
 
 // GetDirectorWithID mocks base method.
@@ -176,6 +525,36 @@ func (mr *MockDirectorServiceMockRecorder) GetDirectorWithID(directorID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectorWithID", reflect.TypeOf((*MockDirectorService)(nil).GetDirectorWithID), directorID)
 }
 
+// UpdateDirector mocks base method.
+func (m *MockDirectorService) UpdateDirector(director core.Director) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDirector", director)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDirector indicates an expected call of UpdateDirector.
+func (mr *MockDirectorServiceMockRecorder) UpdateDirector(director interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDirector", reflect.TypeOf((*MockDirectorService)(nil).UpdateDirector), director)
+}
+
+// UploadDirectorPhoto mocks base method.
+func (m *MockDirectorService) UploadDirectorPhoto(directorID string, photo core.Photo) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadDirectorPhoto", directorID, photo)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadDirectorPhoto indicates an expected call of UploadDirectorPhoto.
+func (mr *MockDirectorServiceMockRecorder) UploadDirectorPhoto(directorID, photo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDirectorPhoto", reflect.TypeOf((*MockDirectorService)(nil).UploadDirectorPhoto), directorID, photo)
+}
+
 // MockMovieService is a mock of MovieService interface.
 type MockMovieService struct {
 	ctrl     *gomock.Controller
@@ -213,6 +592,21 @@ func (mr *MockMovieServiceMockRecorder) CreateMovie(movie interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockMovieService)(nil).CreateMovie), movie)
 }
 
+// DeleteMovie mocks base method.
+func (m *MockMovieService) DeleteMovie(movieID string, cascade bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovie", movieID, cascade)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMovie indicates an expected call of DeleteMovie.
+func (mr *MockMovieServiceMockRecorder) DeleteMovie(movieID, cascade interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieService)(nil).DeleteMovie), movieID, cascade)
+}
+
 // Get mocks base method.
 func (m *MockMovieService) Get(movieID string) (core.Movie, error) {
 	m.ctrl.T.Helper()
@@ -258,6 +652,21 @@ func (mr *MockMovieServiceMockRecorder) GetList(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockMovieService)(nil).GetList), arg0)
 }
 
+// UpdateMovie mocks base method.
+func (m *MockMovieService) UpdateMovie(movie core.Movie) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovie", movie)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMovie indicates an expected call of UpdateMovie.
+func (mr *MockMovieServiceMockRecorder) UpdateMovie(movie interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieService)(nil).UpdateMovie), movie)
+}
+
 // MockListsService is a mock of ListsService interface.
 type MockListsService struct {
 	ctrl     *gomock.Controller
@@ -282,17 +691,31 @@ func (m *MockListsService) EXPECT() *MockListsServiceMockRecorder {
 }
 
 // AddMovieToList mocks base method.
-func (m *MockListsService) AddMovieToList(movieID, listID string) error {
+func (m *MockListsService) AddMovieToList(accountID, listID, movieID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddMovieToList", movieID, listID)
+	ret := m.ctrl.Call(m, "AddMovieToList", accountID, listID, movieID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddMovieToList indicates an expected call of AddMovieToList.
-func (mr *MockListsServiceMockRecorder) AddMovieToList(movieID, listID interface{}) *gomock.Call {
+func (mr *MockListsServiceMockRecorder) AddMovieToList(accountID, listID, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieToList", reflect.TypeOf((*MockListsService)(nil).AddMovieToList), movieID, listID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieToList", reflect.TypeOf((*MockListsService)(nil).AddMovieToList), accountID, listID, movieID)
+}
+
+// AddMovieToSystemList mocks base method.
+func (m *MockListsService) AddMovieToSystemList(accountID string, listType core.ListType, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovieToSystemList", accountID, listType, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovieToSystemList indicates an expected call of AddMovieToSystemList.
+func (mr *MockListsServiceMockRecorder) AddMovieToSystemList(accountID, listType, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieToSystemList", reflect.TypeOf((*MockListsService)(nil).AddMovieToSystemList), accountID, listType, movieID)
 }
 
 // Create mocks base method.
@@ -310,6 +733,20 @@ func (mr *MockListsServiceMockRecorder) Create(list interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockListsService)(nil).Create), list)
 }
 
+// Delete mocks base method.
+func (m *MockListsService) Delete(accountID, listID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", accountID, listID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListsServiceMockRecorder) Delete(accountID, listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockListsService)(nil).Delete), accountID, listID)
+}
+
 // GetAllAccountLists mocks base method.
 func (m *MockListsService) GetAllAccountLists(arg0 []core.QuerySliceElement) ([]core.MovieList, error) {
 	m.ctrl.T.Helper()
@@ -324,3 +761,210 @@ func (mr *MockListsServiceMockRecorder) GetAllAccountLists(arg0 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAccountLists", reflect.TypeOf((*MockListsService)(nil).GetAllAccountLists), arg0)
 }
+
+// GetListWithMovies mocks base method.
+func (m *MockListsService) GetListWithMovies(listID, accountID string, qp core.ConditionParams) (core.MovieListDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithMovies", listID, accountID, qp)
+	ret0, _ := ret[0].(core.MovieListDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListWithMovies indicates an expected call of GetListWithMovies.
+func (mr *MockListsServiceMockRecorder) GetListWithMovies(listID, accountID, qp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithMovies", reflect.TypeOf((*MockListsService)(nil).GetListWithMovies), listID, accountID, qp)
+}
+
+// RemoveMovieFromList mocks base method.
+func (m *MockListsService) RemoveMovieFromList(accountID, listID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMovieFromList", accountID, listID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMovieFromList indicates an expected call of RemoveMovieFromList.
+func (mr *MockListsServiceMockRecorder) RemoveMovieFromList(accountID, listID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMovieFromList", reflect.TypeOf((*MockListsService)(nil).RemoveMovieFromList), accountID, listID, movieID)
+}
+
+// RemoveMovieFromSystemList mocks base method.
+func (m *MockListsService) RemoveMovieFromSystemList(accountID string, listType core.ListType, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMovieFromSystemList", accountID, listType, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMovieFromSystemList indicates an expected call of RemoveMovieFromSystemList.
+func (mr *MockListsServiceMockRecorder) RemoveMovieFromSystemList(accountID, listType, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMovieFromSystemList", reflect.TypeOf((*MockListsService)(nil).RemoveMovieFromSystemList), accountID, listType, movieID)
+}
+
+// Rename mocks base method.
+func (m *MockListsService) Rename(accountID, listID, listType string) (core.MovieList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", accountID, listID, listType)
+	ret0, _ := ret[0].(core.MovieList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockListsServiceMockRecorder) Rename(accountID, listID, listType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockListsService)(nil).Rename), accountID, listID, listType)
+}
+
+// MockRatingService is a mock of RatingService interface.
+type MockRatingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingServiceMockRecorder
+}
+
+// MockRatingServiceMockRecorder is the mock recorder for MockRatingService.
+type MockRatingServiceMockRecorder struct {
+	mock *MockRatingService
+}
+
+// NewMockRatingService creates a new mock instance.
+func NewMockRatingService(ctrl *gomock.Controller) *MockRatingService {
+	mock := &MockRatingService{ctrl: ctrl}
+	mock.recorder = &MockRatingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingService) EXPECT() *MockRatingServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRatingService) Delete(accountID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", accountID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRatingServiceMockRecorder) Delete(accountID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRatingService)(nil).Delete), accountID, movieID)
+}
+
+// Get mocks base method.
+func (m *MockRatingService) Get(accountID, movieID string) (core.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", accountID, movieID)
+	ret0, _ := ret[0].(core.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRatingServiceMockRecorder) Get(accountID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRatingService)(nil).Get), accountID, movieID)
+}
+
+// Rate mocks base method.
+func (m *MockRatingService) Rate(rating core.Rating) (core.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", rating)
+	ret0, _ := ret[0].(core.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockRatingServiceMockRecorder) Rate(rating interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockRatingService)(nil).Rate), rating)
+}
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKeyService) Authenticate(secret string) (core.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", secret)
+	ret0, _ := ret[0].(core.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeyServiceMockRecorder) Authenticate(secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKeyService)(nil).Authenticate), secret)
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyService) Create(actorID string, input core.APIKeyInput) (core.IssuedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actorID, input)
+	ret0, _ := ret[0].(core.IssuedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyServiceMockRecorder) Create(actorID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyService)(nil).Create), actorID, input)
+}
+
+// List mocks base method.
+func (m *MockAPIKeyService) List() ([]core.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]core.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPIKeyServiceMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIKeyService)(nil).List))
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyService) Revoke(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyServiceMockRecorder) Revoke(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyService)(nil).Revoke), id)
+}
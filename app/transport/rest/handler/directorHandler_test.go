@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"bytes"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -127,7 +129,7 @@ func TestDirector_getAll(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:  http.StatusOK,
-			expectedRequestBody: `{"id":"dcabae88-1693-4349-af92-14704e4ffaab","name":"James Kameron","birth_date":"0001-01-01","created":"","modified":""}`,
+			expectedRequestBody: `{"id":"dcabae88-1693-4349-af92-14704e4ffaab","name":"James Kameron","birth_date":"0001-01-01","created":"","modified":"","photo_url":""}`,
 		},
 		"Internal error": {
 			logger:    log,
@@ -200,18 +202,25 @@ func TestDirector_get(t *testing.T) {
 		"Successfull case": {
 			logger: log,
 			mockBehavior: func(s *MockDirectorService) {
-				s.EXPECT().GetDirectorList().Return([]core.Director{
+				s.EXPECT().GetDirectorList(core.ConditionParams{
+					Limit:  "20",
+					Offset: "0",
+					Export: "none",
+					CheckList: core.ListValidationFilds{
+						Limit: true, Offset: true, Filter: true, Sort: true, Export: true,
+					},
+				}).Return([]core.Director{
 					{ID: "1"},
 					{ID: "2"},
 				}, nil)
 			},
 			expectedStatusCode:  http.StatusOK,
-			expectedRequestBody: `[{"id":"1","name":"","birth_date":"0001-01-01","created":"","modified":""},{"id":"2","name":"","birth_date":"0001-01-01","created":"","modified":""}]`,
+			expectedRequestBody: `[{"id":"1","name":"","birth_date":"0001-01-01","created":"","modified":"","photo_url":""},{"id":"2","name":"","birth_date":"0001-01-01","created":"","modified":"","photo_url":""}]`,
 		},
 		"Internal error": {
 			logger: log,
 			mockBehavior: func(s *MockDirectorService) {
-				s.EXPECT().GetDirectorList().Return([]core.Director{},
+				s.EXPECT().GetDirectorList(gomock.Any()).Return([]core.Director{},
 					errors.New("some internal error"))
 			},
 			expectedStatusCode:  http.StatusInternalServerError,
@@ -244,3 +253,504 @@ func TestDirector_get(t *testing.T) {
 		})
 	}
 }
+
+func TestDirector_update(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		directorID = "dcabae88-1693-4349-af92-14704e4ffaab"
+		modified   = "2023-01-25T19:42:11.546434Z"
+	)
+
+	type mockBehavior func(s *MockDirectorService)
+
+	testCasesTable := map[string]struct {
+		directorID          string
+		ifMatch             string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		"Successfull case": {
+			directorID: directorID,
+			ifMatch:    core.ETag(modified),
+			inputBody:  `{"name":"James Cameron", "birth_date":"1954-08-16"}`,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().UpdateDirector(gomock.Any()).DoAndReturn(func(d core.Director) (string, error) {
+					assert.Equal(t, directorID, d.ID)
+					assert.Equal(t, modified, d.Modified)
+
+					return "2023-01-26T10:00:00Z", nil
+				})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"action":"successful"}`,
+		},
+		"Wrong id": {
+			directorID:          "Wrong-ID",
+			inputBody:           `{"name":"James Cameron", "birth_date":"1954-08-16"}`,
+			mockBehavior:        func(s *MockDirectorService) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"Director not found": {
+			directorID: directorID,
+			inputBody:  `{"name":"James Cameron", "birth_date":"1954-08-16"}`,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().UpdateDirector(gomock.Any()).Return("", core.ErrNowDirectorFound)
+			},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"error":"` + core.ErrNowDirectorFound.Error() + `"}`,
+		},
+		"Duplicate director": {
+			directorID: directorID,
+			inputBody:  `{"name":"James Cameron", "birth_date":"1954-08-16"}`,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().UpdateDirector(gomock.Any()).Return("", core.ErrDublicatDirector)
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"` + core.ErrDublicatDirector.Error() + `"}`,
+		},
+		"Modified since it was read": {
+			directorID: directorID,
+			ifMatch:    core.ETag(modified),
+			inputBody:  `{"name":"James Cameron", "birth_date":"1954-08-16"}`,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().UpdateDirector(gomock.Any()).Return("", core.ErrPreconditionFailed)
+			},
+			expectedStatusCode:  http.StatusPreconditionFailed,
+			expectedRequestBody: `{"error":"` + core.ErrPreconditionFailed.Error() + `"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockDirectorService(ctrl)
+			testCase.mockBehavior(service)
+
+			dh := NewDirectorHandler(service, log)
+
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodPut, "/director/"+testCase.directorID,
+				strings.NewReader(testCase.inputBody))
+			if testCase.ifMatch != "" {
+				c.Request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			r.PUT("/director/:id", dh.update)
+			r.ServeHTTP(w, c.Request)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestDirector_delete(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const directorID = "dcabae88-1693-4349-af92-14704e4ffaab"
+
+	type mockBehavior func(s *MockDirectorService)
+
+	testCasesTable := map[string]struct {
+		directorID          string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		"Successfull case": {
+			directorID: directorID,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().DeleteDirector(directorID).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"action":"successful"}`,
+		},
+		"Director has movies": {
+			directorID: directorID,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().DeleteDirector(directorID).Return(core.ErrDirectorHasMovie)
+			},
+			expectedStatusCode:  http.StatusConflict,
+			expectedRequestBody: `{"error":"` + core.ErrDirectorHasMovie.Error() + `"}`,
+		},
+		"Director not found": {
+			directorID: directorID,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().DeleteDirector(directorID).Return(core.ErrNowDirectorFound)
+			},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"error":"` + core.ErrNowDirectorFound.Error() + `"}`,
+		},
+		"Internal error": {
+			directorID: directorID,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().DeleteDirector(directorID).Return(errors.New("some internal error"))
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"error":"some internal error"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockDirectorService(ctrl)
+			testCase.mockBehavior(service)
+
+			dh := NewDirectorHandler(service, log)
+
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodDelete, "/director/"+testCase.directorID, nil)
+
+			r.DELETE("/director/:id", dh.delete)
+			r.ServeHTTP(w, c.Request)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+// Returns the multipart body with the file in the field and its content type.
+func multipartFile(t *testing.T, field string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(field, "photo")
+	if err != nil {
+		t.FailNow()
+	}
+
+	if _, err := part.Write(content); err != nil {
+		t.FailNow()
+	}
+
+	if err := writer.Close(); err != nil {
+		t.FailNow()
+	}
+
+	return body, writer.FormDataContentType()
+}
+
+func TestDirector_uploadPhoto(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const directorID = "dcabae88-1693-4349-af92-14704e4ffaab"
+
+	pngContent := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 100)...)
+
+	type mockBehavior func(s *MockDirectorService)
+
+	testCasesTable := map[string]struct {
+		directorID          string
+		field               string
+		content             []byte
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		"Successfull case": {
+			directorID: directorID,
+			field:      "photo",
+			content:    pngContent,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().UploadDirectorPhoto(directorID, gomock.Any()).DoAndReturn(
+					func(id string, photo core.Photo) (string, error) {
+						assert.Equal(t, "image/png", photo.ContentType)
+						assert.Equal(t, int64(len(pngContent)), photo.Size)
+
+						return "/photos/director/" + id + "/new.png", nil
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"photo_url":"/photos/director/dcabae88-1693-4349-af92-14704e4ffaab/new.png"}`,
+		},
+		"The content type is detected by the content": {
+			directorID: directorID,
+			field:      "photo",
+			content:    []byte("<?php echo 'not an image'; ?>"),
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().UploadDirectorPhoto(directorID, gomock.Any()).DoAndReturn(
+					func(id string, photo core.Photo) (string, error) {
+						assert.Equal(t, "text/plain; charset=utf-8", photo.ContentType)
+
+						return "", core.ErrUnsupportedPhoto
+					})
+			},
+			expectedStatusCode:  http.StatusUnsupportedMediaType,
+			expectedRequestBody: `{"error":"` + core.ErrUnsupportedPhoto.Error() + `"}`,
+		},
+		"Too large body": {
+			directorID:          directorID,
+			field:               "photo",
+			content:             make([]byte, core.MaxPhotoSize+multipartOverhead),
+			mockBehavior:        func(s *MockDirectorService) {},
+			expectedStatusCode:  http.StatusRequestEntityTooLarge,
+			expectedRequestBody: `{"error":"` + core.ErrPhotoTooLarge.Error() + `"}`,
+		},
+		"No photo field": {
+			directorID:          directorID,
+			field:               "image",
+			content:             pngContent,
+			mockBehavior:        func(s *MockDirectorService) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"http: no such file"}`,
+		},
+		"Director not found": {
+			directorID: directorID,
+			field:      "photo",
+			content:    pngContent,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().UploadDirectorPhoto(directorID, gomock.Any()).Return("", core.ErrNowDirectorFound)
+			},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"error":"` + core.ErrNowDirectorFound.Error() + `"}`,
+		},
+		"Wrong id": {
+			directorID:          "Wrong-ID",
+			field:               "photo",
+			content:             pngContent,
+			mockBehavior:        func(s *MockDirectorService) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"invalid UUID length: 8"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockDirectorService(ctrl)
+			testCase.mockBehavior(service)
+
+			dh := NewDirectorHandler(service, log)
+
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			body, contentType := multipartFile(t, testCase.field, testCase.content)
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/director/"+testCase.directorID+"/photo", body)
+			c.Request.Header.Set("Content-Type", contentType)
+
+			r.POST("/director/:id/photo", dh.uploadPhoto)
+			r.ServeHTTP(w, c.Request)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestDirector_getConditional(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	avgRate, firstYear, latestYear := 7.5, 1984, 2009
+
+	director := core.Director{
+		ID:       "dcabae88-1693-4349-af92-14704e4ffaab",
+		Name:     "James Cameron",
+		Modified: "2023-04-01T10:00:00.123456+03:00",
+		Summary: &core.DirectorSummary{
+			MoviesCount:       2,
+			AvgRate:           &avgRate,
+			FirstReleaseYear:  &firstYear,
+			LatestReleaseYear: &latestYear,
+			Version:           "2-2023-04-02 10:00:00+03",
+		},
+	}
+
+	currentETag := core.VersionedETag(director.Modified, director.Summary.Version)
+
+	testCasesTable := map[string]struct {
+		ifNoneMatch         string
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		"No header": {
+			ifNoneMatch:        "",
+			expectedStatusCode: http.StatusOK,
+			expectedRequestBody: `{"id":"dcabae88-1693-4349-af92-14704e4ffaab","name":"James Cameron","birth_date":"0001-01-01",` +
+				`"created":"","modified":"2023-04-01T10:00:00.123456+03:00","photo_url":"",` +
+				`"summary":{"movies_count":2,"avg_rate":7.5,"first_release_year":1984,"latest_release_year":2009}}`,
+		},
+		"Actual version": {
+			ifNoneMatch:         currentETag,
+			expectedStatusCode:  http.StatusNotModified,
+			expectedRequestBody: "",
+		},
+		"The movies were changed since": {
+			ifNoneMatch:        core.VersionedETag(director.Modified, "1-2023-03-02 10:00:00+03"),
+			expectedStatusCode: http.StatusOK,
+			expectedRequestBody: `{"id":"dcabae88-1693-4349-af92-14704e4ffaab","name":"James Cameron","birth_date":"0001-01-01",` +
+				`"created":"","modified":"2023-04-01T10:00:00.123456+03:00","photo_url":"",` +
+				`"summary":{"movies_count":2,"avg_rate":7.5,"first_release_year":1984,"latest_release_year":2009}}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockDirectorService(ctrl)
+			service.EXPECT().GetDirectorWithID(director.ID).Return(director, nil)
+
+			dh := NewDirectorHandler(service, log)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			r.GET("/director/:id", dh.get)
+
+			req := httptest.NewRequest(http.MethodGet, "/director/"+director.ID, nil)
+
+			if testCase.ifNoneMatch != "" {
+				req.Header.Set(ifNoneMatchHeader, testCase.ifNoneMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedRequestBody, w.Body.String())
+
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, currentETag, w.Header().Get(etagHeader))
+
+				// The tag of the representation with the summary can be used for the conditional update.
+				modified, err := core.ModifiedFromETag(w.Header().Get(etagHeader))
+				assert.NoError(t, err)
+				assert.Equal(t, director.Modified, modified)
+			}
+		})
+	}
+}
+
+func TestDirector_movies(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const directorID = "dcabae88-1693-4349-af92-14704e4ffaab"
+
+	type mockBehavior func(s *MockDirectorService)
+
+	testCasesTable := map[string]struct {
+		directorID          string
+		query               string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		"Successfull case": {
+			directorID: directorID,
+			query:      "?f=genre:drama&s=release_date:desc&limit=50",
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().GetDirectorMovies(directorID, core.ConditionParams{
+					Limit:  "50",
+					Offset: "0",
+					Export: "none",
+					Filter: []core.QuerySliceElement{{Key: "genre", Val: "drama"}},
+					Sort:   []core.QuerySliceElement{{Key: "release_date", Val: "desc"}},
+					CheckList: core.ListValidationFilds{
+						Limit: true, Offset: true, Filter: true, Sort: true, Export: true,
+					},
+				}).Return([]core.Movie{{ID: "movie-id-1", DirectorID: directorID}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedRequestBody: `[{"id":"movie-id-1","title":"","genre":"","director_id":"dcabae88-1693-4349-af92-14704e4ffaab",` +
+				`"rate":0,"release_date":"","duration":0,"avg_rate":0,"votes":0,"created":"","modified":""}]`,
+		},
+		"Director without movies": {
+			directorID: directorID,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().GetDirectorMovies(directorID, gomock.Any()).Return([]core.Movie{}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `[]`,
+		},
+		"Director not found": {
+			directorID: directorID,
+			mockBehavior: func(s *MockDirectorService) {
+				s.EXPECT().GetDirectorMovies(directorID, gomock.Any()).Return(nil, core.ErrNowDirectorFound)
+			},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"error":"` + core.ErrNowDirectorFound.Error() + `"}`,
+		},
+		"Unallowed limit": {
+			directorID:          directorID,
+			query:               "?limit=30",
+			mockBehavior:        func(s *MockDirectorService) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"query preparetion failed: unallowed limit"}`,
+		},
+		"Wrong id": {
+			directorID:          "Wrong-ID",
+			mockBehavior:        func(s *MockDirectorService) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"invalid UUID length: 8"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewMockDirectorService(ctrl)
+			testCase.mockBehavior(service)
+
+			dh := NewDirectorHandler(service, log)
+
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodGet,
+				"/director/"+testCase.directorID+"/movies"+testCase.query, nil)
+
+			r.GET("/director/:id/movies", dh.movies)
+			r.ServeHTTP(w, c.Request)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -2,6 +2,8 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/Brigant/PetPorject/app/core"
@@ -10,6 +12,14 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	photoFormField = "photo"
+	// The space for the boundaries and the headers of the multipart form around the photo.
+	multipartOverhead = 64 << 10
+	// The number of bytes which is used to detect the content type.
+	sniffLen = 512
+)
+
 type DirectorHandler struct {
 	service DirectorService
 	logger  *logger.Logger
@@ -34,6 +44,14 @@ func (h *DirectorHandler) create(c *gin.Context) {
 	}
 
 	if err := h.service.CreateDirector(director); err != nil {
+		if errors.Is(err, core.ErrDublicatDirector) {
+			h.logger.Debugw("CreateDirector", "error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("CreateDirector", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 
 		return
@@ -76,13 +94,45 @@ func (h *DirectorHandler) get(c *gin.Context) {
 		return
 	}
 
+	// The summary is derived from the director movies, so its version is a part of the tag.
+	etag := core.ETag(director.Modified)
+	if director.Summary != nil {
+		etag = core.VersionedETag(director.Modified, director.Summary.Version)
+	}
+
+	if notModified(c, etag) {
+		c.Status(http.StatusNotModified)
+
+		return
+	}
+
+	setETag(c, etag)
+
 	c.JSON(http.StatusOK, director)
 }
 
-// Returns the slice of the directors.
+// Returns the page of the directors. The full example of url query:
+// /director/all?f=name:cameron&f=name_prefix:ja&s=birth_date:desc&s=name:asc&limit=50&offset=100
+// The name filter is the case-insensitive substring search, the name_prefix is the prefix one.
 func (h *DirectorHandler) getAll(c *gin.Context) {
-	directorsList, err := h.service.GetDirectorList()
+	var queryParameter core.ConditionParams
+
+	if err := queryParameter.Prepare(c); err != nil {
+		h.logger.Debugw("Prepare query params", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	directorsList, err := h.service.GetDirectorList(queryParameter)
 	if err != nil {
+		if errors.Is(err, core.ErrUnkownConditionKey) {
+			h.logger.Debugw("GetDirectorList", "error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
 		h.logger.Errorw("GetDirectorList", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 
@@ -91,3 +141,207 @@ func (h *DirectorHandler) getAll(c *gin.Context) {
 
 	c.JSON(http.StatusOK, directorsList)
 }
+
+// Changes the director defined by its ID. If the If-Match header is present,
+// the director is updated only if it has not been changed since.
+func (h *DirectorHandler) update(c *gin.Context) {
+	id, ok := h.parseDirectorID(c)
+	if !ok {
+		return
+	}
+
+	expectedModified, err := ifMatchModified(c)
+	if err != nil {
+		h.logger.Debugw("ifMatchModified", "error", err.Error())
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var director core.Director
+
+	if err := c.ShouldBindJSON(&director); err != nil {
+		h.logger.Debugw("Update director", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	director.ID = id
+	director.Modified = expectedModified
+
+	modified, err := h.service.UpdateDirector(director)
+	if err != nil {
+		h.respondDirectorError(c, "UpdateDirector", err)
+
+		return
+	}
+
+	setETag(c, core.ETag(modified))
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Deletes the director defined by its ID. The director who still has movies can't be deleted.
+func (h *DirectorHandler) delete(c *gin.Context) {
+	id, ok := h.parseDirectorID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteDirector(id); err != nil {
+		h.respondDirectorError(c, "DeleteDirector", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Returns the page of the director movies. The query has the same rules as for the list of all movies:
+// /director/:id/movies?f=genre:comedy&f=rate:7.9&s=release_date:desc&limit=50&offset=0
+func (h *DirectorHandler) movies(c *gin.Context) {
+	id, ok := h.parseDirectorID(c)
+	if !ok {
+		return
+	}
+
+	var queryParameter core.ConditionParams
+
+	if err := queryParameter.Prepare(c); err != nil {
+		h.logger.Debugw("Prepare query params", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	movieList, err := h.service.GetDirectorMovies(id, queryParameter)
+	if err != nil {
+		if errors.Is(err, core.ErrUnkownConditionKey) {
+			h.logger.Debugw("GetDirectorMovies", "error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.respondDirectorError(c, "GetDirectorMovies", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, movieList)
+}
+
+// Uploads the photo of the director. The photo should be passed as the "photo" field
+// of the multipart form. The content type is detected by the content of the file, not by the client header.
+func (h *DirectorHandler) uploadPhoto(c *gin.Context) {
+	id, ok := h.parseDirectorID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, core.MaxPhotoSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile(photoFormField)
+	if err != nil {
+		maxBytesErr := new(http.MaxBytesError)
+		if errors.As(err, &maxBytesErr) {
+			h.respondDirectorError(c, "FormFile", core.ErrPhotoTooLarge)
+
+			return
+		}
+
+		h.logger.Debugw("FormFile", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Errorw("Open photo", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	defer file.Close()
+
+	contentType, err := detectContentType(file)
+	if err != nil {
+		h.logger.Errorw("detectContentType", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	photoURL, err := h.service.UploadDirectorPhoto(id, core.Photo{
+		ContentType: contentType,
+		Size:        fileHeader.Size,
+		Body:        file,
+	})
+	if err != nil {
+		h.respondDirectorError(c, "UploadDirectorPhoto", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"photo_url": photoURL})
+}
+
+// Sniffs the content type by the first bytes of the file and rewinds it to the beginning.
+func detectContentType(file io.ReadSeeker) (string, error) {
+	head := make([]byte, sniffLen)
+
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("can't read the file: %w", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("can't rewind the file: %w", err)
+	}
+
+	return http.DetectContentType(head[:n]), nil
+}
+
+// Takes the director ID from the path. Writes the response and returns false if it is not UUID.
+func (h *DirectorHandler) parseDirectorID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.logger.Debugw("ID is not UUID", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", false
+	}
+
+	return id, true
+}
+
+// Writes the response with the status code which corresponds to the error of the director service.
+func (h *DirectorHandler) respondDirectorError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, core.ErrNowDirectorFound):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrDublicatDirector):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrDirectorHasMovie):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrPreconditionFailed):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrUnsupportedPhoto):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrPhotoTooLarge):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorw(operation, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	etagHeader        = "ETag"
+	ifMatchHeader     = "If-Match"
+	ifNoneMatchHeader = "If-None-Match"
+	anyETag           = "*"
+)
+
+// Sets the ETag header, the tag should be made by core.ETag or core.VersionedETag.
+func setETag(c *gin.Context, current string) {
+	c.Header(etagHeader, current)
+}
+
+// Reports whether the If-None-Match header matches the current entity tag,
+// which means the client already has the actual version of the entity.
+func notModified(c *gin.Context, current string) bool {
+	header := c.GetHeader(ifNoneMatchHeader)
+	if header == "" {
+		return false
+	}
+
+	for _, etag := range strings.Split(header, ",") {
+		etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+		if etag == anyETag || etag == current {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the modified timestamp which the client expects the entity to have,
+// or an empty string if the If-Match header is absent or matches any version.
+func ifMatchModified(c *gin.Context) (string, error) {
+	header := strings.TrimSpace(c.GetHeader(ifMatchHeader))
+	if header == "" || header == anyETag {
+		return "", nil
+	}
+
+	modified, err := core.ModifiedFromETag(header)
+	if err != nil {
+		return "", core.ErrPreconditionFailed
+	}
+
+	return modified, nil
+}
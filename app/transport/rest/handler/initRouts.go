@@ -3,28 +3,23 @@ package handler
 import (
 	"errors"
 
+	"github.com/Brigant/PetPorject/app/core"
 	"github.com/Brigant/PetPorject/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-var (
-	availableRoles                  = []string{"user", "admin"}
-	errValidatorBind                = errors.New("can't bind the validator")
-	checkRoleFunc    validator.Func = func(fl validator.FieldLevel) bool {
+var errValidatorBind = errors.New("can't bind the validator")
+
+// Returns the validator which accepts only the roles defined in the role permissions.
+func checkRoleFunc(roles core.RolePermissions) validator.Func {
+	return func(fl validator.FieldLevel) bool {
 		role, ok := fl.Field().Interface().(string)
-		if ok {
-			for _, r := range availableRoles {
-				if r == role {
-					return true
-				}
-			}
-		}
 
-		return false
+		return ok && roles.HasRole(role)
 	}
-)
+}
 
 // The structure describes the dependencies.
 type Deps struct {
@@ -32,6 +27,11 @@ type Deps struct {
 	DirectorService DirectorService
 	MovieService    MovieService
 	ListService     ListsService
+	RatingService   RatingService
+	APIKeyService   APIKeyService
+	Roles           core.RolePermissions
+	// The proxies whose forwarding headers are trusted for the client IP, none if it is empty.
+	TrustedProxies []string
 }
 
 type Handler struct {
@@ -39,6 +39,10 @@ type Handler struct {
 	Director DirectorHandler
 	Movie    MovieHandler
 	List     ListHandler
+	Rating   RatingHandler
+	APIKey   APIKeyHandler
+	roles    core.RolePermissions
+	proxies  []string
 	log      *logger.Logger
 }
 
@@ -48,6 +52,10 @@ func NewHandler(deps Deps, logger *logger.Logger) Handler {
 		Director: NewDirectorHandler(deps.DirectorService, logger),
 		Movie:    NewMovieHandler(deps.MovieService, logger),
 		List:     NewListHandler(deps.ListService, logger),
+		Rating:   NewRatingHandler(deps.RatingService, logger),
+		APIKey:   NewAPIKeyHandler(deps.APIKeyService, logger),
+		roles:    deps.Roles,
+		proxies:  deps.TrustedProxies,
 		log:      logger,
 	}
 }
@@ -59,42 +67,99 @@ func (h *Handler) InitRouter(mode string) *gin.Engine {
 
 	router := gin.New()
 
+	// Gin trusts every proxy by default, then anyone could choose the own client IP by the header.
+	if err := router.SetTrustedProxies(h.proxies); err != nil {
+		h.log.Errorw("set trusted proxies, no proxy is trusted", "err", err.Error())
+		router.SetTrustedProxies(nil) //nolint:errcheck
+	}
+
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		if err := v.RegisterValidation("checkRole", checkRoleFunc); err != nil {
+		if err := v.RegisterValidation("checkRole", checkRoleFunc(h.roles)); err != nil {
 			h.log.Errorw("bind validator", "err", errValidatorBind.Error())
 		}
 	}
 
 	router.Use(gin.Recovery(), h.midlewareWithLogger)
 
+	router.GET("/.well-known/jwks.json", h.Account.jwks)
+
 	auth := router.Group("/auth")
 	{
 		auth.POST("/", h.Account.singUp)
 		auth.POST("/login", h.Account.login)
-		auth.GET("/logout", h.userIdentity, h.Account.logout)
+		auth.GET("/logout", h.userIdentity, h.requireAccount, h.Account.logout)
 		auth.POST("/refresh", h.Account.refreshToken)
+		auth.GET("/sessions", h.userIdentity, h.requireAccount, h.Account.sessions)
+		auth.DELETE("/sessions/:id", h.userIdentity, h.requireAccount, h.Account.revokeSession)
+		auth.POST("/verify-phone", h.Account.requestPhoneVerification)
+		auth.POST("/verify-phone/confirm", h.Account.confirmPhone)
+		auth.POST("/password-reset/request", h.Account.requestPasswordReset)
+		auth.POST("/password-reset/confirm", h.Account.confirmPasswordReset)
+		auth.GET("/oidc/login", h.Account.oidcLogin)
+		auth.GET("/oidc/callback", h.Account.oidcCallback)
+	}
+
+	admin := router.Group("/admin", h.userIdentity, h.requireAccount, h.requirePermission(core.PermAccountManage))
+	{
+		admin.GET("/accounts", h.Account.listAccounts)
+		admin.GET("/accounts/:id", h.Account.getAccount)
+		admin.PATCH("/accounts/:id/role", h.Account.changeRole)
+		admin.POST("/accounts/:id/disable", h.Account.disableAccount)
+		admin.POST("/accounts/:id/enable", h.Account.enableAccount)
+		admin.DELETE("/accounts/:id/sessions", h.Account.forceLogout)
+		admin.DELETE("/accounts/:id/lockout", h.Account.clearLoginLockout)
+		admin.DELETE("/lockouts/ip/:ip", h.Account.clearIPLockout)
+		admin.POST("/api-keys", h.APIKey.create)
+		admin.GET("/api-keys", h.APIKey.list)
+		admin.DELETE("/api-keys/:id", h.APIKey.revoke)
 	}
 
 	director := router.Group("/director", h.userIdentity)
 	{
-		director.POST("/", h.adminIdentity, h.Director.create)
-		director.GET("/:id", h.Director.get)
-		director.GET("/all", h.Director.getAll)
+		director.POST("/", h.requirePermission(core.PermDirectorWrite), h.Director.create)
+		director.GET("/:id", h.requirePermission(core.PermCatalogueRead), h.Director.get)
+		director.GET("/all", h.requirePermission(core.PermCatalogueRead), h.Director.getAll)
+		director.GET("/:id/movies", h.requirePermission(core.PermCatalogueRead), h.Director.movies)
+		director.PUT("/:id", h.requirePermission(core.PermDirectorWrite), h.Director.update)
+		director.DELETE("/:id", h.requirePermission(core.PermDirectorWrite), h.Director.delete)
+		director.POST("/:id/photo", h.requirePermission(core.PermDirectorWrite), h.Director.uploadPhoto)
 	}
 
 	movie := router.Group("/movie", h.userIdentity)
 	{
-		movie.POST("/", h.adminIdentity, h.Movie.create)
-		movie.GET("/:id", h.Movie.get)
-		movie.GET("/", h.Movie.getAll)
+		movie.POST("/", h.requirePermission(core.PermMovieWrite), h.Movie.create)
+		movie.GET("/:id", h.requirePermission(core.PermCatalogueRead), h.Movie.get)
+		movie.GET("/", h.requirePermission(core.PermCatalogueRead), h.Movie.getAll)
+		movie.PUT("/:id", h.requirePermission(core.PermMovieWrite), h.Movie.update)
+		movie.PATCH("/:id", h.requirePermission(core.PermMovieWrite), h.Movie.patch)
+		movie.DELETE("/:id", h.requirePermission(core.PermMovieWrite), h.Movie.delete)
+		movie.GET("/:id/rating", h.requireAccount, h.Rating.get)
+		movie.PUT("/:id/rating", h.requireAccount, h.Rating.put)
+		movie.DELETE("/:id/rating", h.requireAccount, h.Rating.delete)
 	}
 
-	list := router.Group("list", h.userIdentity)
+	list := router.Group("list", h.userIdentity, h.requireAccount)
 	{
 		list.POST("/", h.List.create)
 		list.GET("/:id", h.List.get)
 		list.GET("/", h.List.getAll)
 		list.POST("/add", h.List.movieToList)
+		list.PATCH("/:id", h.List.rename)
+		list.DELETE("/:id", h.List.delete)
+		list.DELETE("/:id/movies/:movieId", h.List.movieFromList)
+	}
+
+	me := router.Group("/me", h.userIdentity, h.requireAccount)
+	{
+		me.GET("", h.Account.profile)
+		me.PATCH("", h.Account.updateProfile)
+		me.PUT("/password", h.Account.changePassword)
+		me.DELETE("", h.Account.deleteAccount)
+		me.GET("/export", h.Account.exportAccount)
+		me.PUT("/favorites/:movieId", h.List.movieToSystemList(core.FavoriteList))
+		me.DELETE("/favorites/:movieId", h.List.movieFromSystemList(core.FavoriteList))
+		me.PUT("/wishlist/:movieId", h.List.movieToSystemList(core.WishList))
+		me.DELETE("/wishlist/:movieId", h.List.movieFromSystemList(core.WishList))
 	}
 
 	return router
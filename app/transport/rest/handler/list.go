@@ -69,8 +69,47 @@ func (h *ListHandler) create(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"created with ID": listID})
 }
 
+// Handler returns the list of the authenticated account with the page of its movies.
+// The movies are paginated and sorted with the same rules as the movie catalogue, ex.:
+// /list/:id?f=genre:comedy&s=rate:desc&limit=50&offset=0 .
 func (h *ListHandler) get(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"get": "res"})
+	listID, accountID, ok := h.listOwner(c)
+	if !ok {
+		return
+	}
+
+	var queryParameter core.ConditionParams
+
+	if err := queryParameter.Prepare(c); err != nil {
+		h.logger.Debugw("get list handler", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	list, err := h.service.GetListWithMovies(listID, accountID, queryParameter)
+	if err != nil {
+		if errors.Is(err, core.ErrListNotFound) {
+			h.logger.Debugw("get list handler", "error", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		if errors.Is(err, core.ErrUnkownConditionKey) {
+			h.logger.Debugw("get list handler", "error", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("get list handler", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
 }
 
 // Handler for the movie list getting of the athenticated accaount.
@@ -126,6 +165,8 @@ type requesMovieList struct {
 	MovieID uuid.UUID `json:"movie_id" db:"movie_id" binding:"required"`
 }
 
+// Handler adds the movie to the list of the authenticated account.
+// The list of another account is reported as not found.
 func (h ListHandler) movieToList(c *gin.Context) {
 	input := requesMovieList{}
 
@@ -136,7 +177,22 @@ func (h ListHandler) movieToList(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AddMovieToList(input.ListID.String(), input.MovieID.String()); err != nil {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("Handler movieToList -> getAccountID", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.AddMovieToList(accountID, input.ListID.String(), input.MovieID.String()); err != nil {
+		if errors.Is(err, core.ErrListNotFound) {
+			h.logger.Debugw("Handler movieToList -> AddMovieToList", "error", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
 		if errors.Is(err, core.ErrDuplicateRow) || errors.Is(err, core.ErrForeignKeyViolation) {
 			h.logger.Debugw("Handler movieToList -> AddMovieToList", "error", err.Error())
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -152,3 +208,173 @@ func (h ListHandler) movieToList(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{"action": "successful"})
 }
+
+type inputListType struct {
+	Type string `json:"type" binding:"required"`
+}
+
+// Handler changes the type of the list of the authenticated account.
+func (h ListHandler) rename(c *gin.Context) {
+	listID, accountID, ok := h.listOwner(c)
+	if !ok {
+		return
+	}
+
+	var input inputListType
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("Handler rename -> ShouldBindJSON", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	list, err := h.service.Rename(accountID, listID, input.Type)
+	if err != nil {
+		h.respondListError(c, "Handler rename -> Rename", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Handler deletes the list of the authenticated account with all its movies.
+func (h ListHandler) delete(c *gin.Context) {
+	listID, accountID, ok := h.listOwner(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(accountID, listID); err != nil {
+		h.respondListError(c, "Handler delete -> Delete", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Handler removes the movie from the list of the authenticated account.
+func (h ListHandler) movieFromList(c *gin.Context) {
+	listID, accountID, ok := h.listOwner(c)
+	if !ok {
+		return
+	}
+
+	movieID := c.Param("movieId")
+
+	if _, err := uuid.Parse(movieID); err != nil {
+		h.logger.Debugw("Handler movieFromList -> uuid.Parse", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.RemoveMovieFromList(accountID, listID, movieID); err != nil {
+		h.respondListError(c, "Handler movieFromList -> RemoveMovieFromList", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Returns the handler which adds the movie to the system list of the authenticated account.
+// Adding the movie which is already in the list is successful as well.
+func (h ListHandler) movieToSystemList(listType core.ListType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		movieID, accountID, ok := h.systemListMovie(c)
+		if !ok {
+			return
+		}
+
+		if err := h.service.AddMovieToSystemList(accountID, listType, movieID); err != nil {
+			h.respondListError(c, "Handler movieToSystemList -> AddMovieToSystemList", err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"action": "successful"})
+	}
+}
+
+// Returns the handler which removes the movie from the system list of the authenticated account.
+func (h ListHandler) movieFromSystemList(listType core.ListType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		movieID, accountID, ok := h.systemListMovie(c)
+		if !ok {
+			return
+		}
+
+		if err := h.service.RemoveMovieFromSystemList(accountID, listType, movieID); err != nil {
+			h.respondListError(c, "Handler movieFromSystemList -> RemoveMovieFromSystemList", err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"action": "successful"})
+	}
+}
+
+// Takes the movie ID from the path and the account ID from the context.
+// Writes the response and returns false if any of them is wrong.
+func (h ListHandler) systemListMovie(c *gin.Context) (string, string, bool) {
+	movieID := c.Param("movieId")
+
+	if _, err := uuid.Parse(movieID); err != nil {
+		h.logger.Debugw("Handler systemListMovie -> uuid.Parse", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", "", false
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("Handler systemListMovie -> getAccountID", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", "", false
+	}
+
+	return movieID, accountID, true
+}
+
+// Takes the list ID from the path and the account ID from the context.
+// Writes the response and returns false if any of them is wrong.
+func (h ListHandler) listOwner(c *gin.Context) (string, string, bool) {
+	listID := c.Param("id")
+
+	if _, err := uuid.Parse(listID); err != nil {
+		h.logger.Debugw("Handler listOwner -> uuid.Parse", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", "", false
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("Handler listOwner -> getAccountID", "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", "", false
+	}
+
+	return listID, accountID, true
+}
+
+// Writes the response with the status code which corresponds to the error of the list service.
+func (h ListHandler) respondListError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, core.ErrListNotFound), errors.Is(err, core.ErrNotFound):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, core.ErrDuplicateRow), errors.Is(err, core.ErrForeignKeyViolation),
+		errors.Is(err, core.ErrSystemList):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorw(operation, "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -212,16 +213,137 @@ func TestList_getAll(t *testing.T) {
 	}
 }
 
+func TestList_get(t *testing.T) {
+	log, err := logger.New("DEBUG")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		listID    = "0d3f1a5e-3b5e-4a7f-9b64-1f0e3f1c2a11"
+		accountID = "8c172d76-f750-4369-a5e2-27c877299168"
+	)
+
+	type mockBehavior func(s *MockListsService)
+
+	testCasesTable := map[string]struct {
+		listID               string
+		urlQuery             string
+		userCtx              string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Successful case": {
+			listID:   listID,
+			urlQuery: `?s=rate:desc&limit=50`,
+			userCtx:  userCtx,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().GetListWithMovies(listID, accountID, core.ConditionParams{
+					Limit:  "50",
+					Offset: "0",
+					Export: "none",
+					Sort:   []core.QuerySliceElement{{Key: "rate", Val: "desc"}},
+					CheckList: core.ListValidationFilds{
+						Limit: true, Offset: true, Filter: true, Sort: true, Export: true,
+					},
+				}).Return(core.MovieListDetails{
+					MovieList: core.MovieList{Type: "favorite"},
+					Movies: []core.ListMovie{
+						{Movie: core.Movie{ID: "movie-id", Title: "Titanic"}, DirectorName: "James Cameron"},
+					},
+				}, nil).Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"id":"00000000-0000-0000-0000-000000000000","type":"favorite",` +
+				`"account_id":"00000000-0000-0000-0000-000000000000","created":"","modified":"",` +
+				`"movies":[{"id":"movie-id","title":"Titanic","genre":"","director_id":"","rate":0,"release_date":"",` +
+				`"duration":0,"avg_rate":0,"votes":0,"created":"","modified":"","director_name":"James Cameron"}]}`,
+		},
+		"The list is not found or belongs to another account": {
+			listID:  listID,
+			userCtx: userCtx,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().GetListWithMovies(listID, accountID, gomock.Any()).Return(core.MovieListDetails{},
+					core.ErrListNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"no list found"}`,
+		},
+		"Unallowed sort": {
+			listID:               listID,
+			urlQuery:             `?s=rate:up`,
+			userCtx:              userCtx,
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"query preparetion failed: the sort value: unallowed sort"}`,
+		},
+		"Unkown Error": {
+			listID:  listID,
+			userCtx: userCtx,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().GetListWithMovies(listID, accountID, gomock.Any()).Return(core.MovieListDetails{},
+					errors.New("some error")).Times(1)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"some error"}`,
+		},
+		"Wrong list ID": {
+			listID:               "wrong-id",
+			userCtx:              userCtx,
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"No ID in context": {
+			listID:               listID,
+			userCtx:              "bad",
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"no account found in contex"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			listService := NewMockListsService(ctrl)
+			testCase.mockBehavior(listService)
+
+			lh := NewListHandler(listService, log)
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+			ctx.Request = httptest.NewRequest(http.MethodGet,
+				"/list/"+testCase.listID+testCase.urlQuery, nil)
+			ctx.Params = gin.Params{{Key: "id", Value: testCase.listID}}
+
+			ctx.Set(testCase.userCtx, accountID)
+			lh.get(ctx)
+
+			assert.Equal(t, testCase.expectedStatusCode, response.Code)
+			assert.Equal(t, testCase.expectedResponseBody, response.Body.String())
+		})
+	}
+}
+
 func TestList_movieToList(t *testing.T) {
 	log, err := logger.New("DEBUG")
 	if err != nil {
 		t.FailNow()
 	}
 
+	const accountID = "8c172d76-f750-4369-a5e2-27c877299168"
+
 	type mockBehavior func(s *MockListsService)
 
 	testCasesTable := map[string]struct {
 		inputBody            string
+		userCtx              string
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
@@ -229,15 +351,31 @@ func TestList_movieToList(t *testing.T) {
 		"Successful case": {
 			inputBody: `{"list_id":"e018e175-7813-4969-a99a-ed234afb2dd9","movie_id":"ca160814-59b3-4d1d-8bae-e3772fa0c6fb"}`,
 			mockBehavior: func(s *MockListsService) {
-				s.EXPECT().AddMovieToList("e018e175-7813-4969-a99a-ed234afb2dd9", "ca160814-59b3-4d1d-8bae-e3772fa0c6fb").Return(nil).Times(1)
+				s.EXPECT().AddMovieToList(accountID, "e018e175-7813-4969-a99a-ed234afb2dd9", "ca160814-59b3-4d1d-8bae-e3772fa0c6fb").Return(nil).Times(1)
 			},
 			expectedStatusCode:   http.StatusCreated,
 			expectedResponseBody: `{"action":"successful"}`,
 		},
+		"The list of another account": {
+			inputBody: `{"list_id":"e018e175-7813-4969-a99a-ed234afb2dd9","movie_id":"ca160814-59b3-4d1d-8bae-e3772fa0c6fb"}`,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().AddMovieToList(accountID, "e018e175-7813-4969-a99a-ed234afb2dd9", "ca160814-59b3-4d1d-8bae-e3772fa0c6fb").Return(
+					fmt.Errorf("service authorize list got the error: %w", core.ErrListNotFound)).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"service authorize list got the error: no list found"}`,
+		},
+		"No ID in context": {
+			inputBody:            `{"list_id":"e018e175-7813-4969-a99a-ed234afb2dd9","movie_id":"ca160814-59b3-4d1d-8bae-e3772fa0c6fb"}`,
+			userCtx:              "bad",
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"no account found in contex"}`,
+		},
 		"Unique error": {
 			inputBody: `{"list_id":"e018e175-7813-4969-a99a-ed234afb2dd9","movie_id":"ca160814-59b3-4d1d-8bae-e3772fa0c6fb"}`,
 			mockBehavior: func(s *MockListsService) {
-				s.EXPECT().AddMovieToList("e018e175-7813-4969-a99a-ed234afb2dd9", "ca160814-59b3-4d1d-8bae-e3772fa0c6fb").Return(
+				s.EXPECT().AddMovieToList(accountID, "e018e175-7813-4969-a99a-ed234afb2dd9", "ca160814-59b3-4d1d-8bae-e3772fa0c6fb").Return(
 					core.ErrDuplicateRow).Times(1)
 			},
 			expectedStatusCode:   http.StatusBadRequest,
@@ -246,7 +384,7 @@ func TestList_movieToList(t *testing.T) {
 		"Foreign key violation": {
 			inputBody: `{"list_id":"e018e175-7813-4969-a99a-ed234afb2dd9","movie_id":"ca160814-59b3-4d1d-8bae-e3772fa0c6fb"}`,
 			mockBehavior: func(s *MockListsService) {
-				s.EXPECT().AddMovieToList("e018e175-7813-4969-a99a-ed234afb2dd9", "ca160814-59b3-4d1d-8bae-e3772fa0c6fb").Return(
+				s.EXPECT().AddMovieToList(accountID, "e018e175-7813-4969-a99a-ed234afb2dd9", "ca160814-59b3-4d1d-8bae-e3772fa0c6fb").Return(
 					core.ErrForeignKeyViolation).Times(1)
 			},
 			expectedStatusCode:   http.StatusBadRequest,
@@ -293,7 +431,12 @@ func TestList_movieToList(t *testing.T) {
 			response := httptest.NewRecorder()
 			ctx, router := gin.CreateTestContext(response)
 
-			router.POST("/list/add", lh.movieToList)
+			ctxKey := userCtx
+			if testCase.userCtx != "" {
+				ctxKey = testCase.userCtx
+			}
+
+			router.POST("/list/add", func(c *gin.Context) { c.Set(ctxKey, accountID) }, lh.movieToList)
 
 			ctx.Request = httptest.NewRequest(http.MethodPost, "/list/add", strings.NewReader(testCase.inputBody))
 
@@ -304,3 +447,213 @@ func TestList_movieToList(t *testing.T) {
 		})
 	}
 }
+
+func TestList_mutations(t *testing.T) {
+	log, err := logger.New("DEBUG")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		listID    = "e018e175-7813-4969-a99a-ed234afb2dd9"
+		movieID   = "ca160814-59b3-4d1d-8bae-e3772fa0c6fb"
+		accountID = "8c172d76-f750-4369-a5e2-27c877299168"
+	)
+
+	type mockBehavior func(s *MockListsService)
+
+	testCasesTable := map[string]struct {
+		method               string
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Remove the movie": {
+			method: http.MethodDelete,
+			path:   "/list/" + listID + "/movies/" + movieID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().RemoveMovieFromList(accountID, listID, movieID).Return(nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Remove the movie which is not in the list": {
+			method: http.MethodDelete,
+			path:   "/list/" + listID + "/movies/" + movieID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().RemoveMovieFromList(accountID, listID, movieID).Return(core.ErrNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"nothing was found"}`,
+		},
+		"Remove the movie from the list of another account": {
+			method: http.MethodDelete,
+			path:   "/list/" + listID + "/movies/" + movieID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().RemoveMovieFromList(accountID, listID, movieID).Return(core.ErrListNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"no list found"}`,
+		},
+		"Remove the movie with wrong ID": {
+			method:               http.MethodDelete,
+			path:                 "/list/" + listID + "/movies/wrong-id",
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"Delete the list": {
+			method: http.MethodDelete,
+			path:   "/list/" + listID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().Delete(accountID, listID).Return(nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Delete the list of another account": {
+			method: http.MethodDelete,
+			path:   "/list/" + listID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().Delete(accountID, listID).Return(core.ErrListNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"no list found"}`,
+		},
+		"Delete the list with wrong ID": {
+			method:               http.MethodDelete,
+			path:                 "/list/wrong-id",
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"Rename the list": {
+			method:    http.MethodPatch,
+			path:      "/list/" + listID,
+			inputBody: `{"type":"classics"}`,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().Rename(accountID, listID, "classics").Return(core.MovieList{Type: "classics"}, nil).Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"id":"00000000-0000-0000-0000-000000000000","type":"classics",` +
+				`"account_id":"00000000-0000-0000-0000-000000000000","created":"","modified":""}`,
+		},
+		"Rename the list to the existing type": {
+			method:    http.MethodPatch,
+			path:      "/list/" + listID,
+			inputBody: `{"type":"favorite"}`,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().Rename(accountID, listID, "favorite").Return(core.MovieList{}, core.ErrDuplicateRow).Times(1)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"such record already exists"}`,
+		},
+		"Rename the list of another account": {
+			method:    http.MethodPatch,
+			path:      "/list/" + listID,
+			inputBody: `{"type":"classics"}`,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().Rename(accountID, listID, "classics").Return(core.MovieList{}, core.ErrListNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"no list found"}`,
+		},
+		"Rename the system list": {
+			method:    http.MethodPatch,
+			path:      "/list/" + listID,
+			inputBody: `{"type":"classics"}`,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().Rename(accountID, listID, "classics").Return(core.MovieList{}, core.ErrSystemList).Times(1)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the system list can't be renamed or deleted"}`,
+		},
+		"Add to favorites": {
+			method: http.MethodPut,
+			path:   "/me/favorites/" + movieID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().AddMovieToSystemList(accountID, core.FavoriteList, movieID).Return(nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Add the unknown movie to wishlist": {
+			method: http.MethodPut,
+			path:   "/me/wishlist/" + movieID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().AddMovieToSystemList(accountID, core.WishList, movieID).Return(core.ErrForeignKeyViolation).Times(1)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"some value has no reference to the list or to the movie"}`,
+		},
+		"Add to favorites with wrong movie ID": {
+			method:               http.MethodPut,
+			path:                 "/me/favorites/wrong-id",
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"Remove from wishlist": {
+			method: http.MethodDelete,
+			path:   "/me/wishlist/" + movieID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().RemoveMovieFromSystemList(accountID, core.WishList, movieID).Return(nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Remove the movie which is not in favorites": {
+			method: http.MethodDelete,
+			path:   "/me/favorites/" + movieID,
+			mockBehavior: func(s *MockListsService) {
+				s.EXPECT().RemoveMovieFromSystemList(accountID, core.FavoriteList, movieID).Return(core.ErrNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"nothing was found"}`,
+		},
+		"Rename without type": {
+			method:               http.MethodPatch,
+			path:                 "/list/" + listID,
+			inputBody:            `{}`,
+			mockBehavior:         func(s *MockListsService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputListType.Type' Error:Field validation for 'Type' failed on the 'required' tag"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			listService := NewMockListsService(ctrl)
+			testCase.mockBehavior(listService)
+
+			lh := NewListHandler(listService, log)
+
+			response := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(response)
+
+			setAccount := func(c *gin.Context) { c.Set(userCtx, accountID) }
+
+			router.PATCH("/list/:id", setAccount, lh.rename)
+			router.DELETE("/list/:id", setAccount, lh.delete)
+			router.DELETE("/list/:id/movies/:movieId", setAccount, lh.movieFromList)
+			router.PUT("/me/favorites/:movieId", setAccount, lh.movieToSystemList(core.FavoriteList))
+			router.DELETE("/me/favorites/:movieId", setAccount, lh.movieFromSystemList(core.FavoriteList))
+			router.PUT("/me/wishlist/:movieId", setAccount, lh.movieToSystemList(core.WishList))
+			router.DELETE("/me/wishlist/:movieId", setAccount, lh.movieFromSystemList(core.WishList))
+
+			ctx.Request = httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.inputBody))
+
+			router.ServeHTTP(response, ctx.Request)
+
+			assert.Equal(t, testCase.expectedStatusCode, response.Code)
+			assert.Equal(t, testCase.expectedResponseBody, response.Body.String())
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Brigant/PetPorject/app/core"
 	"github.com/Brigant/PetPorject/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -19,7 +20,7 @@ func TestHandler_UserIdentity(t *testing.T) {
 		t.Error("can't initialize logger")
 	}
 
-	type mockBehavior func(s *MockAccountService, accessToken string)
+	type mockBehavior func(s *MockAccountService, k *MockAPIKeyService, accessToken string)
 
 	tableTestCases := map[string]struct {
 		logger               *logger.Logger
@@ -32,18 +33,20 @@ func TestHandler_UserIdentity(t *testing.T) {
 	}{
 		"Success": {
 			logger: log,
-			mockBehavior: func(s *MockAccountService, accessToken string) {
-				s.EXPECT().ParseToken(accessToken).Return("AccountID-111", "user", nil).Times(1)
+			mockBehavior: func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {
+				s.EXPECT().ParseToken(accessToken).Return(core.Identity{
+					AccountID: "AccountID-111", Role: "user", RefreshToken: "refresh-111",
+				}, nil).Times(1)
 			},
 			accessToken:          "token",
 			headerName:           authoriazahionHeader,
 			headerValue:          "Bearer token",
 			expectedStatusCode:   200,
-			expectedResponseBody: "AccountID-111 user",
+			expectedResponseBody: "AccountID-111 user refresh-111",
 		},
 		"Empty header": {
 			logger:               log,
-			mockBehavior:         func(s *MockAccountService, accessToken string) {},
+			mockBehavior:         func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {},
 			accessToken:          "token",
 			headerName:           "",
 			headerValue:          "Bearer token",
@@ -52,7 +55,7 @@ func TestHandler_UserIdentity(t *testing.T) {
 		},
 		"Invalid Bearer": {
 			logger:               log,
-			mockBehavior:         func(s *MockAccountService, accessToken string) {},
+			mockBehavior:         func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {},
 			accessToken:          "token",
 			headerName:           authoriazahionHeader,
 			headerValue:          "Bearerk token",
@@ -61,17 +64,28 @@ func TestHandler_UserIdentity(t *testing.T) {
 		},
 		"Empty token": {
 			logger:               log,
-			mockBehavior:         func(s *MockAccountService, accessToken string) {},
+			mockBehavior:         func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {},
 			accessToken:          "token",
 			headerName:           authoriazahionHeader,
 			headerValue:          "Bearerk ",
 			expectedStatusCode:   401,
 			expectedResponseBody: `{"error":"invalid header"}`,
 		},
+		"Revoked session": {
+			logger: log,
+			mockBehavior: func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {
+				s.EXPECT().ParseToken(accessToken).Return(core.Identity{}, core.ErrSessionRevoked).Times(1)
+			},
+			accessToken:          "token",
+			headerName:           authoriazahionHeader,
+			headerValue:          "Bearer token",
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"the session of the token is revoked"}`,
+		},
 		"Service Failure": {
 			logger: log,
-			mockBehavior: func(s *MockAccountService, accessToken string) {
-				s.EXPECT().ParseToken(accessToken).Return("", "", errors.New("failed to parse token")).Times(1)
+			mockBehavior: func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {
+				s.EXPECT().ParseToken(accessToken).Return(core.Identity{}, errors.New("failed to parse token")).Times(1)
 			},
 			accessToken:          "token",
 			headerName:           authoriazahionHeader,
@@ -79,6 +93,34 @@ func TestHandler_UserIdentity(t *testing.T) {
 			expectedStatusCode:   401,
 			expectedResponseBody: `{"error":"failed to parse token"}`,
 		},
+		"API key": {
+			logger: log,
+			mockBehavior: func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {
+				k.EXPECT().Authenticate("ppk_secret").Return(core.APIKey{ID: "key-111"}, nil).Times(1)
+			},
+			headerName:           authoriazahionHeader,
+			headerValue:          "ApiKey ppk_secret",
+			expectedStatusCode:   200,
+			expectedResponseBody: "api key key-111",
+		},
+		"Revoked API key": {
+			logger: log,
+			mockBehavior: func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {
+				k.EXPECT().Authenticate("ppk_secret").Return(core.APIKey{}, core.ErrAPIKeyRevoked).Times(1)
+			},
+			headerName:           authoriazahionHeader,
+			headerValue:          "ApiKey ppk_secret",
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"api key is revoked"}`,
+		},
+		"Empty API key": {
+			logger:               log,
+			mockBehavior:         func(s *MockAccountService, k *MockAPIKeyService, accessToken string) {},
+			headerName:           authoriazahionHeader,
+			headerValue:          "ApiKey ",
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"invalid header"}`,
+		},
 	}
 
 	for name, testCase := range tableTestCases {
@@ -87,18 +129,26 @@ func TestHandler_UserIdentity(t *testing.T) {
 			defer ctrl.Finish()
 
 			accountService := NewMockAccountService(ctrl)
-			testCase.mockBehavior(accountService, testCase.accessToken)
+			apiKeyService := NewMockAPIKeyService(ctrl)
+			testCase.mockBehavior(accountService, apiKeyService, testCase.accessToken)
 
-			mw := NewHandler(Deps{AccountService: accountService}, testCase.logger)
+			mw := NewHandler(Deps{AccountService: accountService, APIKeyService: apiKeyService}, testCase.logger)
 
 			// Build and setup Test Server
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
 			r.GET("/protected", mw.userIdentity, func(c *gin.Context) {
+				if key, ok := c.Get(apiKeyCtx); ok {
+					c.String(http.StatusOK, "api key "+key.(core.APIKey).ID)
+
+					return
+				}
+
 				accountID, _ := c.Get(userCtx)
 				role, _ := c.Get(roleCtx)
+				refreshToken, _ := c.Get(refreshTokenCtx)
 
-				c.String(http.StatusOK, fmt.Sprintf("%s %s", accountID, role))
+				c.String(http.StatusOK, fmt.Sprintf("%s %s %s", accountID, role, refreshToken))
 			})
 
 			// Test Request
@@ -116,29 +166,92 @@ func TestHandler_UserIdentity(t *testing.T) {
 	}
 }
 
-func TestHandler_AdminIdentitiy(t *testing.T) {
+func TestHandler_requirePermission(t *testing.T) {
 	log, err := logger.New("INFO")
 	if err != nil {
 		t.Error("can't initialize logger")
 	}
 
 	tableTestCases := map[string]struct {
-		logger               *logger.Logger
 		role                 string
+		apiKey               *core.APIKey
+		permissions          []core.Permission
 		expectedStatusCode   int
 		expectedResponseBody string
 	}{
 		"Empty role": {
-			logger:               log,
 			role:                 "",
+			permissions:          []core.Permission{core.PermMovieWrite},
 			expectedStatusCode:   401,
 			expectedResponseBody: `{"error":"empty role"}`,
 		},
-		"Invalid role": {
-			logger:               log,
-			role:                 "user",
-			expectedStatusCode:   401,
-			expectedResponseBody: `{"error":"you are not admin"}`,
+		"User can't write movies": {
+			role:                 core.RoleUser,
+			permissions:          []core.Permission{core.PermMovieWrite},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you don't have the permission: movie:write"}`,
+		},
+		"Editor writes movies": {
+			role:               core.RoleEditor,
+			permissions:        []core.Permission{core.PermMovieWrite},
+			expectedStatusCode: 200,
+		},
+		"Editor can't manage accounts": {
+			role:                 core.RoleEditor,
+			permissions:          []core.Permission{core.PermAccountManage},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you don't have the permission: account:manage"}`,
+		},
+		"Admin has all the permissions": {
+			role:               core.RoleAdmin,
+			permissions:        []core.Permission{core.PermAccountManage, core.PermDirectorWrite},
+			expectedStatusCode: 200,
+		},
+		"Unknown role": {
+			role:                 "superuser",
+			permissions:          []core.Permission{core.PermDirectorWrite},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you don't have the permission: director:write"}`,
+		},
+		"API key with the scope": {
+			apiKey:             &core.APIKey{ID: "key-111", Scopes: []core.Permission{core.PermMovieWrite}},
+			permissions:        []core.Permission{core.PermMovieWrite},
+			expectedStatusCode: 200,
+		},
+		"API key without the scope": {
+			apiKey:               &core.APIKey{ID: "key-111", Scopes: []core.Permission{core.PermMovieWrite}},
+			permissions:          []core.Permission{core.PermDirectorWrite},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you don't have the permission: director:write"}`,
+		},
+		"User reads the catalogue": {
+			role:               core.RoleUser,
+			permissions:        []core.Permission{core.PermCatalogueRead},
+			expectedStatusCode: 200,
+		},
+		"API key with the read scope reads the catalogue": {
+			apiKey:             &core.APIKey{ID: "key-111", Scopes: []core.Permission{core.PermCatalogueRead}},
+			permissions:        []core.Permission{core.PermCatalogueRead},
+			expectedStatusCode: 200,
+		},
+		"API key without scopes can't read the catalogue": {
+			apiKey:               &core.APIKey{ID: "key-111"},
+			permissions:          []core.Permission{core.PermCatalogueRead},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you don't have the permission: catalogue:read"}`,
+		},
+		"API key with the write scope can't read the catalogue": {
+			apiKey:               &core.APIKey{ID: "key-111", Scopes: []core.Permission{core.PermMovieWrite}},
+			permissions:          []core.Permission{core.PermCatalogueRead},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you don't have the permission: catalogue:read"}`,
+		},
+		"API key doesn't get the role permissions": {
+			role:                 core.RoleAdmin,
+			apiKey:               &core.APIKey{ID: "key-111"},
+			permissions:          []core.Permission{core.PermMovieWrite},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"you don't have the permission: movie:write"}`,
 		},
 	}
 
@@ -151,19 +264,55 @@ func TestHandler_AdminIdentitiy(t *testing.T) {
 			c, _ := gin.CreateTestContext(w)
 			c.Set(roleCtx, testCase.role)
 
+			if testCase.apiKey != nil {
+				c.Set(apiKeyCtx, *testCase.apiKey)
+			}
+
 			middleware := Handler{
-				Account:  AccountHandler{},
-				Director: DirectorHandler{},
-				Movie:    MovieHandler{},
-				List:     ListHandler{},
-				log:      testCase.logger,
+				roles: core.DefaultRolePermissions(),
+				log:   log,
 			}
 
 			// Invoke the middleware.
-			middleware.adminIdentity(c)
+			middleware.requirePermission(testCase.permissions...)(c)
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
 		})
 	}
 }
+
+func TestHandler_requireAccount(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.Error("can't initialize logger")
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	middleware := Handler{log: log}
+
+	router := gin.New()
+	router.GET("/me", func(c *gin.Context) {
+		if c.GetHeader(authoriazahionHeader) == "ApiKey" {
+			c.Set(apiKeyCtx, core.APIKey{ID: "key-111"})
+		}
+	}, middleware.requireAccount, func(c *gin.Context) {
+		c.String(http.StatusOK, "account")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "account", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set(authoriazahionHeader, "ApiKey")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `{"error":"the account login is required, the api key is not accepted"}`, w.Body.String())
+}
@@ -2,26 +2,34 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/Brigant/PetPorject/app/core"
 	"github.com/gin-gonic/gin"
 )
 
 const (
 	authoriazahionHeader = "Authorization"
 	authorizationType    = "Bearer"
+	apiKeyType           = "ApiKey" // The services call the API with the key instead of the access token.
 	userCtx              = "userID"
 	roleCtx              = "userRole"
+	refreshTokenCtx      = "refreshToken"
+	apiKeyCtx            = "apiKey"
 	headerPartsNumber    = 2
-	roleAdmin            = "admin"
 )
 
 var (
 	errEmptyHeader   = errors.New("empty header, expecting Authorization header")
 	errInvalidHeader = errors.New("invalid header")
 	errEmptyRole     = errors.New("empty role")
-	errNotAdmin      = errors.New("you are not admin")
+	errNoPermission  = errors.New("you don't have the permission")
+	errNotStringID   = errors.New("accountID is not string")
+	errNotAPIKey     = errors.New("apiKey is not the api key")
+	// The API key is not tied to the account, so it can't be used on the routes of the account.
+	errAPIKeyRefused = errors.New("the account login is required, the api key is not accepted")
 )
 
 func (h Handler) midlewareWithLogger(c *gin.Context) {
@@ -33,7 +41,9 @@ func (h Handler) midlewareWithLogger(c *gin.Context) {
 	c.Next()
 }
 
-// The middleware checks if there is some registred user.
+// The middleware checks if there is some registred user or the service with the API key.
+// The account is identified by the header "Authorization: Bearer <access token>",
+// the service by the header "Authorization: ApiKey <key>".
 func (h Handler) userIdentity(c *gin.Context) {
 	header := c.GetHeader(authoriazahionHeader)
 	if header == "" {
@@ -55,7 +65,7 @@ func (h Handler) userIdentity(c *gin.Context) {
 		return
 	}
 
-	if headerParts[0] != authorizationType {
+	if headerParts[1] == "" {
 		h.log.Debugw("userIdentify", "error", errInvalidHeader.Error())
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 			"error": errInvalidHeader.Error(),
@@ -64,16 +74,21 @@ func (h Handler) userIdentity(c *gin.Context) {
 		return
 	}
 
-	if headerParts[1] == "" {
+	switch headerParts[0] {
+	case authorizationType:
+		h.accountIdentity(c, headerParts[1])
+	case apiKeyType:
+		h.apiKeyIdentity(c, headerParts[1])
+	default:
 		h.log.Debugw("userIdentify", "error", errInvalidHeader.Error())
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 			"error": errInvalidHeader.Error(),
 		})
-
-		return
 	}
+}
 
-	userID, userRole, err := h.Account.service.ParseToken(headerParts[1])
+func (h Handler) accountIdentity(c *gin.Context, accessToken string) {
+	identity, err := h.Account.service.ParseToken(accessToken)
 	if err != nil {
 		h.log.Debugw("userIdentify", "error", err.Error())
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
@@ -83,29 +98,96 @@ func (h Handler) userIdentity(c *gin.Context) {
 		return
 	}
 
-	c.Set(userCtx, userID)
-	c.Set(roleCtx, userRole)
+	c.Set(userCtx, identity.AccountID)
+	c.Set(roleCtx, identity.Role)
+	c.Set(refreshTokenCtx, identity.RefreshToken)
 }
 
-// This midleware implement the functionality of userIdentity
-// and also check the admin role is present.
-func (h Handler) adminIdentity(c *gin.Context) {
-	role, exist := c.Get(roleCtx)
-	if !exist || role == "" {
-		h.log.Debugw("adminIdentity", "error", errEmptyRole.Error())
+func (h Handler) apiKeyIdentity(c *gin.Context, secret string) {
+	key, err := h.APIKey.service.Authenticate(secret)
+	if err != nil {
+		h.log.Debugw("userIdentify", "error", err.Error())
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-			"error": errEmptyRole.Error(),
+			"error": err.Error(),
 		})
 
 		return
 	}
 
-	if role != roleAdmin {
-		h.log.Debugw("adminIdentity", "error", errNotAdmin.Error())
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-			"error": errNotAdmin.Error(),
+	c.Set(apiKeyCtx, key)
+}
+
+// The middleware goes after userIdentity and refuses the API key
+// on the routes which act on behalf of the account.
+func (h Handler) requireAccount(c *gin.Context) {
+	if _, ok := c.Get(apiKeyCtx); ok {
+		h.log.Debugw("requireAccount", "error", errAPIKeyRefused.Error())
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": errAPIKeyRefused.Error(),
 		})
+	}
+}
 
-		return
+// Returns the middleware which goes after userIdentity and checks the caller is granted all the permissions:
+// the API key by its scopes and the account by its role.
+func (h Handler) requirePermission(permissions ...core.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, err := h.callerPermissions(c)
+		if err != nil {
+			h.log.Debugw("requirePermission", "error", err.Error())
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+
+			return
+		}
+
+		for _, permission := range permissions {
+			if !granted(permission) {
+				h.log.Debugw("requirePermission", "error", errNoPermission.Error(),
+					"role", c.GetString(roleCtx), "permission", permission)
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error": fmt.Sprintf("%s: %s", errNoPermission.Error(), permission),
+				})
+
+				return
+			}
+		}
 	}
 }
+
+// Returns the check of the permissions granted to the API key or to the role of the account.
+func (h Handler) callerPermissions(c *gin.Context) (func(core.Permission) bool, error) {
+	if ctxKey, ok := c.Get(apiKeyCtx); ok {
+		key, ok := ctxKey.(core.APIKey)
+		if !ok {
+			return nil, errNotAPIKey
+		}
+
+		return key.Has, nil
+	}
+
+	role := c.GetString(roleCtx)
+	if role == "" {
+		return nil, errEmptyRole
+	}
+
+	return func(permission core.Permission) bool {
+		return h.roles.Has(role, permission)
+	}, nil
+}
+
+// Returns the account ID which was set to the context by the userIdentity middleware.
+func getAccountID(c *gin.Context) (string, error) {
+	ctxAccountID, ok := c.Get(userCtx)
+	if !ok {
+		return "", core.ErrContexAccountNotFound
+	}
+
+	accountID, ok := ctxAccountID.(string)
+	if !ok {
+		return "", errNotStringID
+	}
+
+	return accountID, nil
+}
@@ -101,12 +101,21 @@ func (h *MovieHandler) get(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, core.ETag(movie.Modified)) {
+		c.Status(http.StatusNotModified)
+
+		return
+	}
+
+	setETag(c, core.ETag(movie.Modified))
+
 	c.JSON(http.StatusOK, movie)
 }
 
 // Handler is for the movie's list recievcing weighted by parameters. The full example of url query:
 // /movie/?offset=3&f=genre:comedy&f=rate:10&s=duration:desc&s=rate:asc&s=release_date:asc&limit=100&export=csv
 // The allowed values for s[...] are "desc" or "asc", for export: "csv" or "none".
+// The rate key relates to the average of the users ratings, so f=rate:7.9 returns movies rated not lower than 7.9.
 func (h *MovieHandler) getAll(c *gin.Context) {
 	queryParameter, err := h.prepareQueryParams(c)
 	if err != nil {
@@ -127,6 +136,13 @@ func (h *MovieHandler) getAll(c *gin.Context) {
 				return
 			}
 
+			if errors.Is(err, core.ErrUnkownConditionKey) {
+				h.logger.Debugw("bad query", "error", err.Error())
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+				return
+			}
+
 			h.logger.Debugw("Service Getlist", "error", err.Error())
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 
@@ -153,6 +169,13 @@ func (h *MovieHandler) getAll(c *gin.Context) {
 				return
 			}
 
+			if errors.Is(err, core.ErrUnkownConditionKey) {
+				h.logger.Debugw("bad query", "error", err.Error())
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+				return
+			}
+
 			h.logger.Debugw("Service Getlist", "error", err.Error())
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 
@@ -163,6 +186,185 @@ func (h *MovieHandler) getAll(c *gin.Context) {
 	}
 }
 
+// Handler for the full update of the movie. The body has the same rules as for the movie creation.
+// If the If-Match header is present, the movie is updated only if it has not been changed since.
+func (h *MovieHandler) update(c *gin.Context) {
+	id, ok := h.parseMovieID(c)
+	if !ok {
+		return
+	}
+
+	expectedModified, err := ifMatchModified(c)
+	if err != nil {
+		h.logger.Debugw("ifMatchModified", "error", err.Error())
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var movie core.Movie
+
+	if err := c.ShouldBindJSON(&movie); err != nil {
+		h.logger.Debugw("Should bind with movie enteties", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	movie.ID = id
+	movie.Modified = expectedModified
+
+	h.saveMovie(c, movie)
+}
+
+// Handler for the partial update of the movie. Only the fields present in the body are changed,
+// the result is validated with the same rules as for the movie creation.
+// The changes are stored only if nobody has changed the movie since it was read here
+// or since the version from the If-Match header.
+func (h *MovieHandler) patch(c *gin.Context) {
+	id, ok := h.parseMovieID(c)
+	if !ok {
+		return
+	}
+
+	expectedModified, err := ifMatchModified(c)
+	if err != nil {
+		h.logger.Debugw("ifMatchModified", "error", err.Error())
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	movie, err := h.service.Get(id)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			h.logger.Debugw("Get movie", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("Get movie", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if expectedModified != "" && expectedModified != movie.Modified {
+		h.logger.Debugw("patch movie", "error", core.ErrPreconditionFailed.Error())
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": core.ErrPreconditionFailed.Error()})
+
+		return
+	}
+
+	storedModified := movie.Modified
+
+	// The body is decoded over the stored movie, so the absent fields keep their values.
+	if err := c.ShouldBindJSON(&movie); err != nil {
+		h.logger.Debugw("Should bind with movie enteties", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	movie.ID = id
+	movie.Modified = storedModified
+
+	h.saveMovie(c, movie)
+}
+
+// Handler for the movie deletion. The movie which is in some lists is deleted only with cascade=true
+// query parameter, otherwise the response is 409 with the number of such lists.
+func (h *MovieHandler) delete(c *gin.Context) {
+	id, ok := h.parseMovieID(c)
+	if !ok {
+		return
+	}
+
+	cascade := c.Query("cascade") == "true"
+
+	listCount, err := h.service.DeleteMovie(id, cascade)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			h.logger.Debugw("DeleteMovie", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		if errors.Is(err, core.ErrMovieInLists) {
+			h.logger.Debugw("DeleteMovie", "error", err.Error())
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "lists": listCount})
+
+			return
+		}
+
+		h.logger.Errorw("DeleteMovie", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful", "removed_from_lists": listCount})
+}
+
+// Checks the director ID of the movie and stores the changes.
+func (h *MovieHandler) saveMovie(c *gin.Context, movie core.Movie) {
+	if _, err := uuid.Parse(movie.DirectorID); err != nil {
+		h.logger.Debugw("Parse: directorID not uuid", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	modified, err := h.service.UpdateMovie(movie)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			h.logger.Debugw("UpdateMovie", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		if errors.Is(err, core.ErrForeignViolation) || errors.Is(err, core.ErrUniqueMovie) {
+			h.logger.Debugw("UpdateMovie", "error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		if errors.Is(err, core.ErrPreconditionFailed) {
+			h.logger.Debugw("UpdateMovie", "error", err.Error())
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("UpdateMovie", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	setETag(c, core.ETag(modified))
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Takes the movie ID from the path. Writes the response and returns false if it is not UUID.
+func (h *MovieHandler) parseMovieID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.logger.Debugw("ID is not UUID", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", false
+	}
+
+	return id, true
+}
+
 func (h MovieHandler) prepareQueryParams(c *gin.Context) (core.ConditionParams, error) {
 	var queryParameter core.ConditionParams
 	queryParameter.CheckList.Export = true
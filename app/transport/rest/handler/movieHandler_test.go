@@ -243,7 +243,7 @@ func TestMovie_get(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"id":"6b823d5e-3d37-4617-a568-226e2e31a4f4","title":"TestTitle","genre":"","director_id":"","rate":0,"release_date":"","duration":0,"created":"","modified":""}`,
+			expectedResponseBody: `{"id":"6b823d5e-3d37-4617-a568-226e2e31a4f4","title":"TestTitle","genre":"","director_id":"","rate":0,"release_date":"","duration":0,"avg_rate":0,"votes":0,"created":"","modified":""}`,
 		},
 		"Not found in params": {
 			inputID:              "6b823d5e-3d37-4617-a568-226e2e31a4f4",
@@ -309,6 +309,77 @@ func TestMovie_get(t *testing.T) {
 	}
 }
 
+func TestMovie_getConditional(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	movie := core.Movie{
+		ID:       "6b823d5e-3d37-4617-a568-226e2e31a4f4",
+		Modified: "2023-04-01T10:00:00.123456+03:00",
+	}
+
+	testCasesTable := map[string]struct {
+		ifNoneMatch        string
+		expectedStatusCode int
+		expectedEmptyBody  bool
+	}{
+		"No header": {
+			ifNoneMatch:        "",
+			expectedStatusCode: http.StatusOK,
+		},
+		"Actual version": {
+			ifNoneMatch:        core.ETag(movie.Modified),
+			expectedStatusCode: http.StatusNotModified,
+			expectedEmptyBody:  true,
+		},
+		"One of the versions is actual": {
+			ifNoneMatch:        `"stale", W/` + core.ETag(movie.Modified),
+			expectedStatusCode: http.StatusNotModified,
+			expectedEmptyBody:  true,
+		},
+		"Stale version": {
+			ifNoneMatch:        core.ETag("2023-03-01T10:00:00.123456+03:00"),
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			movieService := NewMockMovieService(ctrl)
+			movieService.EXPECT().Get(movie.ID).Return(movie, nil).Times(1)
+
+			mh := NewMovieHandler(movieService, log)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			r.GET("/movie/:id", mh.get)
+
+			req := httptest.NewRequest(http.MethodGet, "/movie/"+movie.ID, nil)
+
+			if testCase.ifNoneMatch != "" {
+				req.Header.Set(ifNoneMatchHeader, testCase.ifNoneMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedEmptyBody, w.Body.Len() == 0)
+
+			if !testCase.expectedEmptyBody {
+				assert.Equal(t, core.ETag(movie.Modified), w.Header().Get(etagHeader))
+			}
+		})
+	}
+}
+
 func TestMovie_getAll(t *testing.T) {
 	log, err := logger.New("INFO")
 	if err != nil {
@@ -330,7 +401,7 @@ func TestMovie_getAll(t *testing.T) {
 				}, nil).Times(1)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `[{"id":"movie-id-1","title":"","genre":"","director_id":"","rate":0,"release_date":"","duration":0,"created":"","modified":""}]`,
+			expectedResponseBody: `[{"id":"movie-id-1","title":"","genre":"","director_id":"","rate":0,"release_date":"","duration":0,"avg_rate":0,"votes":0,"created":"","modified":""}]`,
 		},
 		"Internal Server error": {
 			queryPath: "/movie/?f=genre:comedy",
@@ -350,6 +421,15 @@ func TestMovie_getAll(t *testing.T) {
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"alert":"nothing was found"}`,
 		},
+		"Filter key unknown to the storage": {
+			queryPath: "/movie/?f=type:favorite",
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().GetList(gomock.Any()).Return(nil,
+					core.ErrUnkownConditionKey).Times(1)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"condition has unknown parameters"}`,
+		},
 		"Wronge filter key": {
 			queryPath: "/movie/?f=wronKey:comedy",
 			mockBehavior: func(s *MockMovieService) {
@@ -361,7 +441,15 @@ func TestMovie_getAll(t *testing.T) {
 			queryPath:            "/movie/?f=rate:badData",
 			mockBehavior:         func(s *MockMovieService) {},
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"query preparetion failed: the value shlould be an integer: strconv.Atoi: parsing \"badData\": invalid syntax"}`,
+			expectedResponseBody: `{"error":"query preparetion failed: the value shlould be a number: strconv.ParseFloat: parsing \"badData\": invalid syntax"}`,
+		},
+		"Fractional rate value": {
+			queryPath: "/movie/?f=rate:7.9&s=votes:desc",
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().GetList(gomock.Any()).Return([]core.Movie{}, nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `[]`,
 		},
 		"Rate value outrange": {
 			queryPath:            "/movie/?f=rate:11",
@@ -407,7 +495,7 @@ func TestMovie_getAll(t *testing.T) {
 				}, nil).Times(1)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: "Number,Title,Genre,Director,Rate,Release_Date,Duration/Min\n0,supermovie,,,0,0001-01-01,0\n",
+			expectedResponseBody: "Number,Title,Genre,Director,Rate,Release_Date,Duration/Min,Average_Rate,Votes\n0,supermovie,,,0,0001-01-01,0,0,0\n",
 		},
 		"UnSuccessful export": {
 			queryPath: "/movie/?export=csv",
@@ -447,3 +535,333 @@ func TestMovie_getAll(t *testing.T) {
 		})
 	}
 }
+
+func TestMovie_update(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const movieID = "6b823d5e-3d37-4617-a568-226e2e31a4f4"
+
+	validBody := `{
+		"title":"Avatar2",
+		"genre":"Adventure",
+		"director_id": "bed41cca-ee04-4975-ad7e-5b142e8a9306",
+		"rate":1,
+		"release_date":"2023-01-01",
+		"duration":10800
+	}`
+
+	type mockBehavior func(s *MockMovieService)
+
+	testCasesTable := map[string]struct {
+		movieID              string
+		inputBody            string
+		ifMatch              string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		"Successful case": {
+			movieID:   movieID,
+			inputBody: validBody,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().UpdateMovie(core.Movie{
+					ID:          movieID,
+					Title:       "Avatar2",
+					Genre:       "Adventure",
+					DirectorID:  "bed41cca-ee04-4975-ad7e-5b142e8a9306",
+					Rate:        1,
+					ReleaseDate: "2023-01-01",
+					Duration:    10800,
+				}).Return("2023-04-01T10:00:00.123456+03:00", nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         core.ETag("2023-04-01T10:00:00.123456+03:00"),
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Conditional update": {
+			movieID:   movieID,
+			inputBody: validBody,
+			ifMatch:   core.ETag("2023-04-01T10:00:00.123456+03:00"),
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().UpdateMovie(gomock.Any()).DoAndReturn(func(movie core.Movie) (string, error) {
+					assert.Equal(t, "2023-04-01T10:00:00.123456+03:00", movie.Modified)
+
+					return "2023-04-02T10:00:00.123456+03:00", nil
+				}).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         core.ETag("2023-04-02T10:00:00.123456+03:00"),
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Modified by someone else": {
+			movieID:   movieID,
+			inputBody: validBody,
+			ifMatch:   core.ETag("2023-04-01T10:00:00.123456+03:00"),
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().UpdateMovie(gomock.Any()).Return("", core.ErrPreconditionFailed).Times(1)
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"the entity was modified since it was read"}`,
+		},
+		"Malformed If-Match": {
+			movieID:              movieID,
+			inputBody:            validBody,
+			ifMatch:              "not-etag",
+			mockBehavior:         func(s *MockMovieService) {},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"the entity was modified since it was read"}`,
+		},
+		"Wrong movie ID": {
+			movieID:              "wrong-id",
+			inputBody:            validBody,
+			mockBehavior:         func(s *MockMovieService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"Empty title": {
+			movieID:              movieID,
+			inputBody:            `{"genre":"Adventure","director_id":"bed41cca-ee04-4975-ad7e-5b142e8a9306","release_date":"2023-01-01","duration":1}`,
+			mockBehavior:         func(s *MockMovieService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'Movie.Title' Error:Field validation for 'Title' failed on the 'required' tag"}`,
+		},
+		"Not found": {
+			movieID:   movieID,
+			inputBody: validBody,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().UpdateMovie(gomock.Any()).Return("", core.ErrNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"nothing was found"}`,
+		},
+		"Wrong director": {
+			movieID:   movieID,
+			inputBody: validBody,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().UpdateMovie(gomock.Any()).Return("", core.ErrForeignViolation).Times(1)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"wrong foreign key"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			movieService := NewMockMovieService(ctrl)
+			testCase.mockBehavior(movieService)
+
+			mh := NewMovieHandler(movieService, log)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			r.PUT("/movie/:id", mh.update)
+
+			req := httptest.NewRequest(http.MethodPut, "/movie/"+testCase.movieID,
+				strings.NewReader(testCase.inputBody))
+
+			if testCase.ifMatch != "" {
+				req.Header.Set(ifMatchHeader, testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedETag, w.Header().Get(etagHeader))
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestMovie_patch(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const movieID = "6b823d5e-3d37-4617-a568-226e2e31a4f4"
+
+	storedMovie := core.Movie{
+		ID:          movieID,
+		Title:       "Avatr2",
+		Genre:       "Adventure",
+		DirectorID:  "bed41cca-ee04-4975-ad7e-5b142e8a9306",
+		Rate:        1,
+		ReleaseDate: "2023-01-01",
+		Duration:    10800,
+		Modified:    "2023-04-01T10:00:00.123456+03:00",
+	}
+
+	type mockBehavior func(s *MockMovieService)
+
+	testCasesTable := map[string]struct {
+		inputBody            string
+		ifMatch              string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Successful case": {
+			inputBody: `{"title":"Avatar2","id":"11111111-1111-1111-1111-111111111111","modified":"2000-01-01T00:00:00Z"}`,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().Get(movieID).Return(storedMovie, nil).Times(1)
+
+				patched := storedMovie
+				patched.Title = "Avatar2"
+
+				s.EXPECT().UpdateMovie(patched).Return("2023-04-02T10:00:00.123456+03:00", nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"If-Match of the stale version": {
+			inputBody: `{"title":"Avatar2"}`,
+			ifMatch:   core.ETag("2023-03-01T10:00:00.123456+03:00"),
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().Get(movieID).Return(storedMovie, nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"the entity was modified since it was read"}`,
+		},
+		"Changed between reading and writing": {
+			inputBody: `{"title":"Avatar2"}`,
+			ifMatch:   core.ETag(storedMovie.Modified),
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().Get(movieID).Return(storedMovie, nil).Times(1)
+				s.EXPECT().UpdateMovie(gomock.Any()).Return("", core.ErrPreconditionFailed).Times(1)
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"the entity was modified since it was read"}`,
+		},
+		"Patched value breaks the rules": {
+			inputBody: `{"duration":0}`,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().Get(movieID).Return(storedMovie, nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'Movie.Duration' Error:Field validation for 'Duration' failed on the 'gte' tag"}`,
+		},
+		"Not found": {
+			inputBody: `{"title":"Avatar2"}`,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().Get(movieID).Return(core.Movie{}, core.ErrNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"nothing was found"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			movieService := NewMockMovieService(ctrl)
+			testCase.mockBehavior(movieService)
+
+			mh := NewMovieHandler(movieService, log)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			r.PATCH("/movie/:id", mh.patch)
+
+			req := httptest.NewRequest(http.MethodPatch, "/movie/"+movieID, strings.NewReader(testCase.inputBody))
+
+			if testCase.ifMatch != "" {
+				req.Header.Set(ifMatchHeader, testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestMovie_delete(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const movieID = "6b823d5e-3d37-4617-a568-226e2e31a4f4"
+
+	type mockBehavior func(s *MockMovieService)
+
+	testCasesTable := map[string]struct {
+		queryPath            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Successful case": {
+			queryPath: "/movie/" + movieID,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().DeleteMovie(movieID, false).Return(0, nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful","removed_from_lists":0}`,
+		},
+		"Movie is in lists": {
+			queryPath: "/movie/" + movieID,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().DeleteMovie(movieID, false).Return(3, core.ErrMovieInLists).Times(1)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"the movie is still referenced by the lists","lists":3}`,
+		},
+		"Cascade deletion": {
+			queryPath: "/movie/" + movieID + "?cascade=true",
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().DeleteMovie(movieID, true).Return(3, nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful","removed_from_lists":3}`,
+		},
+		"Not found": {
+			queryPath: "/movie/" + movieID,
+			mockBehavior: func(s *MockMovieService) {
+				s.EXPECT().DeleteMovie(movieID, false).Return(0, core.ErrNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"nothing was found"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			movieService := NewMockMovieService(ctrl)
+			testCase.mockBehavior(movieService)
+
+			mh := NewMovieHandler(movieService, log)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			r.DELETE("/movie/:id", mh.delete)
+
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, testCase.queryPath, nil))
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
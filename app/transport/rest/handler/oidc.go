@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// The cookie binds the state of the login to the browser which started it.
+	oidcStateCookie = "oidc_state"
+	oidcCookiePath  = "/auth/oidc"
+)
+
+var errOIDCStateMismatch = errors.New("the single sign-on login was not started in this browser")
+
+type inputOIDCCallback struct {
+	Code             string `form:"code"`
+	State            string `form:"state" binding:"required"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+// Redirects the user to the identity provider to log in.
+func (h AccountHandler) oidcLogin(c *gin.Context) {
+	authURL, login, err := h.service.StartOIDCLogin()
+	if err != nil {
+		h.respondOIDCError(c, "StartOIDCLogin", err)
+
+		return
+	}
+
+	setOIDCStateCookie(c, login.State, time.Until(login.Expired))
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// The identity provider redirects the user back here with the code or the error.
+func (h AccountHandler) oidcCallback(c *gin.Context) {
+	var input inputOIDCCallback
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.logger.Debugw("ShouldBindQuery", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if input.Error != "" {
+		h.logger.Debugw("oidcCallback", "error", input.Error, "description", input.ErrorDescription)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": core.ErrOIDCAuthFailed.Error()})
+
+		return
+	}
+
+	if input.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "the code is required"})
+
+		return
+	}
+
+	// Otherwise whoever gets the callback URL would log the victim's browser in to the own account.
+	stateCookie, err := c.Cookie(oidcStateCookie)
+	setOIDCStateCookie(c, "", -1)
+
+	if err != nil || subtle.ConstantTimeCompare([]byte(stateCookie), []byte(input.State)) != 1 {
+		h.logger.Warnw("security event: the single sign-on state doesn't match the cookie",
+			"clientIP", c.ClientIP())
+		c.JSON(http.StatusBadRequest, gin.H{"error": errOIDCStateMismatch.Error()})
+
+		return
+	}
+
+	session := core.Session{
+		RequestHost: c.Request.Host,
+		ClientIP:    c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	}
+
+	tokenPair, err := h.service.FinishOIDCLogin(input.Code, input.State, session)
+	if err != nil {
+		h.respondOIDCError(c, "FinishOIDCLogin", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenPair)
+}
+
+// Writes the response with the status code which corresponds to the error of the single sign-on.
+func (h AccountHandler) respondOIDCError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, core.ErrOIDCDisabled):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusNotFound, gin.H{"error": core.ErrOIDCDisabled.Error()})
+	case errors.Is(err, core.ErrOIDCLoginNotFound):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": core.ErrOIDCLoginNotFound.Error()})
+	case errors.Is(err, core.ErrOIDCAuthFailed):
+		h.logger.Warnw(operation, "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": core.ErrOIDCAuthFailed.Error()})
+	case errors.Is(err, core.ErrOIDCPhoneRequired):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusForbidden, gin.H{"error": core.ErrOIDCPhoneRequired.Error()})
+	case errors.Is(err, core.ErrAccountDisabled):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusForbidden, gin.H{"error": core.ErrAccountDisabled.Error()})
+	case errors.Is(err, core.ErrOIDCPhoneNotLinked):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusConflict, gin.H{"error": core.ErrOIDCPhoneNotLinked.Error()})
+	default:
+		h.logger.Errorw(operation, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Sets the cookie of the login state, it is deleted if the lifetime is not positive.
+// SameSite=Lax still sends it on the redirect from the provider, which is the top-level navigation.
+func setOIDCStateCookie(c *gin.Context, state string, ttl time.Duration) {
+	maxAge := int(ttl.Seconds())
+	if maxAge <= 0 {
+		maxAge = -1
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     oidcCookiePath,
+		MaxAge:   maxAge,
+		Secure:   c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHandler_oidc(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const authURL = "https://idp.example.com/authorize?client_id=movies-api&state=state-111"
+
+	type mockBehavior func(s *MockAccountService)
+
+	testCasesTable := map[string]struct {
+		path                 string
+		mockBehavior         mockBehavior
+		stateCookie          string
+		expectedStatusCode   int
+		expectedLocation     string
+		expectedResponseBody string
+	}{
+		"Login redirects to the provider": {
+			path: "/auth/oidc/login",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().StartOIDCLogin().
+					Return(authURL, core.OIDCLogin{State: "state-111", Expired: time.Now().Add(10 * time.Minute)}, nil)
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedLocation:   authURL,
+		},
+		"Login when the single sign-on is not configured": {
+			path: "/auth/oidc/login",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().StartOIDCLogin().Return("", core.OIDCLogin{}, core.ErrOIDCDisabled)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"the single sign-on is not configured"}`,
+		},
+		"Login failed": {
+			path: "/auth/oidc/login",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().StartOIDCLogin().Return("", core.OIDCLogin{}, errors.New("provider is unavailable"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"provider is unavailable"}`,
+		},
+		"Callback issues the token pair": {
+			path:        "/auth/oidc/callback?code=code-111&state=state-111",
+			stateCookie: "state-111",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().FinishOIDCLogin("code-111", "state-111", gomock.Any()).
+					Return(core.TokenPair{AccessToken: "access-111", RefreshToken: "refresh-111", ExpiresIn: 60}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"AccessToken":"access-111","RefreshToken":"refresh-111","expires_in":60}`,
+		},
+		"Callback without the state cookie": {
+			path:                 "/auth/oidc/callback?code=code-111&state=state-111",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the single sign-on login was not started in this browser"}`,
+		},
+		"Callback with the state cookie of another login": {
+			path:                 "/auth/oidc/callback?code=code-111&state=state-111",
+			stateCookie:          "state-222",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the single sign-on login was not started in this browser"}`,
+		},
+		"Callback without the state": {
+			path:                 "/auth/oidc/callback?code=code-111",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputOIDCCallback.State' Error:Field validation for 'State' failed on the 'required' tag"}`,
+		},
+		"Callback without the code": {
+			path:                 "/auth/oidc/callback?state=state-111",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the code is required"}`,
+		},
+		"Callback with the error of the provider": {
+			path:                 "/auth/oidc/callback?error=access_denied&state=state-111",
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"the identity provider login failed"}`,
+		},
+		"Callback with the unknown state": {
+			path:        "/auth/oidc/callback?code=code-111&state=state-111",
+			stateCookie: "state-111",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().FinishOIDCLogin("code-111", "state-111", gomock.Any()).
+					Return(core.TokenPair{}, fmt.Errorf("service FinishOIDCLogin got the error: %w", core.ErrOIDCLoginNotFound))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the single sign-on login is not found or expired"}`,
+		},
+		"Callback with the rejected code": {
+			path:        "/auth/oidc/callback?code=code-111&state=state-111",
+			stateCookie: "state-111",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().FinishOIDCLogin("code-111", "state-111", gomock.Any()).
+					Return(core.TokenPair{}, fmt.Errorf("service FinishOIDCLogin got the error: %w", core.ErrOIDCAuthFailed))
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"the identity provider login failed"}`,
+		},
+		"Callback without the verified phone": {
+			path:        "/auth/oidc/callback?code=code-111&state=state-111",
+			stateCookie: "state-111",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().FinishOIDCLogin("code-111", "state-111", gomock.Any()).
+					Return(core.TokenPair{}, fmt.Errorf("service FinishOIDCLogin got the error: %w", core.ErrOIDCPhoneRequired))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"the identity provider didn't share the verified phone"}`,
+		},
+		"Callback for the account with the unverified phone": {
+			path:        "/auth/oidc/callback?code=code-111&state=state-111",
+			stateCookie: "state-111",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().FinishOIDCLogin("code-111", "state-111", gomock.Any()).
+					Return(core.TokenPair{}, fmt.Errorf("service FinishOIDCLogin got the error: %w", core.ErrOIDCPhoneNotLinked))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"the account with the phone exists, verify its phone to link the single sign-on"}`,
+		},
+		"Callback for the disabled account": {
+			path:        "/auth/oidc/callback?code=code-111&state=state-111",
+			stateCookie: "state-111",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().FinishOIDCLogin("code-111", "state-111", gomock.Any()).
+					Return(core.TokenPair{}, core.ErrAccountDisabled)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"` + core.ErrAccountDisabled.Error() + `"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountService := NewMockAccountService(ctrl)
+			testCase.mockBehavior(accountService)
+
+			accountHandler := AccountHandler{service: accountService, logger: log}
+
+			router := gin.New()
+			router.GET("/auth/oidc/login", accountHandler.oidcLogin)
+			router.GET("/auth/oidc/callback", accountHandler.oidcCallback)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+
+			if testCase.stateCookie != "" {
+				req.AddCookie(&http.Cookie{Name: "oidc_state", Value: testCase.stateCookie})
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+
+			if testCase.expectedLocation != "" {
+				assert.Equal(t, testCase.expectedLocation, w.Header().Get("Location"))
+
+				cookies := w.Result().Cookies()
+				if assert.Len(t, cookies, 1) {
+					assert.Equal(t, "oidc_state", cookies[0].Name)
+					assert.Equal(t, "state-111", cookies[0].Value)
+					assert.Equal(t, "/auth/oidc", cookies[0].Path)
+					assert.True(t, cookies[0].HttpOnly)
+					assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+					assert.InDelta(t, 600, cookies[0].MaxAge, 5)
+				}
+
+				return
+			}
+
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
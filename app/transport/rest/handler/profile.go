@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/gin-gonic/gin"
+)
+
+type inputProfile struct {
+	Age int `json:"age" binding:"required,gte=1,lte=120"`
+}
+
+type inputPasswordChange struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=255,ascii"`
+}
+
+type inputAccountDeletion struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// Returns the account of the caller.
+func (h AccountHandler) profile(c *gin.Context) {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("profile", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	account, err := h.service.Profile(accountID)
+	if err != nil {
+		h.respondProfileError(c, "Profile", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// Changes the age of the caller.
+func (h AccountHandler) updateProfile(c *gin.Context) {
+	var input inputProfile
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("updateProfile", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	account, err := h.service.UpdateAge(accountID, input.Age)
+	if err != nil {
+		h.respondProfileError(c, "UpdateAge", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// Changes the password of the caller and revokes the other sessions.
+func (h AccountHandler) changePassword(c *gin.Context) {
+	var input inputPasswordChange
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("changePassword", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	err = h.service.ChangePassword(accountID, c.GetString(refreshTokenCtx), input.CurrentPassword, input.NewPassword)
+	if err != nil {
+		h.respondProfileError(c, "ChangePassword", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Deletes the account of the caller with all its data and responds with the report of the removed rows.
+func (h AccountHandler) deleteAccount(c *gin.Context) {
+	var input inputAccountDeletion
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("deleteAccount", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	report, err := h.service.DeleteAccount(accountID, input.Password)
+	if err != nil {
+		h.respondProfileError(c, "DeleteAccount", err)
+
+		return
+	}
+
+	h.logger.Infow("account erased", "accountID", report.AccountID, "sessions", report.Sessions,
+		"lists", report.Lists, "listMovies", report.ListMovies, "ratings", report.Ratings,
+		"identities", report.Identities, "oneTimeCodes", report.OneTimeCodes)
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Returns everything stored about the caller as the JSON file.
+func (h AccountHandler) exportAccount(c *gin.Context) {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("exportAccount", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	export, err := h.service.ExportAccount(accountID)
+	if err != nil {
+		h.respondProfileError(c, "ExportAccount", err)
+
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="account-export.json"`)
+	c.JSON(http.StatusOK, export)
+}
+
+// Writes the response with the status code which corresponds to the error of the profile service.
+func (h AccountHandler) respondProfileError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, core.ErrUserNotFound):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusNotFound, gin.H{"error": core.ErrUserNotFound.Error()})
+	case errors.As(err, &core.LoginLockedError{}):
+		h.logger.Warnw(operation, "error", err.Error())
+		c.Header("Retry-After", retryAfterSeconds(err))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": core.ErrLoginLocked.Error()})
+	case errors.Is(err, core.ErrWrongPassword):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusForbidden, gin.H{"error": core.ErrWrongPassword.Error()})
+	case errors.Is(err, core.ErrPasswordTooLong):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": core.ErrPasswordTooLong.Error()})
+	default:
+		h.logger.Errorw(operation, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHandler_profile(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const accountID = "2e6a2b3e-0000-4000-8000-000000000001"
+
+	created := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	account := core.AccountInfo{
+		ID: accountID, Phone: "+380999999999", Age: 31, Role: core.RoleUser, Created: created, Modified: created,
+	}
+	accountJSON := `{"id":"` + accountID + `","phone":"+380999999999","age":31,"role":"user","disabled":false,` +
+		`"phone_verified":false,"created":"2023-05-01T10:00:00Z","modified":"2023-05-01T10:00:00Z"}`
+
+	type mockBehavior func(s *MockAccountService)
+
+	testCasesTable := map[string]struct {
+		method               string
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedRetryAfter   string
+		expectedResponseBody string
+	}{
+		"Get profile": {
+			method: http.MethodGet,
+			path:   "/me",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().Profile(accountID).Return(account, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: accountJSON,
+		},
+		"Get profile of deleted account": {
+			method: http.MethodGet,
+			path:   "/me",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().Profile(accountID).Return(core.AccountInfo{}, core.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"user is not found with such credentials"}`,
+		},
+		"Update age": {
+			method:    http.MethodPatch,
+			path:      "/me",
+			inputBody: `{"age":31}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().UpdateAge(accountID, 31).Return(account, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: accountJSON,
+		},
+		"Update age out of range": {
+			method:               http.MethodPatch,
+			path:                 "/me",
+			inputBody:            `{"age":130}`,
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputProfile.Age' Error:Field validation for 'Age' failed on the 'lte' tag"}`,
+		},
+		"Change password": {
+			method:    http.MethodPut,
+			path:      "/me/password",
+			inputBody: `{"current_password":"password1234","new_password":"password5678"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ChangePassword(accountID, "refresh-111", "password1234", "password5678").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Change password with wrong current one": {
+			method:    http.MethodPut,
+			path:      "/me/password",
+			inputBody: `{"current_password":"password0000","new_password":"password5678"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ChangePassword(accountID, "refresh-111", "password0000", "password5678").
+					Return(fmt.Errorf("service ChangePassword got the error: %w", core.ErrWrongPassword))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"wrong passord"}`,
+		},
+		"Change password while the guessing is locked": {
+			method:    http.MethodPut,
+			path:      "/me/password",
+			inputBody: `{"current_password":"password0000","new_password":"password5678"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ChangePassword(accountID, "refresh-111", "password0000", "password5678").
+					Return(fmt.Errorf("service ChangePassword got the error: %w",
+						core.LoginLockedError{RetryAfter: 29500 * time.Millisecond}))
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedRetryAfter:   "30",
+			expectedResponseBody: `{"error":"too many failed login attempts"}`,
+		},
+		"Change to short password": {
+			method:               http.MethodPut,
+			path:                 "/me/password",
+			inputBody:            `{"current_password":"password1234","new_password":"short"}`,
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputPasswordChange.NewPassword' Error:Field validation for 'NewPassword' failed on the 'min' tag"}`,
+		},
+		"Delete account": {
+			method:    http.MethodDelete,
+			path:      "/me",
+			inputBody: `{"password":"password1234"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().DeleteAccount(accountID, "password1234").Return(core.ErasureReport{
+					AccountID: accountID, Sessions: 2, Lists: 3, ListMovies: 5, Ratings: 1, Identities: 1, OneTimeCodes: 1,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"account_id":"` + accountID + `","sessions":2,"lists":3,"list_movies":5,` +
+				`"ratings":1,"identities":1,"one_time_codes":1}`,
+		},
+		"Delete account failed": {
+			method:    http.MethodDelete,
+			path:      "/me",
+			inputBody: `{"password":"password1234"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().DeleteAccount(accountID, "password1234").Return(core.ErasureReport{}, errors.New("db is down"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"db is down"}`,
+		},
+		"Delete account with wrong password": {
+			method:    http.MethodDelete,
+			path:      "/me",
+			inputBody: `{"password":"password0000"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().DeleteAccount(accountID, "password0000").Return(core.ErasureReport{}, core.ErrWrongPassword)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"wrong passord"}`,
+		},
+		"Export account": {
+			method: http.MethodGet,
+			path:   "/me/export",
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ExportAccount(accountID).Return(core.AccountExport{
+					Exported: created,
+					Account:  account,
+					Sessions: []core.SessionInfo{},
+					Lists:    []core.ListExport{},
+					Ratings:  []core.Rating{{AccountID: accountID, MovieID: "movie-1", Score: 7.5}},
+					Identities: []core.ExportedIdentity{
+						{Issuer: "https://sso.example.com", Subject: "subject-111", Created: created},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"exported":"2023-05-01T10:00:00Z","account":` + accountJSON + `,"sessions":[],` +
+				`"lists":[],"ratings":[{"account_id":"` + accountID + `","movie_id":"movie-1","score":7.5,` +
+				`"created":"","modified":""}],"identities":[{"issuer":"https://sso.example.com",` +
+				`"subject":"subject-111","created":"2023-05-01T10:00:00Z"}]}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountService := NewMockAccountService(ctrl)
+			testCase.mockBehavior(accountService)
+
+			accountHandler := AccountHandler{service: accountService, logger: log}
+
+			setIdentity := func(c *gin.Context) {
+				c.Set(userCtx, accountID)
+				c.Set(refreshTokenCtx, "refresh-111")
+			}
+
+			router := gin.New()
+			me := router.Group("/me", setIdentity)
+			me.GET("", accountHandler.profile)
+			me.PATCH("", accountHandler.updateProfile)
+			me.PUT("/password", accountHandler.changePassword)
+			me.DELETE("", accountHandler.deleteAccount)
+			me.GET("/export", accountHandler.exportAccount)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.inputBody))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedRetryAfter, w.Header().Get("Retry-After"))
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
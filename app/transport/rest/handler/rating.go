@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RatingHandler struct {
+	service RatingService
+	logger  *logger.Logger
+}
+
+func NewRatingHandler(s RatingService, log *logger.Logger) RatingHandler {
+	return RatingHandler{
+		service: s,
+		logger:  log,
+	}
+}
+
+type inputRating struct {
+	Score *float64 `json:"score" binding:"required"`
+}
+
+// Returns the score which the authenticated account gave to the movie.
+func (h *RatingHandler) get(c *gin.Context) {
+	accountID, movieID, ok := h.parseRatingKey(c)
+	if !ok {
+		return
+	}
+
+	rating, err := h.service.Get(accountID, movieID)
+	if err != nil {
+		if errors.Is(err, core.ErrRatingNotFound) {
+			h.logger.Debugw("Get rating", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("Get rating", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rating)
+}
+
+// Puts the score of the authenticated account to the movie or changes the existing one.
+// The body example: {"score": 7.9}.
+func (h *RatingHandler) put(c *gin.Context) {
+	accountID, movieID, ok := h.parseRatingKey(c)
+	if !ok {
+		return
+	}
+
+	var input inputRating
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("Put rating -> ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	rating, err := h.service.Rate(core.Rating{
+		AccountID: accountID,
+		MovieID:   movieID,
+		Score:     *input.Score,
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrUnallowedScore) {
+			h.logger.Debugw("Put rating", "error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		if errors.Is(err, core.ErrNotFound) {
+			h.logger.Debugw("Put rating", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("Put rating", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rating)
+}
+
+// Removes the score of the authenticated account from the movie.
+func (h *RatingHandler) delete(c *gin.Context) {
+	accountID, movieID, ok := h.parseRatingKey(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(accountID, movieID); err != nil {
+		if errors.Is(err, core.ErrRatingNotFound) || errors.Is(err, core.ErrNotFound) {
+			h.logger.Debugw("Delete rating", "error", err.Error())
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.logger.Errorw("Delete rating", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Takes the account ID from the context and the movie ID from the path.
+// Writes the response and returns false if any of them is wrong.
+func (h *RatingHandler) parseRatingKey(c *gin.Context) (string, string, bool) {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		h.logger.Debugw("getAccountID", "error", err.Error())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+		return "", "", false
+	}
+
+	movieID := c.Param("id")
+
+	if _, err := uuid.Parse(movieID); err != nil {
+		h.logger.Debugw("ID is not UUID", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return "", "", false
+	}
+
+	return accountID, movieID, true
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRating_put(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		accountID = "8c172d76-f750-4369-a5e2-27c877299168"
+		movieID   = "6b823d5e-3d37-4617-a568-226e2e31a4f4"
+	)
+
+	type mockBehavior func(s *MockRatingService)
+
+	testCasesTable := map[string]struct {
+		movieID              string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Successful case": {
+			movieID:   movieID,
+			inputBody: `{"score":7.9}`,
+			mockBehavior: func(s *MockRatingService) {
+				s.EXPECT().Rate(core.Rating{AccountID: accountID, MovieID: movieID, Score: 7.9}).Return(
+					core.Rating{AccountID: accountID, MovieID: movieID, Score: 7.9}, nil).Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"account_id":"8c172d76-f750-4369-a5e2-27c877299168",` +
+				`"movie_id":"6b823d5e-3d37-4617-a568-226e2e31a4f4","score":7.9,"created":"","modified":""}`,
+		},
+		"Zero score is allowed": {
+			movieID:   movieID,
+			inputBody: `{"score":0}`,
+			mockBehavior: func(s *MockRatingService) {
+				s.EXPECT().Rate(core.Rating{AccountID: accountID, MovieID: movieID, Score: 0}).Return(
+					core.Rating{AccountID: accountID, MovieID: movieID}, nil).Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"account_id":"8c172d76-f750-4369-a5e2-27c877299168",` +
+				`"movie_id":"6b823d5e-3d37-4617-a568-226e2e31a4f4","score":0,"created":"","modified":""}`,
+		},
+		"No score": {
+			movieID:              movieID,
+			inputBody:            `{}`,
+			mockBehavior:         func(s *MockRatingService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputRating.Score' Error:Field validation for 'Score' failed on the 'required' tag"}`,
+		},
+		"Wrong movie ID": {
+			movieID:              "wrong-id",
+			inputBody:            `{"score":7}`,
+			mockBehavior:         func(s *MockRatingService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid UUID length: 8"}`,
+		},
+		"Unallowed score": {
+			movieID:   movieID,
+			inputBody: `{"score":7.95}`,
+			mockBehavior: func(s *MockRatingService) {
+				s.EXPECT().Rate(gomock.Any()).Return(core.Rating{}, core.ErrUnallowedScore).Times(1)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the score should be in range from 0 to 10 with one decimal place"}`,
+		},
+		"Movie not found": {
+			movieID:   movieID,
+			inputBody: `{"score":7}`,
+			mockBehavior: func(s *MockRatingService) {
+				s.EXPECT().Rate(gomock.Any()).Return(core.Rating{}, core.ErrNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"nothing was found"}`,
+		},
+		"Internal error": {
+			movieID:   movieID,
+			inputBody: `{"score":7}`,
+			mockBehavior: func(s *MockRatingService) {
+				s.EXPECT().Rate(gomock.Any()).Return(core.Rating{}, errors.New("some error")).Times(1)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"some error"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ratingService := NewMockRatingService(ctrl)
+			testCase.mockBehavior(ratingService)
+
+			rh := NewRatingHandler(ratingService, log)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			r.PUT("/movie/:id/rating", func(c *gin.Context) {
+				c.Set(userCtx, accountID)
+			}, rh.put)
+
+			req := httptest.NewRequest(http.MethodPut, "/movie/"+testCase.movieID+"/rating",
+				strings.NewReader(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestRating_delete(t *testing.T) {
+	log, err := logger.New("INFO")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const (
+		accountID = "8c172d76-f750-4369-a5e2-27c877299168"
+		movieID   = "6b823d5e-3d37-4617-a568-226e2e31a4f4"
+	)
+
+	type mockBehavior func(s *MockRatingService)
+
+	testCasesTable := map[string]struct {
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Successful case": {
+			mockBehavior: func(s *MockRatingService) {
+				s.EXPECT().Delete(accountID, movieID).Return(nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Not rated": {
+			mockBehavior: func(s *MockRatingService) {
+				s.EXPECT().Delete(accountID, movieID).Return(core.ErrRatingNotFound).Times(1)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"no rating found"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ratingService := NewMockRatingService(ctrl)
+			testCase.mockBehavior(ratingService)
+
+			rh := NewRatingHandler(ratingService, log)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			r.DELETE("/movie/:id/rating", func(c *gin.Context) {
+				c.Set(userCtx, accountID)
+			}, rh.delete)
+
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/movie/"+movieID+"/rating", nil))
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
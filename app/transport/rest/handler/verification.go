@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/gin-gonic/gin"
+)
+
+type inputPhone struct {
+	Phone string `json:"phone" binding:"required,e164"`
+}
+
+type inputPhoneCode struct {
+	Phone string `json:"phone" binding:"required,e164"`
+	Code  string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type inputPasswordReset struct {
+	Phone       string `json:"phone" binding:"required,e164"`
+	Code        string `json:"code" binding:"required,len=6,numeric"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=255,ascii"`
+}
+
+// Sends the phone verification code. The response is the same whether the phone is registered or not.
+func (h AccountHandler) requestPhoneVerification(c *gin.Context) {
+	var input inputPhone
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.RequestPhoneVerification(input.Phone); err != nil {
+		h.respondCodeError(c, "RequestPhoneVerification", err)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"action": "successful"})
+}
+
+func (h AccountHandler) confirmPhone(c *gin.Context) {
+	var input inputPhoneCode
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.ConfirmPhone(input.Phone, input.Code); err != nil {
+		h.respondCodeError(c, "ConfirmPhone", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Sends the password reset code. The response is the same whether the phone is registered or not.
+func (h AccountHandler) requestPasswordReset(c *gin.Context) {
+	var input inputPhone
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.RequestPasswordReset(input.Phone); err != nil {
+		h.respondCodeError(c, "RequestPasswordReset", err)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"action": "successful"})
+}
+
+func (h AccountHandler) confirmPasswordReset(c *gin.Context) {
+	var input inputPasswordReset
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.logger.Debugw("ShouldBindJSON", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := h.service.ResetPassword(input.Phone, input.Code, input.NewPassword); err != nil {
+		h.respondCodeError(c, "ResetPassword", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": "successful"})
+}
+
+// Writes the response with the status code which corresponds to the error of the one-time code.
+func (h AccountHandler) respondCodeError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, core.ErrInvalidCode):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": core.ErrInvalidCode.Error()})
+	case errors.Is(err, core.ErrCodeExpired):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": core.ErrCodeExpired.Error()})
+	case errors.Is(err, core.ErrTooManyAttempts):
+		h.logger.Warnw(operation, "error", err.Error())
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": core.ErrTooManyAttempts.Error()})
+	case errors.Is(err, core.ErrPasswordTooLong):
+		h.logger.Debugw(operation, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": core.ErrPasswordTooLong.Error()})
+	default:
+		h.logger.Errorw(operation, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHandler_verification(t *testing.T) {
+	log, err := logger.New("ERROR")
+	if err != nil {
+		t.FailNow()
+	}
+
+	const phone = "+380999999999"
+
+	type mockBehavior func(s *MockAccountService)
+
+	testCasesTable := map[string]struct {
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		"Request phone verification": {
+			path:      "/auth/verify-phone",
+			inputBody: `{"phone":"` + phone + `"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().RequestPhoneVerification(phone).Return(nil)
+			},
+			expectedStatusCode:   http.StatusAccepted,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Request phone verification with wrong phone": {
+			path:                 "/auth/verify-phone",
+			inputBody:            `{"phone":"380999999999"}`,
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputPhone.Phone' Error:Field validation for 'Phone' failed on the 'e164' tag"}`,
+		},
+		"Confirm phone": {
+			path:      "/auth/verify-phone/confirm",
+			inputBody: `{"phone":"` + phone + `","code":"123456"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ConfirmPhone(phone, "123456").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Confirm phone with wrong code": {
+			path:      "/auth/verify-phone/confirm",
+			inputBody: `{"phone":"` + phone + `","code":"654321"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ConfirmPhone(phone, "654321").
+					Return(fmt.Errorf("service ConfirmPhone got the error: %w", core.ErrInvalidCode))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the code is invalid"}`,
+		},
+		"Confirm phone with not numeric code": {
+			path:                 "/auth/verify-phone/confirm",
+			inputBody:            `{"phone":"` + phone + `","code":"12345a"}`,
+			mockBehavior:         func(s *MockAccountService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Key: 'inputPhoneCode.Code' Error:Field validation for 'Code' failed on the 'numeric' tag"}`,
+		},
+		"Request password reset": {
+			path:      "/auth/password-reset/request",
+			inputBody: `{"phone":"` + phone + `"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().RequestPasswordReset(phone).Return(nil)
+			},
+			expectedStatusCode:   http.StatusAccepted,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Request password reset failed": {
+			path:      "/auth/password-reset/request",
+			inputBody: `{"phone":"` + phone + `"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().RequestPasswordReset(phone).Return(errors.New("sms gateway is down"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"sms gateway is down"}`,
+		},
+		"Confirm password reset": {
+			path:      "/auth/password-reset/confirm",
+			inputBody: `{"phone":"` + phone + `","code":"123456","new_password":"password5678"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ResetPassword(phone, "123456", "password5678").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"action":"successful"}`,
+		},
+		"Confirm password reset after too many attempts": {
+			path:      "/auth/password-reset/confirm",
+			inputBody: `{"phone":"` + phone + `","code":"123456","new_password":"password5678"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ResetPassword(phone, "123456", "password5678").
+					Return(fmt.Errorf("service ResetPassword got the error: %w", core.ErrTooManyAttempts))
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"error":"too many attempts, request a new code"}`,
+		},
+		"Confirm password reset with expired code": {
+			path:      "/auth/password-reset/confirm",
+			inputBody: `{"phone":"` + phone + `","code":"123456","new_password":"password5678"}`,
+			mockBehavior: func(s *MockAccountService) {
+				s.EXPECT().ResetPassword(phone, "123456", "password5678").
+					Return(fmt.Errorf("service ResetPassword got the error: %w", core.ErrCodeExpired))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"the code is expired"}`,
+		},
+	}
+
+	for name, testCase := range testCasesTable {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountService := NewMockAccountService(ctrl)
+			testCase.mockBehavior(accountService)
+
+			accountHandler := AccountHandler{service: accountService, logger: log}
+
+			router := gin.New()
+			router.POST("/auth/verify-phone", accountHandler.requestPhoneVerification)
+			router.POST("/auth/verify-phone/confirm", accountHandler.confirmPhone)
+			router.POST("/auth/password-reset/request", accountHandler.requestPasswordReset)
+			router.POST("/auth/password-reset/confirm", accountHandler.confirmPasswordReset)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, testCase.path, strings.NewReader(testCase.inputBody))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -1,17 +1,34 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/Brigant/PetPorject/app/core"
+	"github.com/Brigant/PetPorject/app/hasher"
+	"github.com/Brigant/PetPorject/app/keyring"
+	"github.com/Brigant/PetPorject/app/oidc"
+	"github.com/Brigant/PetPorject/app/repositorie/blob"
+	"github.com/Brigant/PetPorject/app/repositorie/memory"
 	"github.com/Brigant/PetPorject/app/repositorie/pg"
 	"github.com/Brigant/PetPorject/app/service"
+	"github.com/Brigant/PetPorject/app/sms"
 	"github.com/Brigant/PetPorject/app/transport/rest/handler"
 	"github.com/Brigant/PetPorject/config"
 	"github.com/Brigant/PetPorject/logger"
 	_ "github.com/lib/pq" // the blank import is needed beceause of sqlx requirements
 )
 
+var (
+	errUnknownBlobDriver = errors.New("unknown blob driver")
+	errUnknownSMSDriver  = errors.New("unknown sms driver")
+	errUnknownStorage    = errors.New("unknown storage")
+)
+
 type Server struct {
 	httpServer *http.Server
 }
@@ -47,24 +64,85 @@ func SetupAndRun() error {
 
 	storage := pg.NewRepository(db)
 
+	blobStore, err := newBlobStore(cfg.Blob)
+	if err != nil {
+		return fmt.Errorf("error while creating blob store: %w", err)
+	}
+
+	passwordHasher, err := hasher.New(hasher.Config{
+		Algorithm:  cfg.Password.Algorithm,
+		BcryptCost: cfg.Password.BcryptCost,
+		Argon2:     hasher.Argon2Params(cfg.Password.Argon2),
+	}, cfg.Salt)
+	if err != nil {
+		return fmt.Errorf("error while creating password hasher: %w", err)
+	}
+
+	tokenKeys, err := newKeyring(cfg.JWT, cfg.SigningKey)
+	if err != nil {
+		return fmt.Errorf("error while loading jwt keys: %w", err)
+	}
+
+	smsSender, err := newSMSSender(cfg.SMS, logger)
+	if err != nil {
+		return fmt.Errorf("error while creating sms sender: %w", err)
+	}
+
+	loginAttempts, err := newLoginAttemptStorage(cfg.LoginThrottle, storage)
+	if err != nil {
+		return fmt.Errorf("error while creating login attempts storage: %w", err)
+	}
+
+	roles, err := newRolePermissions(cfg.Roles)
+	if err != nil {
+		return fmt.Errorf("error while reading roles: %w", err)
+	}
+
 	services := service.New(
 		service.Deps{
 			AccountStorage:  storage.AccountDB,
 			DirectorStorage: storage.DirectorDB,
 			MovieStorage:    storage.MovieDB,
 			ListSorage:      storage.ListDB,
+			RatingStorage:   storage.RatingDB,
+			APIKeyStorage:   storage.APIKeyDB,
+			BlobStore:       blobStore,
+			PasswordHasher:  passwordHasher,
+			TokenKeys:       tokenKeys,
+			SMSSender:       smsSender,
+			LoginAttempts:   loginAttempts,
+			OIDCProvider:    newOIDCProvider(cfg.OIDC, cfg.TokenLeeway),
 		}, cfg)
 
+	if cfg.BootstrapAdminPhone != "" {
+		promoted, err := services.Account.BootstrapAdmin(cfg.BootstrapAdminPhone)
+		if err != nil {
+			return fmt.Errorf("error while bootstrapping the admin: %w", err)
+		}
+
+		if promoted {
+			logger.Infow("the first admin is bootstrapped", "phone", cfg.BootstrapAdminPhone)
+		}
+	}
+
 	restHandlers := handler.NewHandler(
 		handler.Deps{
 			DirectorService: services.Director,
 			AccountService:  services.Account,
 			MovieService:    services.Movie,
 			ListService:     services.List,
+			RatingService:   services.Rating,
+			APIKeyService:   services.APIKey,
+			Roles:           roles,
+			TrustedProxies:  cfg.Server.TrustedProxies,
 		}, logger)
 
 	routes := restHandlers.InitRouter(cfg.Server.Mode)
 
+	if cfg.Blob.Driver == "local" && strings.HasPrefix(cfg.Blob.Local.URL, "/") {
+		routes.Static(cfg.Blob.Local.URL, cfg.Blob.Local.Dir)
+	}
+
 	server := NewServer(cfg.Server.Port, routes)
 
 	if err := server.httpServer.ListenAndServe(); err != nil {
@@ -73,3 +151,118 @@ func SetupAndRun() error {
 
 	return nil
 }
+
+// Returns the blob store defined by the driver in the config.
+func newBlobStore(cfg config.BlobConfig) (service.BlobStore, error) {
+	switch cfg.Driver {
+	case "local":
+		store, err := blob.NewLocalStore(cfg.Local.Dir, cfg.Local.URL)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create local store: %w", err)
+		}
+
+		return store, nil
+	case "s3":
+		return blob.NewS3Store(blob.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			PublicURL: cfg.S3.PublicURL,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownBlobDriver, cfg.Driver)
+	}
+}
+
+// Returns the keyring of the access tokens with the keys loaded from the PEM files.
+func newKeyring(cfg config.JWTConfig, legacySecret string) (keyring.Keyring, error) {
+	keys := make([]keyring.Key, 0, len(cfg.Keys))
+
+	for _, keyCfg := range cfg.Keys {
+		key := keyring.Key{ID: keyCfg.ID, Algorithm: keyCfg.Algorithm}
+
+		if keyCfg.PrivateKey != "" {
+			pem, err := os.ReadFile(keyCfg.PrivateKey)
+			if err != nil {
+				return keyring.Keyring{}, fmt.Errorf("cannot read the private key %q: %w", keyCfg.ID, err)
+			}
+
+			key.PrivateKeyPEM = pem
+		}
+
+		if keyCfg.PublicKey != "" {
+			pem, err := os.ReadFile(keyCfg.PublicKey)
+			if err != nil {
+				return keyring.Keyring{}, fmt.Errorf("cannot read the public key %q: %w", keyCfg.ID, err)
+			}
+
+			key.PublicKeyPEM = pem
+		}
+
+		keys = append(keys, key)
+	}
+
+	tokenKeys, err := keyring.New(cfg.SigningKeyID, keys, legacySecret)
+	if err != nil {
+		return keyring.Keyring{}, fmt.Errorf("cannot create keyring: %w", err)
+	}
+
+	return tokenKeys, nil
+}
+
+// Returns the role permissions from the config or the default ones if the config doesn't define them.
+func newRolePermissions(roles map[string][]string) (core.RolePermissions, error) {
+	if len(roles) == 0 {
+		return core.DefaultRolePermissions(), nil
+	}
+
+	rolePermissions, err := core.NewRolePermissions(roles)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create role permissions: %w", err)
+	}
+
+	return rolePermissions, nil
+}
+
+// Returns the sms sender defined by the driver in the config.
+func newSMSSender(cfg config.SMSConfig, log *logger.Logger) (service.SMSSender, error) {
+	switch cfg.Driver {
+	case "log":
+		return sms.NewLogSender(log), nil
+	case "file":
+		return sms.NewFileSender(cfg.File), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownSMSDriver, cfg.Driver)
+	}
+}
+
+// Returns the storage of the failed logins defined in the config.
+func newLoginAttemptStorage(cfg config.LoginThrottleConfig, repository pg.Repository,
+) (service.LoginAttemptStorage, error) {
+	switch cfg.Storage {
+	case "memory":
+		return memory.NewLoginAttemptStore(), nil
+	case "postgres":
+		return repository.LoginAttemptDB, nil
+	default:
+		return nil, fmt.Errorf("%w of the login attempts: %q", errUnknownStorage, cfg.Storage)
+	}
+}
+
+// Returns the client of the identity provider, it is nil if the single sign-on is not configured.
+func newOIDCProvider(cfg config.OIDCConfig, leeway time.Duration) service.OIDCProvider {
+	if cfg.Issuer == "" {
+		return nil
+	}
+
+	return oidc.NewProvider(oidc.Config{
+		Issuer:       cfg.Issuer,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+		Leeway:       leeway,
+	}, nil)
+}
@@ -12,6 +12,9 @@ import (
 type ServerConfig struct {
 	Mode string
 	Port string
+	// The proxies whose X-Forwarded-For and X-Real-IP headers are trusted for the client IP.
+	// No proxy is trusted if it is empty, so the client IP is the address of the connection.
+	TrustedProxies []string
 }
 
 type PostgresConfig struct {
@@ -23,16 +26,126 @@ type PostgresConfig struct {
 	SSLmode  string
 }
 
+type BlobConfig struct {
+	// The storage of the uploaded files. Available values: "local", "s3".
+	Driver string
+	Local  LocalBlobConfig
+	S3     S3BlobConfig
+}
+
+type LocalBlobConfig struct {
+	Dir string
+	URL string
+}
+
+type S3BlobConfig struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PublicURL string
+}
+
+type SMSConfig struct {
+	// The way the messages are delivered. Available values: "log", "file".
+	Driver string
+	// The file the messages are appended to by the "file" driver.
+	File string
+}
+
+type OneTimeCodeConfig struct {
+	TTL         time.Duration
+	MaxAttempts int
+	// The new code is not sent until this period has passed since the previous one.
+	ResendInterval time.Duration
+}
+
+type LoginThrottleConfig struct {
+	// Where the failed logins are kept. Available values: "memory", "postgres".
+	Storage string
+	// The number of the failures allowed before the phone or the client IP is locked.
+	AccountAttempts int
+	IPAttempts      int
+	// The first lock lasts BaseLockout and every next failure doubles it up to MaxLockout.
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	// The failures older than the window are forgotten.
+	Window time.Duration
+}
+
+type OIDCConfig struct {
+	// The single sign-on is disabled if the issuer is empty.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// The callback of the server, it must be registered at the provider.
+	RedirectURL string
+	Scopes      []string
+	// The time to finish the login at the provider.
+	LoginTTL time.Duration
+}
+
+type PasswordConfig struct {
+	// The algorithm of the new password hashes. Available values: "argon2id", "bcrypt".
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Config
+}
+
+type Argon2Config struct {
+	// The memory in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+type JWTConfig struct {
+	// The id of the key the access tokens are signed with.
+	// The tokens are signed by HS256 with the signing_key if it is empty.
+	SigningKeyID string
+	Keys         []JWTKeyConfig
+}
+
+type JWTKeyConfig struct {
+	ID string `mapstructure:"id"`
+	// Available values: "RS256", "EdDSA".
+	Algorithm string `mapstructure:"algorithm"`
+	// The paths to the PEM files. The key without the private part only verifies the tokens.
+	PrivateKey string `mapstructure:"private_key"`
+	PublicKey  string `mapstructure:"public_key"`
+}
+
 type Config struct {
 	LogLevel string
 	// AccessTokenTTL  int
 	// RefreshTokenTTL int
-	Server          ServerConfig
-	DB              PostgresConfig
+	Server   ServerConfig
+	DB       PostgresConfig
+	Blob     BlobConfig
+	Password PasswordConfig
+	JWT      JWTConfig
+	// The permissions granted to each role. The default roles are used if it is empty.
+	Roles           map[string][]string
 	Salt            string
 	SigningKey      string
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
+	// How long the liveness of the session is cached. The revoked session is rejected
+	// by the other instances of the server only after this period.
+	SessionCacheTTL time.Duration
+	// The allowed clock skew while validating the time claims of the access token.
+	TokenLeeway time.Duration
+	// The phone of the account which becomes the admin at the startup if there is no admin yet.
+	BootstrapAdminPhone string
+	SMS                 SMSConfig
+	OneTimeCode         OneTimeCodeConfig
+	// Whether the login is allowed only after the phone is verified.
+	RequirePhoneVerification bool
+	LoginThrottle            LoginThrottleConfig
+	OIDC                     OIDCConfig
 }
 
 // Allowed logger levels & config key.
@@ -48,6 +161,32 @@ func InitConfig(path string) (Config, error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("config")
 
+	viper.SetDefault("blob.driver", "local")
+	viper.SetDefault("blob.local.dir", "./photos")
+	viper.SetDefault("blob.local.url", "/photos")
+	viper.SetDefault("session_cache_ttl", 30)
+	viper.SetDefault("token_leeway", 30)
+	viper.SetDefault("sms.driver", "log")
+	viper.SetDefault("sms.file", "./sms.jsonl")
+	viper.SetDefault("one_time_code.ttl", 10)
+	viper.SetDefault("one_time_code.max_attempts", 5)
+	viper.SetDefault("one_time_code.resend_interval", 60)
+	viper.SetDefault("login_throttle.storage", "memory")
+	viper.SetDefault("login_throttle.account_attempts", 5)
+	viper.SetDefault("login_throttle.ip_attempts", 20)
+	viper.SetDefault("login_throttle.base_lockout", 30)
+	viper.SetDefault("login_throttle.max_lockout", 15)
+	viper.SetDefault("login_throttle.window", 15)
+	viper.SetDefault("oidc.scopes", []string{"openid", "phone"})
+	viper.SetDefault("oidc.login_ttl", 10)
+	viper.SetDefault("password.algorithm", "argon2id")
+	viper.SetDefault("password.bcrypt_cost", 12)
+	viper.SetDefault("password.argon2.memory", 64*1024)
+	viper.SetDefault("password.argon2.iterations", 3)
+	viper.SetDefault("password.argon2.parallelism", 2)
+	viper.SetDefault("password.argon2.salt_length", 16)
+	viper.SetDefault("password.argon2.key_length", 32)
+
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(`.`, `_`))
 
@@ -60,20 +199,63 @@ func InitConfig(path string) (Config, error) {
 		return Config{}, fmt.Errorf("error while cheking allowed loging leveles: %w", err)
 	}
 
+	var jwtKeys []JWTKeyConfig
+
+	if err := viper.UnmarshalKey("jwt.keys", &jwtKeys); err != nil {
+		return Config{}, fmt.Errorf("error while reading jwt keys: %w", err)
+	}
+
+	var roles map[string][]string
+
+	if err := viper.UnmarshalKey("roles", &roles); err != nil {
+		return Config{}, fmt.Errorf("error while reading roles: %w", err)
+	}
+
 	accessTTL := viper.GetInt("access_token_ttl")
 	refreshTTL := viper.GetInt("refresh_token_ttl")
 	salt := viper.GetString("salt")
 	signingKey := viper.GetString("signing_key")
 
 	cfg := Config{
-		LogLevel:        loglevel,
-		AccessTokenTTL:  time.Duration(accessTTL) * time.Minute,
-		RefreshTokenTTL: time.Duration(refreshTTL) * time.Hour,
-		Salt:            salt,
-		SigningKey:      signingKey,
+		LogLevel:            loglevel,
+		AccessTokenTTL:      time.Duration(accessTTL) * time.Minute,
+		RefreshTokenTTL:     time.Duration(refreshTTL) * time.Hour,
+		SessionCacheTTL:     time.Duration(viper.GetInt("session_cache_ttl")) * time.Second,
+		TokenLeeway:         time.Duration(viper.GetInt("token_leeway")) * time.Second,
+		Salt:                salt,
+		SigningKey:          signingKey,
+		BootstrapAdminPhone: viper.GetString("bootstrap_admin_phone"),
+		Roles:               roles,
+		SMS: SMSConfig{
+			Driver: viper.GetString("sms.driver"),
+			File:   viper.GetString("sms.file"),
+		},
+		OneTimeCode: OneTimeCodeConfig{
+			TTL:            time.Duration(viper.GetInt("one_time_code.ttl")) * time.Minute,
+			MaxAttempts:    viper.GetInt("one_time_code.max_attempts"),
+			ResendInterval: time.Duration(viper.GetInt("one_time_code.resend_interval")) * time.Second,
+		},
+		RequirePhoneVerification: viper.GetBool("require_phone_verification"),
+		LoginThrottle: LoginThrottleConfig{
+			Storage:         viper.GetString("login_throttle.storage"),
+			AccountAttempts: viper.GetInt("login_throttle.account_attempts"),
+			IPAttempts:      viper.GetInt("login_throttle.ip_attempts"),
+			BaseLockout:     time.Duration(viper.GetInt("login_throttle.base_lockout")) * time.Second,
+			MaxLockout:      time.Duration(viper.GetInt("login_throttle.max_lockout")) * time.Minute,
+			Window:          time.Duration(viper.GetInt("login_throttle.window")) * time.Minute,
+		},
+		OIDC: OIDCConfig{
+			Issuer:       viper.GetString("oidc.issuer"),
+			ClientID:     viper.GetString("oidc.client_id"),
+			ClientSecret: viper.GetString("oidc.client_secret"),
+			RedirectURL:  viper.GetString("oidc.redirect_url"),
+			Scopes:       viper.GetStringSlice("oidc.scopes"),
+			LoginTTL:     time.Duration(viper.GetInt("oidc.login_ttl")) * time.Minute,
+		},
 		Server: ServerConfig{
-			Mode: viper.GetString("server.mode"),
-			Port: viper.GetString("server.port"),
+			Mode:           viper.GetString("server.mode"),
+			Port:           viper.GetString("server.port"),
+			TrustedProxies: viper.GetStringSlice("server.trusted_proxies"),
 		},
 		DB: PostgresConfig{
 			Host:     viper.GetString("db.host"),
@@ -83,6 +265,36 @@ func InitConfig(path string) (Config, error) {
 			Password: viper.GetString("db.password"),
 			SSLmode:  viper.GetString("db.sslmode"),
 		},
+		JWT: JWTConfig{
+			SigningKeyID: viper.GetString("jwt.signing_key_id"),
+			Keys:         jwtKeys,
+		},
+		Password: PasswordConfig{
+			Algorithm:  viper.GetString("password.algorithm"),
+			BcryptCost: viper.GetInt("password.bcrypt_cost"),
+			Argon2: Argon2Config{
+				Memory:      viper.GetUint32("password.argon2.memory"),
+				Iterations:  viper.GetUint32("password.argon2.iterations"),
+				Parallelism: uint8(viper.GetUint("password.argon2.parallelism")),
+				SaltLength:  viper.GetUint32("password.argon2.salt_length"),
+				KeyLength:   viper.GetUint32("password.argon2.key_length"),
+			},
+		},
+		Blob: BlobConfig{
+			Driver: viper.GetString("blob.driver"),
+			Local: LocalBlobConfig{
+				Dir: viper.GetString("blob.local.dir"),
+				URL: viper.GetString("blob.local.url"),
+			},
+			S3: S3BlobConfig{
+				Endpoint:  viper.GetString("blob.s3.endpoint"),
+				Region:    viper.GetString("blob.s3.region"),
+				Bucket:    viper.GetString("blob.s3.bucket"),
+				AccessKey: viper.GetString("blob.s3.access_key"),
+				SecretKey: viper.GetString("blob.s3.secret_key"),
+				PublicURL: viper.GetString("blob.s3.public_url"),
+			},
+		},
 	}
 
 	return cfg, nil
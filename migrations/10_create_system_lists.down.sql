@@ -0,0 +1,3 @@
+DELETE FROM public.list AS l
+WHERE l.type IN ('favorite', 'wishlist')
+	AND NOT EXISTS (SELECT 1 FROM public.movie_list AS ml WHERE ml.list_id=l.id);
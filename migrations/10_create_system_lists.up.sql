@@ -0,0 +1,5 @@
+INSERT INTO public.list(account_id, type)
+	SELECT a.id, t.type
+	FROM public.account AS a
+	CROSS JOIN (VALUES ('favorite'), ('wishlist')) AS t(type)
+ON CONFLICT (account_id, type) DO NOTHING;
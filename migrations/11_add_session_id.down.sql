@@ -0,0 +1,3 @@
+ALTER TABLE public."session"
+	DROP CONSTRAINT "session_id_unique",
+	DROP COLUMN "id";
@@ -0,0 +1,3 @@
+ALTER TABLE public."session"
+	ADD "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+	ADD CONSTRAINT "session_id_unique" UNIQUE (id);
@@ -0,0 +1,5 @@
+DROP TABLE public."retired_refresh_token";
+
+ALTER TABLE public."session"
+	DROP CONSTRAINT "session_family_id_unique",
+	DROP COLUMN "family_id";
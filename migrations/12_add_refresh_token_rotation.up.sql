@@ -0,0 +1,15 @@
+ALTER TABLE public."session"
+	ADD "family_id" uuid NOT NULL DEFAULT gen_random_uuid(),
+	ADD CONSTRAINT "session_family_id_unique" UNIQUE (family_id);
+
+-- The refresh tokens which were replaced by the rotation. Presenting one of them again
+-- means the token was stolen, so the whole family is revoked.
+CREATE TABLE public."retired_refresh_token" (
+	"refresh_token" uuid NOT NULL,
+	"family_id" uuid NOT NULL,
+	"account_id" uuid NOT NULL,
+	"retired" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "retired_refresh_token_pk" PRIMARY KEY (refresh_token),
+	CONSTRAINT "retired_refresh_token_fk" FOREIGN KEY (family_id)
+		REFERENCES public."session"(family_id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE public.account
+	DROP COLUMN "disabled";
@@ -0,0 +1,2 @@
+ALTER TABLE public.account
+	ADD "disabled" BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+DROP TABLE public."one_time_code";
+
+ALTER TABLE public.account
+	DROP COLUMN "phone_verified";
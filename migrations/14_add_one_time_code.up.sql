@@ -0,0 +1,16 @@
+ALTER TABLE public.account
+	ADD "phone_verified" BOOLEAN NOT NULL DEFAULT false;
+
+-- The codes sent by SMS to prove the account owns the phone. Only the hash of the code is kept,
+-- the account has at most one code of each purpose.
+CREATE TABLE public."one_time_code" (
+	"account_id" uuid NOT NULL,
+	"purpose" varchar(50) NOT NULL,
+	"code_hash" varchar(255) NOT NULL,
+	"attempts" INT NOT NULL DEFAULT 0,
+	"expired" timestamp with time zone NOT NULL,
+	"created" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "one_time_code_pk" PRIMARY KEY (account_id, purpose),
+	CONSTRAINT "one_time_code_account_id_fk" FOREIGN KEY (account_id)
+		REFERENCES public.account(id) ON DELETE CASCADE
+);
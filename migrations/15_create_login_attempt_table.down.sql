@@ -0,0 +1 @@
+DROP TABLE public."login_attempt";
@@ -0,0 +1,8 @@
+-- The failed logins counted per phone and per client IP to slow down the password guessing.
+CREATE TABLE public."login_attempt" (
+	"key" varchar(255) NOT NULL,
+	"failures" INT NOT NULL DEFAULT 0,
+	"last_failure" timestamp with time zone NOT NULL DEFAULT now(),
+	"locked_until" timestamp with time zone,
+	CONSTRAINT "login_attempt_pk" PRIMARY KEY (key)
+);
@@ -0,0 +1,18 @@
+-- The keys of the services which call the API without the account login.
+-- Only the hash of the key is kept, the prefix lets the admin tell the keys apart.
+CREATE TABLE public."api_key" (
+	"id" uuid NOT NULL DEFAULT gen_random_uuid(),
+	"name" varchar(100) NOT NULL,
+	"prefix" varchar(20) NOT NULL,
+	"key_hash" varchar(255) NOT NULL,
+	"scopes" text[] NOT NULL DEFAULT '{}',
+	"created_by" uuid,
+	"expires" timestamp with time zone,
+	"last_used" timestamp with time zone,
+	"revoked" timestamp with time zone,
+	"created" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "api_key_pk" PRIMARY KEY (id),
+	CONSTRAINT "api_key_hash_unique" UNIQUE (key_hash),
+	CONSTRAINT "api_key_created_by_fk" FOREIGN KEY (created_by)
+		REFERENCES public.account(id) ON DELETE SET NULL
+);
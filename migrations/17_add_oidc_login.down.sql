@@ -0,0 +1,2 @@
+DROP TABLE public."oidc_login";
+DROP TABLE public."account_identity";
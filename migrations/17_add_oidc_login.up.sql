@@ -0,0 +1,20 @@
+-- The accounts of the OpenID Connect provider linked to the accounts.
+CREATE TABLE public."account_identity" (
+	"issuer" varchar(255) NOT NULL,
+	"subject" varchar(255) NOT NULL,
+	"account_id" uuid NOT NULL,
+	"created" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "account_identity_pk" PRIMARY KEY (issuer, subject),
+	CONSTRAINT "account_identity_account_id_fk" FOREIGN KEY (account_id)
+		REFERENCES public.account(id) ON DELETE CASCADE
+);
+
+-- The logins started at the identity provider and not finished by the callback yet.
+CREATE TABLE public."oidc_login" (
+	"state" varchar(100) NOT NULL,
+	"code_verifier" varchar(128) NOT NULL,
+	"nonce" varchar(100) NOT NULL,
+	"expired" timestamp with time zone NOT NULL,
+	"created" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "oidc_login_pk" PRIMARY KEY (state)
+);
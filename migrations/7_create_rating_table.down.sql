@@ -0,0 +1,5 @@
+ALTER TABLE public.movie
+	DROP COLUMN "avg_rate",
+	DROP COLUMN "votes";
+
+DROP TABLE "rating";
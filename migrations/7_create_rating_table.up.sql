@@ -0,0 +1,19 @@
+CREATE TABLE public.rating (
+	"account_id" uuid NOT NULL,
+	"movie_id" uuid NOT NULL,
+	"score" NUMERIC(3,1) NOT NULL,
+	"created" Timestamp With Time Zone NOT NULL DEFAULT NOW(),
+	"modified" Timestamp With Time Zone NOT NULL DEFAULT NOW(),
+	CONSTRAINT "rating_pk" PRIMARY KEY (account_id, movie_id),
+	CONSTRAINT "rating_score_range" CHECK (score >= 0 AND score <= 10),
+	CONSTRAINT "rating_account_id_fk" FOREIGN KEY (account_id) REFERENCES public.account(id),
+	CONSTRAINT "rating_movie_id_fk" FOREIGN KEY (movie_id) REFERENCES public.movie(id)
+);
+
+CREATE TRIGGER update_rating_modtime 
+BEFORE UPDATE ON "rating" 
+FOR EACH ROW EXECUTE PROCEDURE  update_modified_column();
+
+ALTER TABLE public.movie
+	ADD COLUMN "avg_rate" NUMERIC(3,1) NOT NULL DEFAULT 0,
+	ADD COLUMN "votes" INT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP INDEX "unique_director_name_birth_date";
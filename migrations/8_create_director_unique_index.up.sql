@@ -0,0 +1 @@
+CREATE UNIQUE INDEX "unique_director_name_birth_date" ON public.director (LOWER(name), birth_date);
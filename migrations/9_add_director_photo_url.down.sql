@@ -0,0 +1,2 @@
+ALTER TABLE public.director
+	DROP COLUMN "photo_url";
@@ -0,0 +1,2 @@
+ALTER TABLE public.director
+	ADD "photo_url" VARCHAR(2048) NOT NULL DEFAULT '';